@@ -1,72 +1,93 @@
 package acceptance
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/enrichment"
 	"github.com/drewfead/pdx-watcher/internal/root"
 	"github.com/drewfead/pdx-watcher/internal/scraper"
 	"github.com/drewfead/pdx-watcher/proto"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
-func TestAcceptance_ListShowtimes(t *testing.T) {
-	type siteCase struct {
-		goldenDir string
-		site      proto.PdxSite
-		fromFlag  string
-		golden    func() internal.GoldenScraper
-		withTest  func(url string, client *http.Client) internal.Scraper
-	}
+// goldenDir is the root holding one golden fixture directory per scraper. Point it at an
+// alternate fixture set to A/B parser changes: go test ./acceptance -golden-dir=/path/to/golden
+var goldenDir = flag.String("golden-dir", filepath.Join("..", "internal", "scraper", "golden"), "root directory of golden fixtures")
+
+type siteCase struct {
+	golden   string
+	site     proto.PdxSite
+	fromFlag string
+	scraper  func() internal.GoldenScraper
+	withTest func(url string, client *http.Client) internal.Scraper
+}
 
-	cases := []siteCase{
-		{
-			goldenDir: filepath.Join("..", "internal", "scraper", "golden", "hollywoodtheatre"),
-			site:      proto.PdxSite_HollywoodTheatre,
-			fromFlag:  "HollywoodTheatre",
-			golden:    func() internal.GoldenScraper { gs, _ := scraper.HollywoodTheatre().(internal.GoldenScraper)
-				return gs },
-			withTest: func(url string, client *http.Client) internal.Scraper {
-				return scraper.HollywoodTheatre(scraper.WithBaseURL(url), scraper.WithClient(client))
-			},
+var cases = []siteCase{
+	{
+		golden:   "hollywoodtheatre",
+		site:     proto.PdxSite_HollywoodTheatre,
+		fromFlag: "HollywoodTheatre",
+		scraper: func() internal.GoldenScraper {
+			gs, _ := scraper.HollywoodTheatre().(internal.GoldenScraper)
+			return gs
 		},
-		{
-			goldenDir: filepath.Join("..", "internal", "scraper", "golden", "cinemagic"),
-			site:      proto.PdxSite_Cinemagic,
-			fromFlag:  "Cinemagic",
-			golden:    func() internal.GoldenScraper { gs, _ := scraper.Cinemagic().(internal.GoldenScraper)
-				return gs },
-			withTest: func(url string, client *http.Client) internal.Scraper {
-				return scraper.Cinemagic(scraper.CinemagicWithBaseURL(url), scraper.CinemagicWithClient(client))
-			},
+		withTest: func(url string, client *http.Client) internal.Scraper {
+			return scraper.HollywoodTheatre(scraper.WithBaseURL(url), scraper.WithClient(client))
 		},
-		{
-			goldenDir: filepath.Join("..", "internal", "scraper", "golden", "cinema21"),
-			site:      proto.PdxSite_Cinema21,
-			fromFlag:  "Cinema21",
-			golden: func() internal.GoldenScraper {
-				gs, _ := scraper.Cinema21().(internal.GoldenScraper)
-				return gs
-			},
-			withTest: func(url string, client *http.Client) internal.Scraper {
-				return scraper.Cinema21(scraper.Cinema21WithBaseURL(url), scraper.Cinema21WithClient(client))
-			},
+	},
+	{
+		golden:   "cinemagic",
+		site:     proto.PdxSite_Cinemagic,
+		fromFlag: "Cinemagic",
+		scraper: func() internal.GoldenScraper {
+			gs, _ := scraper.Cinemagic().(internal.GoldenScraper)
+			return gs
 		},
-	}
+		withTest: func(url string, client *http.Client) internal.Scraper {
+			return scraper.Cinemagic(scraper.CinemagicWithBaseURL(url), scraper.CinemagicWithClient(client))
+		},
+	},
+	{
+		golden:   "cinema21",
+		site:     proto.PdxSite_Cinema21,
+		fromFlag: "Cinema21",
+		scraper: func() internal.GoldenScraper {
+			gs, _ := scraper.Cinema21().(internal.GoldenScraper)
+			return gs
+		},
+		withTest: func(url string, client *http.Client) internal.Scraper {
+			return scraper.Cinema21(scraper.Cinema21WithBaseURL(url), scraper.Cinema21WithClient(client))
+		},
+	},
+}
+
+// mountGoldenScraper serves tc's golden files from an httptest server and returns a scraper pointed at it.
+func mountGoldenScraper(t *testing.T, tc siteCase) internal.Scraper {
+	t.Helper()
+	gs := tc.scraper()
+	handler, err := gs.MountGolden(t.Context(), filepath.Join(*goldenDir, tc.golden))
+	require.NoError(t, err, "MountGolden")
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return tc.withTest(server.URL, server.Client())
+}
 
+func TestAcceptance_ListShowtimes(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.fromFlag, func(t *testing.T) {
-			gs := tc.golden()
-			handler, err := gs.MountGolden(t.Context(), tc.goldenDir)
-			require.NoError(t, err, "MountGolden")
-			server := httptest.NewServer(handler)
-			t.Cleanup(server.Close)
-
-			s := tc.withTest(server.URL, server.Client())
+			s := mountGoldenScraper(t, tc)
 			registry := scraper.NewRegistry(scraper.WithScraperForSite(tc.site, s))
 
 			outputFile := filepath.Join(t.TempDir(), "output.json")
@@ -91,3 +112,227 @@ func TestAcceptance_ListShowtimes(t *testing.T) {
 		})
 	}
 }
+
+func TestAcceptance_ListShowtimes_FromAll(t *testing.T) {
+	opts := make([]scraper.RegistryOption, 0, len(cases))
+	for _, tc := range cases {
+		opts = append(opts, scraper.WithScraperForSite(tc.site, mountGoldenScraper(t, tc)))
+	}
+	registry := scraper.NewRegistry(opts...)
+
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+
+	rootCmd, err := root.Root(t.Context(), root.WithRegistry(registry))
+	require.NoError(t, err, "Root")
+
+	err = rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "all",
+		"--after", "2026-02-01T00:00:00Z",
+		"--before", "2026-03-01T00:00:00Z",
+		"--format", "json",
+		"--output", outputFile,
+	})
+	require.NoError(t, err, "Run")
+
+	sites := make(map[proto.PdxSite]int)
+	for _, resp := range readJSONResponses(t, outputFile) {
+		sites[resp.GetSite()]++
+	}
+	t.Logf("items per site: %v", sites)
+	require.GreaterOrEqual(t, len(sites), 2, "--from all should return items from multiple sites")
+}
+
+// TestAcceptance_ListShowtimes_DefaultRegistry runs --from all through a registry shaped like the
+// CLI's default one (every site, the None placeholder, and the caching middleware) over golden data,
+// guarding the registry + interleaved + service composition end to end.
+func TestAcceptance_ListShowtimes_DefaultRegistry(t *testing.T) {
+	cached := scraper.Cached(64, 5*time.Minute, scraper.CacheWithJitter(0.1))
+	opts := []scraper.RegistryOption{scraper.WithScraperForSite(proto.PdxSite_None, scraper.None())}
+	for _, tc := range cases {
+		opts = append(opts, scraper.WithScraperForSite(tc.site, mountGoldenScraper(t, tc), cached))
+	}
+	registry := scraper.NewRegistry(opts...)
+
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+	rootCmd, err := root.Root(t.Context(), root.WithRegistry(registry))
+	require.NoError(t, err, "Root")
+	err = rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "all",
+		"--after", "2026-02-01T00:00:00Z",
+		"--before", "2026-03-01T00:00:00Z",
+		"--limit", "0",
+		"--format", "json",
+		"--output", outputFile,
+	})
+	require.NoError(t, err, "Run")
+
+	responses := readJSONResponses(t, outputFile)
+	sites := make(map[proto.PdxSite]int)
+	var last time.Time
+	for i, resp := range responses {
+		sites[resp.GetSite()]++
+		start := resp.GetShowtime().GetStartTime().AsTime()
+		require.False(t, start.Before(last), "item %d (%s) starts at %s, before the previous item at %s", i, resp.GetSite(), start, last)
+		last = start
+	}
+	t.Logf("items per site: %v", sites)
+	for _, tc := range cases {
+		require.NotZero(t, sites[tc.site], "--from all should include %s", tc.fromFlag)
+	}
+	require.Zero(t, sites[proto.PdxSite_None], "the None placeholder yields nothing")
+}
+
+// readJSONResponses parses newline-delimited ListShowtimesResponse JSON written by --format json.
+func readJSONResponses(t *testing.T, path string) []*proto.ListShowtimesResponse {
+	t.Helper()
+	outputBytes, err := os.ReadFile(path)
+	require.NoError(t, err, "ReadFile")
+	var out []*proto.ListShowtimesResponse
+	for line := range strings.Lines(string(outputBytes)) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		resp := &proto.ListShowtimesResponse{}
+		require.NoError(t, protojson.Unmarshal([]byte(line), resp), "Unmarshal %q", line)
+		out = append(out, resp)
+	}
+	return out
+}
+
+// failingScraper always fails to scrape.
+type failingScraper struct {
+	internal.NoCapabilities
+}
+
+func (failingScraper) Descriptor() string { return "failing" }
+
+func (failingScraper) ScrapeShowtimes(context.Context, internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	return nil, errors.New("upstream unavailable")
+}
+
+func TestAcceptance_ListShowtimes_JSONArray(t *testing.T) {
+	cinema21 := cases[2]
+	require.Equal(t, proto.PdxSite_Cinema21, cinema21.site)
+	registry := scraper.NewRegistry(
+		scraper.WithScraperForSite(cinema21.site, mountGoldenScraper(t, cinema21)),
+		scraper.WithScraperForSite(proto.PdxSite_Cinemagic, failingScraper{}),
+	)
+	run := func(t *testing.T, from ...string) ([]json.RawMessage, error) {
+		t.Helper()
+		outputFile := filepath.Join(t.TempDir(), "output.json")
+		rootCmd, err := root.Root(t.Context(), root.WithRegistry(registry))
+		require.NoError(t, err, "Root")
+		args := []string{
+			"pdx-watcher", "list-showtimes",
+			"--after", "2026-02-01T00:00:00Z",
+			"--before", "2026-03-01T00:00:00Z",
+			"--format", "json-array",
+			"--output", outputFile,
+		}
+		for _, f := range from {
+			args = append(args, "--from", f)
+		}
+		runErr := rootCmd.Run(t.Context(), args)
+		outputBytes, err := os.ReadFile(outputFile)
+		require.NoError(t, err, "ReadFile")
+		var items []json.RawMessage
+		require.NoError(t, json.Unmarshal(outputBytes, &items), "output should be a valid JSON array: %s", outputBytes)
+		return items, runErr
+	}
+
+	t.Run("partial failure", func(t *testing.T) {
+		items, err := run(t, "cinema21", "cinemagic")
+		require.NoError(t, err, "Run")
+		require.NotEmpty(t, items, "Cinema21 items should survive the Cinemagic failure")
+		for _, item := range items {
+			resp := &proto.ListShowtimesResponse{}
+			require.NoError(t, protojson.Unmarshal(item, resp))
+			require.Equal(t, proto.PdxSite_Cinema21, resp.GetSite())
+		}
+	})
+
+	t.Run("stream failure", func(t *testing.T) {
+		items, err := run(t, "cinemagic")
+		require.Error(t, err, "Run")
+		require.Empty(t, items)
+	})
+}
+
+func TestAcceptance_ListShowtimes_SinceLast(t *testing.T) {
+	opts := make([]scraper.RegistryOption, 0, len(cases))
+	for _, tc := range cases {
+		opts = append(opts, scraper.WithScraperForSite(tc.site, mountGoldenScraper(t, tc)))
+	}
+	registry := scraper.NewRegistry(opts...)
+	stateFile := filepath.Join(t.TempDir(), "state", "since-last")
+
+	run := func(t *testing.T, now time.Time) []*proto.ListShowtimesResponse {
+		t.Helper()
+		outputFile := filepath.Join(t.TempDir(), "output.json")
+		rootCmd, err := root.Root(t.Context(), root.WithRegistry(registry), root.WithClock(func() time.Time { return now }))
+		require.NoError(t, err, "Root")
+		err = rootCmd.Run(t.Context(), []string{
+			"pdx-watcher", "list-showtimes",
+			"--after", "2026-02-01T00:00:00Z",
+			"--before", "2026-03-01T00:00:00Z",
+			"--limit", "1000",
+			"--since-last",
+			"--state-file", stateFile,
+			"--format", "json",
+			"--output", outputFile,
+		})
+		require.NoError(t, err, "Run")
+		return readJSONResponses(t, outputFile)
+	}
+
+	firstRun := time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)
+	first := run(t, firstRun)
+	require.NotEmpty(t, first, "first run has no state and lists everything from --after")
+
+	second := run(t, firstRun.Add(48*time.Hour))
+	require.NotEmpty(t, second)
+	require.Less(t, len(second), len(first))
+	for _, resp := range second {
+		require.False(t, resp.GetShowtime().GetStartTime().AsTime().Before(firstRun),
+			"second run should only list showtimes after the first run")
+	}
+}
+
+func TestAcceptance_ListShowtimes_EnrichedSummaries(t *testing.T) {
+	cinema21 := cases[2]
+	require.Equal(t, proto.PdxSite_Cinema21, cinema21.site)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(cinema21.site, mountGoldenScraper(t, cinema21)))
+	movies := enrichment.Static(map[string]internal.MovieInfo{
+		"Cleo from 5 to 7 (1962)": {Title: "Cléo from 5 to 7", Overview: "A singer awaits the results of a biopsy."},
+	})
+
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+	rootCmd, err := root.Root(t.Context(), root.WithRegistry(registry), root.WithEnrichment(movies))
+	require.NoError(t, err, "Root")
+	require.NoError(t, rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", cinema21.fromFlag,
+		"--after", "2026-02-01T00:00:00Z",
+		"--before", "2026-03-01T00:00:00Z",
+		"--limit", "1000",
+		"--format", "json",
+		"--output", outputFile,
+	}), "Run")
+
+	var enriched, unmatched int
+	for _, resp := range readJSONResponses(t, outputFile) {
+		showtime := resp.GetShowtime()
+		if showtime.GetScreening().GetTitle() != "Cleo from 5 to 7 (1962)" {
+			require.Nil(t, showtime.GetMovie().Title, "%s has no static entry", showtime.GetSummary())
+			unmatched++
+			continue
+		}
+		require.Equal(t, "Cléo from 5 to 7", showtime.GetSummary(), "the summary uses the enriched title")
+		require.Equal(t, "A singer awaits the results of a biopsy.", showtime.GetMovie().GetOverview())
+		enriched++
+	}
+	require.NotZero(t, enriched, "golden set should list Cleo from 5 to 7")
+	require.NotZero(t, unmatched, "golden set should list other films")
+}
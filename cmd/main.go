@@ -4,12 +4,15 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"os/signal"
 
 	"github.com/drewfead/pdx-watcher/internal/root"
 )
 
 func main() {
-	ctx := context.Background()
+	// Ctrl-C cancels ctx so list-showtimes can stop cleanly and keep what it already wrote.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
 	rootCmd, err := root.Root(ctx)
 	if err != nil {
@@ -4,21 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/drewfead/pdx-watcher/internal/httputil"
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
+	"golang.org/x/sync/singleflight"
 )
 
 // PageStableTimeout is the timeout used when waiting for page stability or running eval scripts.
 var PageStableTimeout = 30 * time.Second
 
+// MaxPages is how many tabs a headless browser keeps open at once; further WithPage calls wait.
+var MaxPages = 4
+
 // Interface runs a callback with a rod page loaded at a given URL and can fetch JSON with optional caching.
 // Implementations may reuse a single browser process (e.g. headlessBrowser).
 type Interface interface {
@@ -30,62 +37,131 @@ type Interface interface {
 	io.Closer
 }
 
-// headlessBrowser manages a single rod browser instance. A channel of capacity 1 serializes
-// access: callers receive the browser, use it, then send it back so only one WithPage runs at a time.
-// Cache holds url -> JSON string for FetchJSON to avoid re-fetching.
+var (
+	// ErrUnavailable is wrapped by every error from a browser that could not be launched or is
+	// disabled, so scrapers with an HTTP path can tell it apart from a page failing to load.
+	ErrUnavailable = errors.New("browser unavailable")
+	// ErrDisabled is returned by a Disabled browser instead of launching chrome.
+	ErrDisabled = fmt.Errorf("%w: disabled (--no-browser)", ErrUnavailable)
+
+	errClosed = errors.New("browser closed")
+)
+
+// disabledBrowser fails every page load with ErrDisabled.
+type disabledBrowser struct{}
+
+// Disabled returns a Browser that never launches chrome, for environments (e.g. headless-unfriendly CI)
+// where launching would fail; scrapers that need it fail with ErrDisabled.
+func Disabled() Interface {
+	return disabledBrowser{}
+}
+
+func (disabledBrowser) WithPage(context.Context, string, func(*rod.Page) error) error {
+	return ErrDisabled
+}
+
+func (disabledBrowser) FetchJSON(context.Context, string, any) func(*rod.Page) error {
+	return func(*rod.Page) error { return ErrDisabled }
+}
+
+func (disabledBrowser) Close() error { return nil }
+
+// headlessBrowser manages a single rod browser instance shared by every caller. Each WithPage opens
+// its own tab; the pages channel holds one token per open tab, so up to MaxPages run at once and
+// the rest wait. Cache holds url -> JSON string for FetchJSON to avoid re-fetching, and fetches
+// de-duplicates concurrent fetches of the same url.
 type headlessBrowser struct {
-	initOnce sync.Once
-	initErr  error
-	ch       chan *rod.Browser
-	cache    map[string]string
-	cacheMu  sync.Mutex
+	initOnce  sync.Once
+	initErr   error
+	browser   *rod.Browser
+	pages     chan struct{}
+	done      chan struct{} // closed by Close; pending WithPage calls give up
+	closeOnce sync.Once
+	closeErr  error
+	cache     map[string]string
+	cacheMu   sync.Mutex
+	fetches   singleflight.Group
+	retry     RetryPolicy
+}
+
+// Option configures a headless browser.
+type Option func(*headlessBrowser)
+
+// WithRetries retries failed in-page fetches up to n more times, waiting baseDelay before the first
+// retry and doubling the wait each time after (see RetryPolicy).
+func WithRetries(n int, baseDelay time.Duration) Option {
+	return func(h *headlessBrowser) {
+		h.retry = RetryPolicy{Retries: max(0, n), BaseDelay: baseDelay}
+	}
 }
 
 // Headless returns a Browser that lazily launches one headless chrome browser and reuses it.
-func Headless() Interface {
-	h := &headlessBrowser{
-		ch:    make(chan *rod.Browser, 1),
-		cache: make(map[string]string),
+func Headless(opts ...Option) Interface {
+	h := newHeadlessBrowser(MaxPages)
+	for _, opt := range opts {
+		opt(h)
 	}
 	h.initOnce.Do(func() {
 		u, err := launcher.New().Logger(newRodLauncherLogger()).Leakless(false).Launch()
 		if err != nil {
-			h.initErr = fmt.Errorf("launch browser: %w", err)
-			close(h.ch)
+			h.initErr = fmt.Errorf("%w: launch browser: %w", ErrUnavailable, err)
 			return
 		}
 		browser := rod.New().ControlURL(u)
 		if err := browser.Connect(); err != nil {
-			h.initErr = fmt.Errorf("connect to browser: %w", err)
-			close(h.ch)
+			h.initErr = fmt.Errorf("%w: connect to browser: %w", ErrUnavailable, err)
 			return
 		}
-		h.ch <- browser
+		h.browser = browser
 	})
 	return h
 }
 
-func (h *headlessBrowser) Close() error {
-	browser, ok := <-h.ch
-	if !ok {
-		return h.initErr
+func newHeadlessBrowser(maxPages int) *headlessBrowser {
+	return &headlessBrowser{
+		pages: make(chan struct{}, max(1, maxPages)),
+		done:  make(chan struct{}),
+		cache: make(map[string]string),
 	}
-	return browser.Close()
 }
 
-// WithPage receives the shared browser from the channel, creates a page at url, runs fn, then sends the browser back.
-// Serializes with other callers (one WithPage at a time). The page is closed when fn returns.
+// Close waits for open pages to finish, then closes the browser. Later WithPage calls fail.
+func (h *headlessBrowser) Close() error {
+	h.closeOnce.Do(func() {
+		close(h.done)
+		if h.initErr != nil {
+			h.closeErr = h.initErr
+			return
+		}
+		for range cap(h.pages) {
+			h.pages <- struct{}{}
+		}
+		h.closeErr = h.browser.Close()
+	})
+	return h.closeErr
+}
+
+// WithPage opens a tab at url, runs fn, and closes the tab when fn returns. Calls run concurrently
+// up to MaxPages open tabs; beyond that they wait for a tab to close.
 func (h *headlessBrowser) WithPage(ctx context.Context, url string, fn func(page *rod.Page) error) error {
 	if h.initErr != nil {
 		return h.initErr
 	}
-	browser, ok := <-h.ch
-	if !ok {
-		return h.initErr
+	select {
+	case <-h.done:
+		return errClosed
+	default:
+	}
+	select {
+	case h.pages <- struct{}{}:
+	case <-h.done:
+		return errClosed
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	defer func() { h.ch <- browser }()
+	defer func() { <-h.pages }()
 
-	page, err := browser.Page(proto.TargetCreateTarget{})
+	page, err := h.browser.Page(proto.TargetCreateTarget{})
 	if err != nil {
 		return fmt.Errorf("create page: %w", err)
 	}
@@ -106,32 +182,157 @@ func (h *headlessBrowser) WithPage(ctx context.Context, url string, fn func(page
 }
 
 // FetchJSON returns a callback that fetches url in the page and unmarshals into dest. Uses internal cache on hit.
+// Failed fetches are retried per WithRetries; a body that isn't JSON is neither retried nor cached.
 func (h *headlessBrowser) FetchJSON(ctx context.Context, urlStr string, dest any) func(*rod.Page) error {
 	return func(page *rod.Page) error {
-		h.cacheMu.Lock()
-		raw, ok := h.cache[urlStr]
-		h.cacheMu.Unlock()
-		if ok {
-			return json.Unmarshal([]byte(raw), dest)
+		raw, err := h.cachedJSON(urlStr, func() (string, error) {
+			var raw string
+			err := h.retry.Do(ctx, urlStr, func() error {
+				var err error
+				raw, err = EvalFetch(ctx, page, urlStr, fetchJSONScript, urlStr)
+				return err
+			})
+			if err == nil && !json.Valid([]byte(raw)) {
+				err = fmt.Errorf("fetch %s: response is not JSON", urlStr)
+			}
+			return raw, err
+		})
+		if err != nil {
+			return err
 		}
-		result, err := page.Context(ctx).Timeout(PageStableTimeout).Eval(fetchJSONScript, urlStr)
+		return json.Unmarshal([]byte(raw), dest)
+	}
+}
+
+// cachedJSON returns the cached body for urlStr, else runs fetch and caches its result. Pages
+// fetching the same url at once share a single fetch.
+func (h *headlessBrowser) cachedJSON(urlStr string, fetch func() (string, error)) (string, error) {
+	h.cacheMu.Lock()
+	raw, ok := h.cache[urlStr]
+	h.cacheMu.Unlock()
+	if ok {
+		return raw, nil
+	}
+	v, err, _ := h.fetches.Do(urlStr, func() (any, error) {
+		raw, err := fetch()
 		if err != nil {
-			return fmt.Errorf("fetch %s: %w", urlStr, err)
+			return "", err
 		}
-		raw = result.Value.Str()
 		h.cacheMu.Lock()
 		h.cache[urlStr] = raw
 		h.cacheMu.Unlock()
-		return json.Unmarshal([]byte(raw), dest)
+		return raw, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// RetryPolicy retries transient failures with exponential backoff. The zero value tries once.
+type RetryPolicy struct {
+	Retries   int           // attempts after the first
+	BaseDelay time.Duration // wait before the first retry; doubled before each later one
+}
+
+// RetryPolicyOf returns b's retry policy (see WithRetries), or the zero policy for browsers without one,
+// so callers evaluating their own scripts in a page retry the same way FetchJSON does.
+func RetryPolicyOf(b Interface) RetryPolicy {
+	if h, ok := b.(*headlessBrowser); ok {
+		return h.retry
+	}
+	return RetryPolicy{}
+}
+
+// Do runs fn until it succeeds, returns an error that retrying won't fix (see retryable), runs out
+// of retries, or ctx is done; it returns fn's last error. what names the operation in debug logs.
+func (p RetryPolicy) Do(ctx context.Context, what string, fn func() error) error {
+	delay := p.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > p.Retries || !retryable(ctx, err) {
+			return err
+		}
+		wait := delay
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > wait {
+			wait = statusErr.RetryAfter
+		}
+		slog.Debug("browser: retrying", "what", what, "attempt", attempt, "delay", wait, "error", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether err could be transient. The caller's ctx decides cancellation: once it
+// is done nothing is retried, while a context error from a per-attempt timeout (PageStableTimeout)
+// is. A browser that can't run and a status the same request would get again are not retried.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrUnavailable) || errors.Is(err, errClosed) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	return true
+}
+
+// FetchResponse is what a fetch script evaluated in a page resolves to: the response's HTTP status,
+// Retry-After header, and raw body, so Go decides what failed rather than parsing a thrown
+// JavaScript error.
+type FetchResponse struct {
+	Status     int    `json:"status"`
+	RetryAfter string `json:"retryAfter"`
+	Body       string `json:"body"`
+}
+
+// StatusError is returned by EvalFetch for a response without a 2xx status.
+type StatusError struct {
+	URL        string
+	Status     int
+	RetryAfter time.Duration // the response's Retry-After (see httputil.RetryAfter); RetryPolicy waits at least this long
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("fetch %s: HTTP %d", e.URL, e.Status)
+}
+
+// Temporary reports whether a retry could get a different answer: a server error, a timeout, or
+// rate limiting. Other 4xx responses would come back the same.
+func (e *StatusError) Temporary() bool {
+	return e.Status >= 500 || e.Status == http.StatusRequestTimeout || e.Status == http.StatusTooManyRequests
+}
+
+// EvalFetch evaluates script, which must resolve to a FetchResponse, in page with args, bounded by
+// PageStableTimeout. It returns the body, or a *StatusError for a non-2xx status; url names the
+// request in errors.
+func EvalFetch(ctx context.Context, page *rod.Page, url, script string, args ...any) (string, error) {
+	result, err := page.Context(ctx).Timeout(PageStableTimeout).Eval(script, args...)
+	if err != nil {
+		return "", fmt.Errorf("fetch %s: %w", url, err)
+	}
+	var resp FetchResponse
+	if err := result.Value.Unmarshal(&resp); err != nil {
+		return "", fmt.Errorf("fetch %s: decode script result: %w", url, err)
+	}
+	if resp.Status < 200 || resp.Status > 299 {
+		statusErr := &StatusError{URL: url, Status: resp.Status}
+		statusErr.RetryAfter, _ = httputil.RetryAfter(http.Header{"Retry-After": {resp.RetryAfter}}, time.Now(), 0)
+		return "", statusErr
 	}
+	return resp.Body, nil
 }
 
-// fetchJSONScript fetches url in the page context and returns the response body as JSON string.
+// fetchJSONScript fetches url in the page context and resolves to a FetchResponse.
 const fetchJSONScript = `(url) => {
-	return fetch(url).then(r => {
-		if (!r.ok) throw new Error('HTTP ' + r.status);
-		return r.json();
-	}).then(obj => JSON.stringify(obj));
+	return fetch(url).then(r => r.text().then(body => ({status: r.status, retryAfter: r.headers.get('Retry-After') || '', body: body})));
 }`
 
 // rodLauncherLogger is an io.Writer that forwards launcher output (e.g. download progress) to slog at debug level.
@@ -0,0 +1,205 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_CachedJSON_ConcurrentFetchesShareOne(t *testing.T) {
+	h := newHeadlessBrowser(4)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func() (string, error) {
+		calls.Add(1)
+		<-release
+		return `{"ok":true}`, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Go(func() {
+			raw, err := h.cachedJSON("https://example.test/api", fetch)
+			require.NoError(t, err)
+			results[i] = raw
+		})
+	}
+	time.Sleep(20 * time.Millisecond) // let the callers pile up behind the first fetch
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), calls.Load())
+	for _, raw := range results {
+		require.JSONEq(t, `{"ok":true}`, raw)
+	}
+	raw, err := h.cachedJSON("https://example.test/api", fetch)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"ok":true}`, raw)
+	require.Equal(t, int32(1), calls.Load(), "cache hit should not fetch")
+}
+
+func TestIntegration_Headless_ConcurrentPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	t.Cleanup(server.Close)
+	b := Headless()
+	t.Cleanup(func() { _ = b.Close() })
+
+	// Each page waits for the other to open, which deadlocks if WithPage serializes.
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			err := b.WithPage(ctx, server.URL, func(*rod.Page) error {
+				arrived.Done()
+				arrived.Wait()
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+	wg.Wait()
+}
+
+func TestUnit_RetryPolicy(t *testing.T) {
+	transient := errors.New("fetch https://example.test/api: TypeError: Failed to fetch")
+	policy := RetryPolicy{Retries: 3, BaseDelay: time.Millisecond}
+
+	t.Run("retries transient errors until success", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(t.Context(), "test", func() error {
+			calls++
+			if calls < 3 {
+				return transient
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after the configured retries", func(t *testing.T) {
+		calls := 0
+		err := policy.Do(t.Context(), "test", func() error {
+			calls++
+			return transient
+		})
+		require.ErrorIs(t, err, transient)
+		require.Equal(t, 4, calls, "one attempt plus three retries")
+	})
+
+	t.Run("zero policy tries once", func(t *testing.T) {
+		calls := 0
+		_ = RetryPolicy{}.Do(t.Context(), "test", func() error {
+			calls++
+			return transient
+		})
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		for _, permanent := range []error{
+			ErrDisabled,
+			&StatusError{URL: "https://example.test/api", Status: 404},
+		} {
+			calls := 0
+			err := policy.Do(t.Context(), "test", func() error {
+				calls++
+				return permanent
+			})
+			require.ErrorIs(t, err, permanent)
+			require.Equal(t, 1, calls, "%v", permanent)
+		}
+	})
+
+	t.Run("temporary statuses and attempt timeouts are retried", func(t *testing.T) {
+		for _, temporary := range []error{
+			&StatusError{URL: "https://example.test/api", Status: 503},
+			&StatusError{URL: "https://example.test/api", Status: 429},
+			fmt.Errorf("fetch https://example.test/api: %w", context.DeadlineExceeded),
+		} {
+			calls := 0
+			err := policy.Do(t.Context(), "test", func() error {
+				calls++
+				return temporary
+			})
+			require.ErrorIs(t, err, temporary)
+			require.Equal(t, 4, calls, "%v", temporary)
+		}
+	})
+
+	t.Run("waits at least the server's Retry-After", func(t *testing.T) {
+		limited := &StatusError{URL: "https://example.test/api", Status: 429, RetryAfter: 50 * time.Millisecond}
+		calls := 0
+		start := time.Now()
+		err := policy.Do(t.Context(), "test", func() error {
+			calls++
+			if calls < 2 {
+				return limited
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), limited.RetryAfter)
+	})
+
+	t.Run("nothing is retried once ctx is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		calls := 0
+		err := policy.Do(ctx, "test", func() error {
+			calls++
+			return transient
+		})
+		require.ErrorIs(t, err, transient)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("stops waiting when ctx is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		calls := 0
+		start := time.Now()
+		err := RetryPolicy{Retries: 5, BaseDelay: time.Hour}.Do(ctx, "test", func() error {
+			calls++
+			cancel()
+			return transient
+		})
+		require.ErrorIs(t, err, transient)
+		require.Equal(t, 1, calls)
+		require.Less(t, time.Since(start), time.Minute)
+	})
+
+	t.Run("backs off exponentially", func(t *testing.T) {
+		var attempts []time.Time
+		err := RetryPolicy{Retries: 3, BaseDelay: 10 * time.Millisecond}.Do(t.Context(), "test", func() error {
+			attempts = append(attempts, time.Now())
+			return transient
+		})
+		require.Error(t, err)
+		require.Len(t, attempts, 4)
+		for i, want := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond} {
+			require.GreaterOrEqual(t, attempts[i+1].Sub(attempts[i]), want, "wait before retry %d", i+1)
+		}
+	})
+}
+
+func TestUnit_RetryPolicyOf(t *testing.T) {
+	h := newHeadlessBrowser(1)
+	WithRetries(2, time.Second)(h)
+	require.Equal(t, RetryPolicy{Retries: 2, BaseDelay: time.Second}, RetryPolicyOf(h))
+	require.Equal(t, RetryPolicy{}, RetryPolicyOf(Disabled()))
+}
@@ -0,0 +1,43 @@
+package enrichment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+)
+
+type static struct {
+	movies map[string]internal.MovieInfo
+}
+
+// Static returns a provider that fills MovieInfo from movies, keyed by the showtime's TitleHint
+// (compared case-insensitively). It makes no requests, so service and CLI tests can exercise
+// enrichment deterministically without TMDB. Showtimes with no entry are left unmatched.
+func Static(movies map[string]internal.MovieInfo) internal.EnrichmentProvider {
+	s := &static{movies: make(map[string]internal.MovieInfo, len(movies))}
+	for hint, movie := range movies {
+		s.movies[strings.ToLower(hint)] = movie
+	}
+	return s
+}
+
+// Enrich records its audit the way the TMDB provider does: a missing title hint is skipped, and a
+// title with no entry leaves the movie unset with no "match" annotation.
+func (s *static) Enrich(_ context.Context, showtime internal.EnrichedShowtime) (internal.EnrichedShowtime, error) {
+	hint := showtime.Source.TitleHint
+	annotations := map[string]any{"title_hint": hint}
+	if hint == "" {
+		annotations["skipped"] = "no title hint"
+	} else if movie, ok := s.movies[strings.ToLower(hint)]; ok {
+		showtime.Movie = movie
+		annotations["match"] = "title"
+	}
+	showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+		Result:      internal.EnrichmentResultSuccess,
+		At:          time.Now(),
+		Annotations: annotations,
+	})
+	return showtime, nil
+}
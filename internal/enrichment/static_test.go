@@ -0,0 +1,24 @@
+package enrichment
+
+import (
+	"testing"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Static(t *testing.T) {
+	provider := Static(map[string]internal.MovieInfo{"Heat": {Title: "Heat", Overview: "A heist."}})
+
+	got := Enrich(t.Context(), internal.SourceShowtime{ID: "heat", TitleHint: "HEAT"}, provider)
+	require.Equal(t, "Heat", got.Movie.Title, "titles match case-insensitively")
+	require.Equal(t, "title", got.Audits[0].Annotations["match"])
+
+	got = Enrich(t.Context(), internal.SourceShowtime{ID: "thief", TitleHint: "Thief"}, provider)
+	require.Empty(t, got.Movie.Title)
+	require.Equal(t, internal.EnrichmentResultSuccess, got.Audits[0].Result, "no match is recorded like TMDB's")
+	require.NotContains(t, got.Audits[0].Annotations, "match")
+
+	got = Enrich(t.Context(), internal.SourceShowtime{ID: "untitled"}, provider)
+	require.Equal(t, "no title hint", got.Audits[0].Annotations["skipped"])
+}
@@ -0,0 +1,49 @@
+package enrichment
+
+import (
+	"fmt"
+
+	"github.com/drewfead/pdx-watcher/internal"
+)
+
+// CacheStats aggregates the TMDB cache audit annotations ("cache_search", "cache_details")
+// across every showtime enriched in a run.
+type CacheStats struct {
+	Searches     int
+	SearchHits   int
+	DetailsCalls int
+	DetailsHits  int
+}
+
+// Add counts the cache activity recorded in audits.
+func (s *CacheStats) Add(audits []internal.EnrichmentAudit) {
+	for _, audit := range audits {
+		if search, ok := audit.Annotations["cache_search"].(map[string]any); ok {
+			s.Searches++
+			if hit, _ := search["hit"].(bool); hit {
+				s.SearchHits++
+			}
+		}
+		details, _ := audit.Annotations["cache_details"].([]map[string]any)
+		for _, d := range details {
+			s.DetailsCalls++
+			if hit, _ := d["cache_hit"].(bool); hit {
+				s.DetailsHits++
+			}
+		}
+	}
+}
+
+// HitRate is the fraction of searches and details calls served from cache (0 when there were none).
+func (s CacheStats) HitRate() float64 {
+	total := s.Searches + s.DetailsCalls
+	if total == 0 {
+		return 0
+	}
+	return float64(s.SearchHits+s.DetailsHits) / float64(total)
+}
+
+func (s CacheStats) String() string {
+	return fmt.Sprintf("enrichment cache: %d searches (%d hits), %d details calls (%d hits), hit rate %.1f%%",
+		s.Searches, s.SearchHits, s.DetailsCalls, s.DetailsHits, s.HitRate()*100)
+}
@@ -0,0 +1,161 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/httputil"
+)
+
+// maxPermalinkPageBytes bounds how much of a film page is read looking for its synopsis; meta tags live in <head>.
+const maxPermalinkPageBytes = 1 << 20
+
+var (
+	// metaTagPattern matches <meta> tags; metaNamePattern and metaContentPattern pick their attributes
+	// apart since pages order them either way.
+	metaTagPattern     = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	metaNamePattern    = regexp.MustCompile(`(?i)\b(?:name|property)\s*=\s*["']([^"']+)["']`)
+	metaContentPattern = regexp.MustCompile(`(?is)\bcontent\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+type synopsis struct {
+	client *http.Client
+}
+
+// SynopsisOption applies configuration to the synopsis provider.
+type SynopsisOption func(*synopsis)
+
+// SynopsisWithClient sets the client film pages are fetched with.
+func SynopsisWithClient(client *http.Client) SynopsisOption {
+	return func(s *synopsis) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// Synopsis returns a provider that replaces placeholder descriptions (ones that just repeat the title,
+// as Hollywood's listing does) with the synopsis from the film's info page, falling back to the TMDB
+// overview when the page has none. It costs a request per such showtime, so it is opt-in
+// (--fetch-synopses), and it should run after TMDB so the overview is available.
+func Synopsis(opts ...SynopsisOption) internal.EnrichmentProvider {
+	s := &synopsis{
+		client: &http.Client{Transport: httputil.SharedCacheTransport(), Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *synopsis) Enrich(ctx context.Context, showtime internal.EnrichedShowtime) (internal.EnrichedShowtime, error) {
+	annotations := make(map[string]any)
+	audit := func() {
+		showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+			Result:      internal.EnrichmentResultSuccess,
+			At:          time.Now(),
+			Annotations: annotations,
+		})
+	}
+	if !placeholderDescription(showtime.Source) {
+		annotations["skipped"] = "has description"
+		audit()
+		return showtime, nil
+	}
+
+	var fetchErr error
+	if href := infoLink(showtime.Source.Screening.Links); href != "" {
+		annotations["permalink"] = href
+		text, err := s.fetchSynopsis(ctx, href)
+		if err == nil && text != "" {
+			showtime.Source.Description = &text
+			annotations["source"] = "permalink"
+			audit()
+			return showtime, nil
+		}
+		fetchErr = err
+	}
+	if overview := showtime.Movie.Overview; overview != "" {
+		showtime.Source.Description = &overview
+		annotations["source"] = "tmdb"
+		if fetchErr != nil {
+			annotations["permalink_error"] = fetchErr.Error()
+		}
+		audit()
+		return showtime, nil
+	}
+	if fetchErr != nil {
+		return showtime, fetchErr
+	}
+	annotations["skipped"] = "no synopsis found"
+	audit()
+	return showtime, nil
+}
+
+func (s *synopsis) fetchSynopsis(ctx context.Context, href string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, href, nil)
+	if err != nil {
+		return "", fmt.Errorf("build film page request for %s: %w", href, err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch film page %s: %w", href, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("fetch film page %s: unexpected status %d", href, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPermalinkPageBytes))
+	if err != nil {
+		return "", fmt.Errorf("read film page %s: %w", href, err)
+	}
+	return extractSynopsis(body), nil
+}
+
+// extractSynopsis returns the page's og:description, else its meta description, else "".
+func extractSynopsis(page []byte) string {
+	found := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAll(page, -1) {
+		name := metaNamePattern.FindSubmatch(tag)
+		content := metaContentPattern.FindSubmatch(tag)
+		if name == nil || content == nil {
+			continue
+		}
+		key := strings.ToLower(string(name[1]))
+		if _, ok := found[key]; !ok {
+			found[key] = string(content[1]) + string(content[2])
+		}
+	}
+	for _, key := range []string{"og:description", "description"} {
+		if text := strings.TrimSpace(html.UnescapeString(found[key])); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// placeholderDescription reports whether showtime's description only repeats its title.
+func placeholderDescription(showtime internal.SourceShowtime) bool {
+	if showtime.Description == nil {
+		return false
+	}
+	description := strings.TrimSpace(*showtime.Description)
+	return description != "" && (description == strings.TrimSpace(showtime.Summary) || description == strings.TrimSpace(showtime.Screening.Title))
+}
+
+// infoLink returns the first link with the info rel, or "".
+func infoLink(links []internal.Link) string {
+	for _, link := range links {
+		if link.Rel == internal.LinkRelInfo && link.Href != "" {
+			return link.Href
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package enrichment
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Synopsis(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/event/heat", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><head>
+<meta name="description" content="Hollywood Theatre - Heat">
+<meta content="A group of high-end professional thieves start to feel the heat from the LAPD when they unknowingly leave a clue at their latest heist." property="og:description" />
+</head><body><h1>Heat</h1></body></html>`)
+	})
+	mux.HandleFunc("/event/bare", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><head><title>Thief</title></head></html>`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	provider := Synopsis(SynopsisWithClient(server.Client()))
+
+	enrich := func(description, path string, movie internal.MovieInfo) internal.EnrichedShowtime {
+		t.Helper()
+		source := internal.SourceShowtime{
+			ID:          "heat",
+			Summary:     "Heat",
+			TitleHint:   "Heat",
+			Description: &description,
+			Screening: internal.ScreeningInfo{
+				Title: "Heat",
+				Links: []internal.Link{{Href: server.URL + path, Display: "Event", Rel: internal.LinkRelInfo}},
+			},
+		}
+		return Enrich(t.Context(), source, Static(map[string]internal.MovieInfo{"Heat": movie}), provider)
+	}
+
+	t.Run("permalink synopsis", func(t *testing.T) {
+		got := enrich("Heat", "/event/heat", internal.MovieInfo{Overview: "TMDB overview"})
+		require.NotNil(t, got.Source.Description)
+		require.Equal(t, "A group of high-end professional thieves start to feel the heat from the LAPD when they unknowingly leave a clue at their latest heist.", *got.Source.Description)
+	})
+
+	t.Run("falls back to TMDB overview", func(t *testing.T) {
+		got := enrich("Heat", "/event/bare", internal.MovieInfo{Overview: "TMDB overview"})
+		require.Equal(t, "TMDB overview", *got.Source.Description)
+
+		got = enrich("Heat", "/event/missing", internal.MovieInfo{Overview: "TMDB overview"})
+		require.Equal(t, "TMDB overview", *got.Source.Description)
+	})
+
+	t.Run("real descriptions are kept", func(t *testing.T) {
+		got := enrich("Michael Mann's crime epic.", "/event/heat", internal.MovieInfo{})
+		require.Equal(t, "Michael Mann's crime epic.", *got.Source.Description)
+	})
+
+	t.Run("fetch error without overview fails", func(t *testing.T) {
+		got := enrich("Heat", "/event/missing", internal.MovieInfo{})
+		require.Equal(t, "Heat", *got.Source.Description)
+		require.Equal(t, internal.EnrichmentResultFailure, got.Audits[len(got.Audits)-1].Result)
+	})
+}
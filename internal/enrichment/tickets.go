@@ -0,0 +1,110 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/htmltext"
+	"github.com/drewfead/pdx-watcher/internal/httputil"
+)
+
+// maxBookingPageBytes bounds how much of a booking page is scanned for sold-out markers.
+const maxBookingPageBytes = 1 << 20
+
+// soldOutPattern matches the sold-out wording booking pages use. It is matched against the page's
+// text (see htmltext.Plain), never its markup: class names like "sold-out", scripts, and styles
+// appear on pages with tickets left too.
+var soldOutPattern = regexp.MustCompile(`(?i)sold[\s-]*out|no longer available|no tickets (?:are )?available`)
+
+type ticketAvailability struct {
+	client *http.Client
+}
+
+// TicketOption applies configuration to the ticket availability provider.
+type TicketOption func(*ticketAvailability)
+
+// TicketsWithClient sets the client booking pages are fetched with.
+func TicketsWithClient(client *http.Client) TicketOption {
+	return func(t *ticketAvailability) {
+		if client != nil {
+			t.client = client
+		}
+	}
+}
+
+// TicketAvailability returns a provider that fetches each showtime's tickets link and sets SoldOut
+// when the booking page says the screening is sold out or the booking no longer exists. It costs a
+// request per showtime, so it is opt-in (--check-tickets).
+func TicketAvailability(opts ...TicketOption) internal.EnrichmentProvider {
+	t := &ticketAvailability{
+		client: &http.Client{Transport: httputil.SharedCacheTransport(), Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *ticketAvailability) Enrich(ctx context.Context, showtime internal.EnrichedShowtime) (internal.EnrichedShowtime, error) {
+	annotations := make(map[string]any)
+	href := ticketsLink(showtime.Source.Screening.Links)
+	if href == "" {
+		annotations["skipped"] = "no tickets link"
+		showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+			Result:      internal.EnrichmentResultSuccess,
+			At:          time.Now(),
+			Annotations: annotations,
+		})
+		return showtime, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, href, nil)
+	if err != nil {
+		return showtime, fmt.Errorf("build booking request for %s: %w", href, err)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return showtime, fmt.Errorf("fetch booking page %s: %w", href, err)
+	}
+	defer resp.Body.Close()
+	annotations["booking_url"] = href
+	annotations["status"] = resp.StatusCode
+
+	var soldOut bool
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// The booking was taken down: nothing left to buy.
+		soldOut = true
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return showtime, fmt.Errorf("fetch booking page %s: unexpected status %d", href, resp.StatusCode)
+	default:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBookingPageBytes))
+		if err != nil {
+			return showtime, fmt.Errorf("read booking page %s: %w", href, err)
+		}
+		soldOut = soldOutPattern.MatchString(htmltext.Plain(string(body)))
+	}
+	showtime.SoldOut = &soldOut
+	annotations["sold_out"] = soldOut
+	showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+		Result:      internal.EnrichmentResultSuccess,
+		At:          time.Now(),
+		Annotations: annotations,
+	})
+	return showtime, nil
+}
+
+// ticketsLink returns the first link with the tickets rel, or "".
+func ticketsLink(links []internal.Link) string {
+	for _, link := range links {
+		if link.Rel == internal.LinkRelTickets && link.Href != "" {
+			return link.Href
+		}
+	}
+	return ""
+}
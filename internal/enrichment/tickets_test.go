@@ -0,0 +1,78 @@
+package enrichment
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_TicketAvailability(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/book/sold-out", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<div class="session"><span class="badge">Sold Out</span></div>`)
+	})
+	mux.HandleFunc("/book/available", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<div class="session"><button>Buy tickets</button></div>`)
+	})
+	mux.HandleFunc("/book/styled", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<style>.sold-out { color: grey; }</style>
+<script>if (seats === 0) { el.classList.add("sold-out"); }</script>
+<div class="session" data-state="sold-out"><button>Buy tickets</button></div>`)
+	})
+	mux.HandleFunc("/book/error", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux) // unknown paths 404, like a removed booking
+	t.Cleanup(server.Close)
+	provider := TicketAvailability(TicketsWithClient(server.Client()))
+
+	enrich := func(links ...internal.Link) internal.EnrichedShowtime {
+		return Enrich(t.Context(), internal.SourceShowtime{
+			ID:        "heat",
+			Screening: internal.ScreeningInfo{Links: links},
+		}, provider)
+	}
+	tickets := func(path string) internal.Link {
+		return internal.Link{Href: server.URL + path, Display: "Tickets", Rel: internal.LinkRelTickets}
+	}
+
+	t.Run("sold out marker", func(t *testing.T) {
+		got := enrich(tickets("/book/sold-out"))
+		require.NotNil(t, got.SoldOut)
+		require.True(t, *got.SoldOut)
+	})
+
+	t.Run("available", func(t *testing.T) {
+		got := enrich(tickets("/book/available"))
+		require.NotNil(t, got.SoldOut)
+		require.False(t, *got.SoldOut)
+	})
+
+	t.Run("sold-out class names and scripts are not markers", func(t *testing.T) {
+		got := enrich(tickets("/book/styled"))
+		require.NotNil(t, got.SoldOut)
+		require.False(t, *got.SoldOut)
+	})
+
+	t.Run("booking removed", func(t *testing.T) {
+		got := enrich(tickets("/book/gone"))
+		require.NotNil(t, got.SoldOut)
+		require.True(t, *got.SoldOut)
+	})
+
+	t.Run("upstream error leaves availability unknown", func(t *testing.T) {
+		got := enrich(tickets("/book/error"))
+		require.Nil(t, got.SoldOut)
+		require.Equal(t, internal.EnrichmentResultFailure, got.Audits[0].Result)
+	})
+
+	t.Run("no tickets link", func(t *testing.T) {
+		got := enrich(internal.Link{Href: server.URL + "/book/sold-out", Display: "Info", Rel: internal.LinkRelInfo})
+		require.Nil(t, got.SoldOut)
+		require.Equal(t, "no tickets link", got.Audits[0].Annotations["skipped"])
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,10 @@ import (
 
 const maxCandidatesForDetails = 5
 
+// candidateDetailsOptions is what pickBestResult fetches per candidate. Scoring and the alternative-title
+// fallback share it so each candidate costs one (cached) details call.
+var candidateDetailsOptions = map[string]string{"append_to_response": "credits,alternative_titles"}
+
 // httpRequestRecord is appended by auditTransport for each outgoing request.
 type httpRequestRecord struct {
 	Method string `json:"method"`
@@ -43,33 +49,78 @@ func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 }
 
 type tmdbEnrichment struct {
-	apiKey string
-	client *tmdb.Client
+	apiKey    string
+	client    *tmdb.Client
+	transport http.RoundTripper   // base transport under the response cache
+	disk      *httputil.DiskCache // persists details responses across runs; nil = memory only
+
+	includeAdult bool // keep adult titles in search results
 
 	// Set per Enrich call for audit; cleared when done.
 	auditRequests     *[]httpRequestRecord
 	detailsCacheAudit *[]struct {
 		MovieID  int
-		CacheHit bool
+		CacheHit httputil.CacheHit
 	}
 	cacheEvents *[]struct {
 		Key string
-		Hit bool
+		Hit httputil.CacheHit
 	} // every cache key + hit for this Enrich
 }
 
 // tmdbDetailsURLPat matches TMDB movie details URLs to extract movie ID for cache audit.
 var tmdbDetailsURLPat = regexp.MustCompile(`/movie/(\d+)(?:\?|$)`)
 
-func TMDB(apiKey string) (internal.EnrichmentProvider, error) {
+// TMDBOption applies configuration to the TMDB enrichment provider.
+type TMDBOption func(*tmdbEnrichment)
+
+// TMDBWithTransport sets the transport TMDB requests go through beneath the response cache
+// (e.g. a fake serving golden responses in tests).
+func TMDBWithTransport(rt http.RoundTripper) TMDBOption {
+	return func(e *tmdbEnrichment) {
+		if rt != nil {
+			e.transport = rt
+		}
+	}
+}
+
+// TMDBWithDiskCache also keeps movie details responses as files under dir for ttl, so repeat runs
+// don't spend rate limit re-fetching metadata that rarely changes. Searches stay memory-only since
+// their results shift as films are added. An empty dir leaves caching in memory only.
+func TMDBWithDiskCache(dir string, ttl time.Duration) TMDBOption {
+	return func(e *tmdbEnrichment) {
+		if dir == "" {
+			return
+		}
+		e.disk = &httputil.DiskCache{
+			Dir:     dir,
+			TTL:     ttl,
+			Persist: tmdbDetailsURLPat.MatchString,
+		}
+	}
+}
+
+// TMDBWithIncludeAdult sets whether searches include adult titles (default false). When false they are
+// requested with include_adult=false and any TMDB returns anyway are dropped before scoring.
+func TMDBWithIncludeAdult(include bool) TMDBOption {
+	return func(e *tmdbEnrichment) {
+		e.includeAdult = include
+	}
+}
+
+func TMDB(apiKey string, opts ...TMDBOption) (internal.EnrichmentProvider, error) {
 	tmdbClient, err := tmdb.InitV4(apiKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize TMDB client: %w", err)
 	}
-	e := &tmdbEnrichment{apiKey: apiKey, client: tmdbClient}
+	e := &tmdbEnrichment{apiKey: apiKey, client: tmdbClient, transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(e)
+	}
 	cacheTransport := &httputil.CacheTransport{
-		Base: http.DefaultTransport,
-		OnCacheHit: func(cacheKey string, hit bool) {
+		Base: e.transport,
+		Disk: e.disk,
+		OnCacheHit: func(cacheKey string, hit httputil.CacheHit) {
 			e.recordCacheHit(cacheKey, hit)
 		},
 	}
@@ -80,11 +131,11 @@ func TMDB(apiKey string) (internal.EnrichmentProvider, error) {
 }
 
 // recordCacheHit records cache events for audit: all keys in cacheEvents, and details URLs in detailsCacheAudit.
-func (e *tmdbEnrichment) recordCacheHit(cacheKey string, hit bool) {
+func (e *tmdbEnrichment) recordCacheHit(cacheKey string, hit httputil.CacheHit) {
 	if e.cacheEvents != nil {
 		*e.cacheEvents = append(*e.cacheEvents, struct {
 			Key string
-			Hit bool
+			Hit httputil.CacheHit
 		}{cacheKey, hit})
 	}
 	if e.detailsCacheAudit == nil {
@@ -100,20 +151,47 @@ func (e *tmdbEnrichment) recordCacheHit(cacheKey string, hit bool) {
 	}
 	*e.detailsCacheAudit = append(*e.detailsCacheAudit, struct {
 		MovieID  int
-		CacheHit bool
+		CacheHit httputil.CacheHit
 	}{id, hit})
 }
 
-// searchCacheHitFromEvents returns true if the search/movie request was a cache hit.
+// searchCacheHitFromEvents returns where the search/movie request was served from.
 func searchCacheHitFromEvents(events []struct {
 	Key string
-	Hit bool
-}) bool {
+	Hit httputil.CacheHit
+}) httputil.CacheHit {
 	for _, ev := range events {
 		if strings.Contains(ev.Key, "search/movie") {
 			return ev.Hit
 		}
 	}
+	return httputil.CacheMiss
+}
+
+// relevantResults drops search results that shouldn't be scored: adult titles unless included, and
+// entries nobody has voted on or viewed, which for short titles are usually junk.
+func (e *tmdbEnrichment) relevantResults(results []tmdb.MovieResult) []tmdb.MovieResult {
+	return slices.DeleteFunc(slices.Clone(results), func(r tmdb.MovieResult) bool {
+		return (r.Adult && !e.includeAdult) || (r.VoteCount == 0 && r.Popularity == 0)
+	})
+}
+
+// resultTitleMatches reports whether hint is r's title or original title, so films listed under either match.
+func resultTitleMatches(r tmdb.MovieResult, hint string) bool {
+	return titleEqual(r.Title, hint) || (r.OriginalTitle != "" && titleEqual(r.OriginalTitle, hint))
+}
+
+// alternativeTitleMatches reports whether hint is one of details' alternative titles
+// (e.g. a romanized or English release title of a foreign film).
+func alternativeTitleMatches(details *tmdb.MovieDetails, hint string) bool {
+	if details.MovieAlternativeTitlesAppend == nil || details.AlternativeTitles == nil {
+		return false
+	}
+	for _, alt := range details.AlternativeTitles.Titles {
+		if titleEqual(alt.Title, hint) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -145,37 +223,76 @@ func runtimeDiff(expectedMins int, actualMins int) int {
 	return d
 }
 
+// matchDetails is what pickBestResult learned from the chosen result's details, when it fetched them.
+type matchDetails struct {
+	runtimeMins int    // 0 = unknown
+	collection  string // belongs_to_collection name; "" = none
+}
+
 // pickBestResult chooses the best TMDB result: when director or runtime hints exist, fetches details
 // for up to maxCandidatesForDetails and prefers director match then closest runtime; otherwise
-// prefers exact title match then first result.
-func (e *tmdbEnrichment) pickBestResult(results []tmdb.MovieResult, normalizedHint, director string, runtimeHint time.Duration) *tmdb.MovieResult {
+// prefers exact title (or original title) match then first result. When nothing matches by director
+// or title, a candidate whose alternative titles include the hint wins. Also returns the candidates
+// considered (for explain output) and the chosen result's runtime and collection when details were
+// fetched (zero otherwise).
+func (e *tmdbEnrichment) pickBestResult(results []tmdb.MovieResult, normalizedHint, director string, runtimeHint time.Duration) (*tmdb.MovieResult, []internal.MatchCandidate, matchDetails) {
 	if len(results) == 0 {
-		return nil
+		return nil, nil, matchDetails{}
+	}
+	n := len(results)
+	if n > maxCandidatesForDetails {
+		n = maxCandidatesForDetails
 	}
 	runtimeMins := int(runtimeHint.Round(time.Minute).Minutes())
-	// No heuristic hints: use title match or first.
+	titleMatched := slices.ContainsFunc(results, func(r tmdb.MovieResult) bool {
+		return resultTitleMatches(r, normalizedHint)
+	})
+	// No heuristic hints: use title match, else alternative title match, else first.
 	if director == "" && runtimeMins <= 0 {
-		for i := range results {
-			if titleEqual(results[i].Title, normalizedHint) {
-				return &results[i]
+		chosen := slices.IndexFunc(results, func(r tmdb.MovieResult) bool {
+			return resultTitleMatches(r, normalizedHint)
+		})
+		var chosenDetails matchDetails
+		if chosen < 0 {
+			chosen = 0
+			if i, details, ok := e.matchAlternativeTitle(results[:n], normalizedHint); ok {
+				chosen, chosenDetails = i, details
 			}
 		}
-		return &results[0]
+		candidates := make([]internal.MatchCandidate, 0, n)
+		for i := 0; i < n; i++ {
+			candidates = append(candidates, internal.MatchCandidate{
+				Title:       results[i].Title,
+				TMDBID:      results[i].ID,
+				RuntimeDiff: -1,
+				Chosen:      i == chosen,
+			})
+		}
+		if chosen >= n {
+			// A title match further down the results still shows up as the chosen candidate.
+			candidates = append(candidates, internal.MatchCandidate{
+				Title:       results[chosen].Title,
+				TMDBID:      results[chosen].ID,
+				RuntimeDiff: -1,
+				Chosen:      true,
+			})
+		}
+		return &results[chosen], candidates, chosenDetails
 	}
 
 	// Fetch details for top candidates to compare director and runtime.
-	n := len(results)
-	if n > maxCandidatesForDetails {
-		n = maxCandidatesForDetails
-	}
 	type scored struct {
-		r    *tmdb.MovieResult
-		dir  bool
-		diff int
+		r        *tmdb.MovieResult
+		dir      bool
+		diff     int
+		altTitle bool // the hint is one of its alternative titles
+		details  matchDetails
+		index    int // into candidates
 	}
-	var best *scored
+	var best, bestAltTitle *scored
+	candidates := make([]internal.MatchCandidate, 0, n)
 	for i := 0; i < n; i++ {
-		details, err := e.client.GetMovieDetails(int(results[i].ID), map[string]string{"append_to_response": "credits"})
+		details, err := e.client.GetMovieDetails(int(results[i].ID), candidateDetailsOptions)
 		if err != nil {
 			continue
 		}
@@ -190,7 +307,27 @@ func (e *tmdbEnrichment) pickBestResult(results []tmdb.MovieResult, normalizedHi
 		}
 		dirMatch := directorMatch(director, tmdbDirector)
 		diff := runtimeDiff(runtimeMins, details.Runtime) // details.Runtime is minutes
-		s := &scored{r: &results[i], dir: dirMatch, diff: diff}
+		candidate := internal.MatchCandidate{
+			Title:         results[i].Title,
+			TMDBID:        results[i].ID,
+			DirectorMatch: dirMatch,
+			RuntimeDiff:   diff,
+		}
+		if runtimeMins <= 0 {
+			candidate.RuntimeDiff = -1
+		}
+		candidates = append(candidates, candidate)
+		s := &scored{
+			r:        &results[i],
+			dir:      dirMatch,
+			diff:     diff,
+			altTitle: alternativeTitleMatches(details, normalizedHint),
+			details:  detailsOf(details),
+			index:    len(candidates) - 1,
+		}
+		if s.altTitle && bestAltTitle == nil {
+			bestAltTitle = s
+		}
 		if best == nil {
 			best = s
 			continue
@@ -202,21 +339,45 @@ func (e *tmdbEnrichment) pickBestResult(results []tmdb.MovieResult, normalizedHi
 			best = s
 		}
 	}
+	if best != nil && !best.dir && !titleMatched && bestAltTitle != nil {
+		best = bestAltTitle
+	}
 	if best != nil {
-		return best.r
+		candidates[best.index].Chosen = true
+		return best.r, candidates, best.details
+	}
+	return &results[0], candidates, matchDetails{}
+}
+
+// matchAlternativeTitle fetches details for results in order and returns the index and details of
+// the first whose alternative titles include hint.
+func (e *tmdbEnrichment) matchAlternativeTitle(results []tmdb.MovieResult, hint string) (int, matchDetails, bool) {
+	for i := range results {
+		details, err := e.client.GetMovieDetails(int(results[i].ID), candidateDetailsOptions)
+		if err != nil {
+			continue
+		}
+		if alternativeTitleMatches(details, hint) {
+			return i, detailsOf(details), true
+		}
 	}
-	return &results[0]
+	return 0, matchDetails{}, false
+}
+
+// detailsOf keeps what pickBestResult reports from a candidate's details.
+func detailsOf(details *tmdb.MovieDetails) matchDetails {
+	return matchDetails{runtimeMins: details.Runtime, collection: details.BelongsToCollection.Name}
 }
 
 func (e *tmdbEnrichment) Enrich(ctx context.Context, showtime internal.EnrichedShowtime) (internal.EnrichedShowtime, error) {
 	var httpRequests []httpRequestRecord
 	var detailsAudit []struct {
 		MovieID  int
-		CacheHit bool
+		CacheHit httputil.CacheHit
 	}
 	var cacheEvents []struct {
 		Key string
-		Hit bool
+		Hit httputil.CacheHit
 	}
 	e.auditRequests = &httpRequests
 	e.detailsCacheAudit = &detailsAudit
@@ -229,6 +390,28 @@ func (e *tmdbEnrichment) Enrich(ctx context.Context, showtime internal.EnrichedS
 
 	annotations := make(map[string]any)
 
+	// A TMDB id from the venue is an exact match: fetch it directly instead of searching and scoring.
+	if id, ok := tmdbIDFromHint(showtime.Source.TMDBIDHint); ok {
+		details, err := e.client.GetMovieDetails(id, map[string]string{"language": "en-US"})
+		if err == nil {
+			showtime.Movie = tmdbMovieInfo(details.ID, details.Title, details.Overview)
+			showtime.Movie.Collection = details.BelongsToCollection.Name
+			fillRuntime(&showtime.Source, details.Runtime)
+			annotations["match"] = "tmdb_id"
+			annotations["runtime_source"] = showtime.Source.RuntimeSource.String()
+			annotateRequests(annotations, detailsAudit, httpRequests)
+			showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+				Result:      internal.EnrichmentResultSuccess,
+				Details:     "",
+				At:          time.Now(),
+				Annotations: annotations,
+			})
+			return showtime, nil
+		}
+		// Fall back to a title search; the venue's id may be stale or wrong.
+		annotations["tmdb_id_error"] = err.Error()
+	}
+
 	if showtime.Source.TitleHint == "" {
 		annotations["skipped"] = "no title hint"
 		showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
@@ -242,37 +425,70 @@ func (e *tmdbEnrichment) Enrich(ctx context.Context, showtime internal.EnrichedS
 
 	searchTitle := showtime.Source.TitleHint
 	searchResults, err := e.client.GetSearchMovies(searchTitle, map[string]string{
-		"language": "en-US",
+		"language":      "en-US",
+		"include_adult": strconv.FormatBool(e.includeAdult),
 	})
 	if err != nil {
 		return showtime, fmt.Errorf("failed to search for movie with title hint %s: %w", searchTitle, err)
 	}
 	searchCacheHit := searchCacheHitFromEvents(cacheEvents)
 
-	best := e.pickBestResult(
-		searchResults.Results,
+	best, candidates, bestDetails := e.pickBestResult(
+		e.relevantResults(searchResults.Results),
 		searchTitle,
 		showtime.Source.DirectorHint,
 		showtime.Source.RuntimeHint,
 	)
 	if best != nil {
-		showtime.Movie = internal.MovieInfo{
-			Title:    best.Title,
-			Overview: best.Overview,
-			Links: []internal.Link{
-				{
-					Href:    fmt.Sprintf("https://www.themoviedb.org/movie/%d", best.ID),
-					Display: "TMDB",
-				},
-			},
+		if bestDetails.runtimeMins == 0 && showtime.Source.RuntimeHint == 0 {
+			// Without hints nothing was fetched for scoring; fetch the chosen movie's details (with the
+			// scoring options, so a later scored lookup hits the cache) to learn its runtime.
+			if details, err := e.client.GetMovieDetails(int(best.ID), candidateDetailsOptions); err == nil {
+				bestDetails = detailsOf(details)
+			}
 		}
+		showtime.Movie = tmdbMovieInfo(best.ID, best.Title, best.Overview)
+		showtime.Movie.Collection = bestDetails.collection
+		fillRuntime(&showtime.Source, bestDetails.runtimeMins)
 	}
+	annotations["runtime_source"] = showtime.Source.RuntimeSource.String()
 
-	annotations["cache_search"] = map[string]any{"hit": searchCacheHit, "query": searchTitle}
+	annotations["cache_search"] = map[string]any{"hit": searchCacheHit.Hit(), "cache": searchCacheHit.String(), "query": searchTitle}
+	if len(candidates) > 0 {
+		annotations[internal.AnnotationMatchCandidates] = candidates
+	}
+	annotateRequests(annotations, detailsAudit, httpRequests)
+
+	showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+		Result:      internal.EnrichmentResultSuccess,
+		Details:     "",
+		At:          time.Now(),
+		Annotations: annotations,
+	})
+	return showtime, nil
+}
+
+// fillRuntime sets source's runtime from TMDB details (in minutes). TMDB is the lowest-precedence
+// runtime source, so it only fills in when the venue had none; a source with no end time then gets
+// one from its start plus the runtime.
+func fillRuntime(source *internal.SourceShowtime, runtimeMins int) {
+	if !source.SetRuntimeHint(time.Duration(runtimeMins)*time.Minute, internal.RuntimeSourceTMDB) {
+		return
+	}
+	if source.EndTime.IsZero() && !source.StartTime.IsZero() {
+		source.EndTime = source.StartTime.Add(source.RuntimeHint)
+	}
+}
+
+// annotateRequests records the details cache audit and outgoing HTTP requests of one Enrich call.
+func annotateRequests(annotations map[string]any, detailsAudit []struct {
+	MovieID  int
+	CacheHit httputil.CacheHit
+}, httpRequests []httpRequestRecord) {
 	if len(detailsAudit) > 0 {
 		detailsList := make([]map[string]any, len(detailsAudit))
 		for i, d := range detailsAudit {
-			detailsList[i] = map[string]any{"movie_id": d.MovieID, "cache_hit": d.CacheHit}
+			detailsList[i] = map[string]any{"movie_id": d.MovieID, "cache_hit": d.CacheHit.Hit(), "cache": d.CacheHit.String()}
 		}
 		annotations["cache_details"] = detailsList
 	}
@@ -283,12 +499,29 @@ func (e *tmdbEnrichment) Enrich(ctx context.Context, showtime internal.EnrichedS
 		}
 		annotations["http_requests"] = reqs
 	}
+}
 
-	showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
-		Result:      internal.EnrichmentResultSuccess,
-		Details:     "",
-		At:          time.Now(),
-		Annotations: annotations,
-	})
-	return showtime, nil
+// tmdbIDFromHint parses a venue-provided TMDB id; ok is false for empty or non-positive ids.
+func tmdbIDFromHint(hint string) (int, bool) {
+	id, err := strconv.Atoi(strings.TrimSpace(hint))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// tmdbMovieInfo builds the MovieInfo for a matched TMDB movie, linking to its TMDB page.
+func tmdbMovieInfo(id int64, title, overview string) internal.MovieInfo {
+	return internal.MovieInfo{
+		Title:    title,
+		Overview: overview,
+		TMDBID:   id,
+		Links: []internal.Link{
+			{
+				Href:    fmt.Sprintf("https://www.themoviedb.org/movie/%d", id),
+				Display: "TMDB",
+				Rel:     internal.LinkRelTMDB,
+			},
+		},
+	}
 }
@@ -0,0 +1,239 @@
+package enrichment
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/stretchr/testify/require"
+)
+
+// goldenTMDB serves TMDB API responses from golden/tmdb and records each request path. Searches are
+// served from search-movie-<query>.json when one exists for the query, else search-movie.json.
+type goldenTMDB struct {
+	mu       sync.Mutex
+	paths    []string
+	searches []url.Values // query parameters of each search
+}
+
+func (g *goldenTMDB) RoundTrip(req *http.Request) (*http.Response, error) {
+	g.mu.Lock()
+	g.paths = append(g.paths, req.URL.Path)
+	g.mu.Unlock()
+
+	var file string
+	switch path := strings.TrimPrefix(req.URL.Path, "/3"); {
+	case path == "/search/movie":
+		g.mu.Lock()
+		g.searches = append(g.searches, req.URL.Query())
+		g.mu.Unlock()
+		file = "search-movie.json"
+		query := strings.ReplaceAll(strings.ToLower(req.URL.Query().Get("query")), " ", "-")
+		if _, err := os.Stat(filepath.Join("golden", "tmdb", "search-movie-"+query+".json")); err == nil {
+			file = "search-movie-" + query + ".json"
+		}
+	case strings.HasPrefix(path, "/movie/"):
+		file = "movie-" + strings.TrimPrefix(path, "/movie/") + ".json"
+	}
+	body, err := os.ReadFile(filepath.Join("golden", "tmdb", file))
+	if file == "" || err != nil {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody, Header: http.Header{}, Request: req}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       readCloser{bytes.NewReader(body)},
+		Request:    req,
+	}, nil
+}
+
+type readCloser struct{ *bytes.Reader }
+
+func (readCloser) Close() error { return nil }
+
+func newGoldenTMDB(t *testing.T, opts ...TMDBOption) (internal.EnrichmentProvider, *goldenTMDB) {
+	t.Helper()
+	fake := &goldenTMDB{}
+	provider, err := TMDB("test-key", append([]TMDBOption{TMDBWithTransport(fake)}, opts...)...)
+	require.NoError(t, err, "TMDB")
+	return provider, fake
+}
+
+func TestUnit_CacheStats_GoldenRun(t *testing.T) {
+	provider, _ := newGoldenTMDB(t)
+	showtime := internal.SourceShowtime{ID: "heat", TitleHint: "Heat", DirectorHint: "Michael Mann"}
+
+	var stats CacheStats
+	for range 2 {
+		enriched := Enrich(t.Context(), showtime, provider)
+		require.Equal(t, "Heat", enriched.Movie.Title)
+		stats.Add(enriched.Audits)
+	}
+
+	// Two searches (the second cached) and two candidates' details per search (the second run's cached).
+	require.Equal(t, CacheStats{Searches: 2, SearchHits: 1, DetailsCalls: 4, DetailsHits: 2}, stats)
+	require.InDelta(t, 0.5, stats.HitRate(), 0.0001)
+	require.Equal(t, "enrichment cache: 2 searches (1 hits), 4 details calls (2 hits), hit rate 50.0%", stats.String())
+}
+
+func TestUnit_TMDB_DiskCache(t *testing.T) {
+	dir := t.TempDir()
+	showtime := internal.SourceShowtime{ID: "heat", TitleHint: "Heat", DirectorHint: "Michael Mann"}
+	detailsCache := func(enriched internal.EnrichedShowtime) []string {
+		var sources []string
+		details, _ := enriched.Audits[0].Annotations["cache_details"].([]map[string]any)
+		for _, d := range details {
+			sources = append(sources, d["cache"].(string))
+		}
+		return sources
+	}
+
+	provider, fake := newGoldenTMDB(t, TMDBWithDiskCache(dir, time.Hour))
+	enriched := Enrich(t.Context(), showtime, provider)
+	require.Equal(t, []string{"miss", "miss"}, detailsCache(enriched))
+	enriched = Enrich(t.Context(), showtime, provider)
+	require.Equal(t, []string{"memory", "memory"}, detailsCache(enriched))
+	require.Equal(t, []string{"/3/search/movie", "/3/movie/949", "/3/movie/17074"}, fake.paths)
+
+	// A new provider (as in the next CLI run) reads details from disk but searches again.
+	provider, fake = newGoldenTMDB(t, TMDBWithDiskCache(dir, time.Hour))
+	enriched = Enrich(t.Context(), showtime, provider)
+	require.Equal(t, "Heat", enriched.Movie.Title)
+	require.Equal(t, []string{"disk", "disk"}, detailsCache(enriched))
+	require.Equal(t, []string{"/3/search/movie"}, fake.paths)
+
+	var stats CacheStats
+	stats.Add(enriched.Audits)
+	require.Equal(t, CacheStats{Searches: 1, DetailsCalls: 2, DetailsHits: 2}, stats, "disk hits count as cache hits")
+}
+
+func TestUnit_CacheStats_Empty(t *testing.T) {
+	var stats CacheStats
+	stats.Add([]internal.EnrichmentAudit{{Annotations: map[string]any{"skipped": "no title hint"}}})
+	require.Zero(t, stats)
+	require.Zero(t, stats.HitRate())
+}
+
+func TestUnit_TMDB_MatchByIDHint(t *testing.T) {
+	provider, fake := newGoldenTMDB(t)
+	// The title alone would be ambiguous between the two "Heat" search results.
+	showtime := internal.SourceShowtime{ID: "heat", TitleHint: "Heat", TMDBIDHint: "17074"}
+
+	enriched := Enrich(t.Context(), showtime, provider)
+
+	require.Equal(t, "Heat", enriched.Movie.Title)
+	require.Equal(t, "https://www.themoviedb.org/movie/17074", enriched.Movie.Links[0].Href)
+	require.Equal(t, internal.LinkRelTMDB, enriched.Movie.Links[0].Rel)
+	require.Equal(t, 101*time.Minute, enriched.Source.RuntimeHint)
+	require.Equal(t, []string{"/3/movie/17074"}, fake.paths, "id hint should fetch details without searching")
+	require.Equal(t, "tmdb_id", enriched.Audits[0].Annotations["match"])
+}
+
+func TestUnit_TMDB_MatchByIDHint_FallsBackToSearch(t *testing.T) {
+	provider, fake := newGoldenTMDB(t)
+	showtime := internal.SourceShowtime{ID: "heat", TitleHint: "Heat", DirectorHint: "Michael Mann", TMDBIDHint: "404"}
+
+	enriched := Enrich(t.Context(), showtime, provider)
+
+	require.Equal(t, "https://www.themoviedb.org/movie/949", enriched.Movie.Links[0].Href)
+	require.Contains(t, fake.paths, "/3/search/movie")
+	require.Contains(t, enriched.Audits[0].Annotations, "tmdb_id_error")
+}
+
+func TestUnit_TMDB_FillsMissingRuntime(t *testing.T) {
+	provider, fake := newGoldenTMDB(t)
+	// Like a Hollywood Theatre listing without a calendar match: no director, runtime, or end time.
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	showtime := internal.SourceShowtime{ID: "heat", TitleHint: "Heat", StartTime: start}
+
+	enriched := Enrich(t.Context(), showtime, provider)
+
+	require.Equal(t, "https://www.themoviedb.org/movie/949", enriched.Movie.Links[0].Href)
+	require.Equal(t, 170*time.Minute, enriched.Source.RuntimeHint)
+	require.Equal(t, internal.RuntimeSourceTMDB, enriched.Source.RuntimeSource)
+	require.Equal(t, start.Add(170*time.Minute), enriched.Source.EndTime)
+	require.Equal(t, []string{"/3/search/movie", "/3/movie/949"}, fake.paths)
+
+	// A listed runtime wins over TMDB's.
+	showtime.SetRuntimeHint(175*time.Minute, internal.RuntimeSourceListing)
+	enriched = Enrich(t.Context(), showtime, provider)
+	require.Equal(t, 175*time.Minute, enriched.Source.RuntimeHint)
+	require.True(t, enriched.Source.EndTime.IsZero(), "end time is only derived from a TMDB runtime")
+}
+
+func TestUnit_TMDB_Collection(t *testing.T) {
+	provider, _ := newGoldenTMDB(t)
+
+	enriched := Enrich(t.Context(), internal.SourceShowtime{ID: "alien", TitleHint: "Alien", TMDBIDHint: "348"}, provider)
+	require.Equal(t, "Alien", enriched.Movie.Title)
+	require.Equal(t, "Alien Collection", enriched.Movie.Collection)
+
+	// Heat's details (fetched for director scoring) belong to no collection.
+	enriched = Enrich(t.Context(), internal.SourceShowtime{ID: "heat", TitleHint: "Heat", DirectorHint: "Michael Mann"}, provider)
+	require.Equal(t, "Heat", enriched.Movie.Title)
+	require.Empty(t, enriched.Movie.Collection)
+}
+
+func TestUnit_TMDB_MatchByAlternativeTitle(t *testing.T) {
+	provider, fake := newGoldenTMDB(t)
+	// Listed under its romanized title; TMDB searches return it as "Seven Samurai" behind a decoy.
+	showtime := internal.SourceShowtime{ID: "seven-samurai", TitleHint: "Shichinin no Samurai"}
+
+	enriched := Enrich(t.Context(), showtime, provider)
+
+	require.Equal(t, "Seven Samurai", enriched.Movie.Title)
+	require.Equal(t, "https://www.themoviedb.org/movie/346", enriched.Movie.Links[0].Href)
+	require.Equal(t, 207*time.Minute, enriched.Source.RuntimeHint, "runtime comes from the details already fetched")
+	require.Equal(t, []string{"/3/search/movie", "/3/movie/966", "/3/movie/346"}, fake.paths)
+
+	// With a runtime hint the details fetched for scoring are reused; the alternative title still decides
+	// even though the decoy's runtime is closer.
+	fake.paths = nil
+	showtime.RuntimeHint = 130 * time.Minute
+	enriched = Enrich(t.Context(), showtime, provider)
+	require.Equal(t, "Seven Samurai", enriched.Movie.Title)
+	require.Empty(t, fake.paths, "search and details are served from the cache")
+}
+
+func TestUnit_TMDB_TitleMatchBeyondDetailCandidates(t *testing.T) {
+	provider, _ := newGoldenTMDB(t)
+	// The exact title is the seventh result, past the first maxCandidatesForDetails.
+	showtime := internal.SourceShowtime{ID: "conversation", TitleHint: "The Conversation"}
+
+	enriched := Enrich(t.Context(), showtime, provider)
+
+	require.Equal(t, "https://www.themoviedb.org/movie/592", enriched.Movie.Links[0].Href)
+	candidates, _ := enriched.Audits[0].Annotations[internal.AnnotationMatchCandidates].([]internal.MatchCandidate)
+	require.Len(t, candidates, maxCandidatesForDetails+1)
+	chosen := candidates[len(candidates)-1]
+	require.True(t, chosen.Chosen, "the chosen result is listed among the candidates")
+	require.Equal(t, "The Conversation", chosen.Title)
+	for _, c := range candidates[:maxCandidatesForDetails] {
+		require.False(t, c.Chosen, c.Title)
+	}
+}
+
+func TestUnit_TMDB_ExcludesAdultAndUnratedResults(t *testing.T) {
+	showtime := internal.SourceShowtime{ID: "vice", TitleHint: "Vice"}
+
+	provider, fake := newGoldenTMDB(t)
+	enriched := Enrich(t.Context(), showtime, provider)
+	require.Equal(t, "https://www.themoviedb.org/movie/429197", enriched.Movie.Links[0].Href,
+		"the adult result and the one without votes or popularity are skipped")
+	require.Len(t, fake.searches, 1)
+	require.Equal(t, "false", fake.searches[0].Get("include_adult"))
+	candidates, _ := enriched.Audits[0].Annotations[internal.AnnotationMatchCandidates].([]internal.MatchCandidate)
+	require.Len(t, candidates, 1)
+
+	provider, fake = newGoldenTMDB(t, TMDBWithIncludeAdult(true))
+	enriched = Enrich(t.Context(), showtime, provider)
+	require.Equal(t, "https://www.themoviedb.org/movie/1190001", enriched.Movie.Links[0].Href)
+	require.Equal(t, "true", fake.searches[0].Get("include_adult"))
+}
@@ -0,0 +1,53 @@
+// Package geo has small geographic helpers for filtering venues by location.
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0
+
+var errInvalidPoint = errors.New("expected LAT,LON")
+
+// Point is a latitude/longitude pair in decimal degrees.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// IsZero reports whether p is unset.
+func (p Point) IsZero() bool {
+	return p == Point{}
+}
+
+// ParsePoint parses "LAT,LON" (e.g. "45.5265,-122.6945").
+func ParsePoint(s string) (Point, error) {
+	latStr, lonStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return Point{}, fmt.Errorf("%w: %q", errInvalidPoint, s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return Point{}, fmt.Errorf("%w: invalid latitude in %q", errInvalidPoint, s)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return Point{}, fmt.Errorf("%w: invalid longitude in %q", errInvalidPoint, s)
+	}
+	return Point{Lat: lat, Lon: lon}, nil
+}
+
+// DistanceKm returns the great-circle (haversine) distance between a and b in kilometers.
+func DistanceKm(a, b Point) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(b.Lat - a.Lat)
+	dLon := toRad(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Lat))*math.Cos(toRad(b.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
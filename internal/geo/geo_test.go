@@ -0,0 +1,27 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_DistanceKm(t *testing.T) {
+	portland := Point{Lat: 45.5152, Lon: -122.6784}
+	seattle := Point{Lat: 47.6062, Lon: -122.3321}
+
+	require.InDelta(t, 233.6, DistanceKm(portland, seattle), 1)
+	require.InDelta(t, DistanceKm(portland, seattle), DistanceKm(seattle, portland), 1e-9)
+	require.Zero(t, DistanceKm(portland, portland))
+}
+
+func TestUnit_ParsePoint(t *testing.T) {
+	p, err := ParsePoint("45.5265, -122.6945")
+	require.NoError(t, err)
+	require.Equal(t, Point{Lat: 45.5265, Lon: -122.6945}, p)
+
+	for _, bad := range []string{"", "45.5", "north,west", "91,0", "0,181"} {
+		_, err := ParsePoint(bad)
+		require.Error(t, err, "ParsePoint(%q)", bad)
+	}
+}
@@ -0,0 +1,69 @@
+// Package htmltext converts HTML fragments from theater feeds into plain text.
+package htmltext
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Plain returns the text content of an HTML fragment with entities decoded (&amp; becomes &).
+// Block-level tags and <br> become line breaks; whitespace within a line is collapsed, blank lines
+// are dropped, and script/style contents are skipped. Text without markup only has its entities
+// decoded and whitespace normalized.
+func Plain(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return normalizeSpace(s)
+	}
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	skip := 0 // depth inside <script>/<style>
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// io.EOF, or malformed input: keep what was decoded so far.
+			return normalizeSpace(b.String())
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(z.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch a := atom.Lookup(name); {
+			case a == atom.Script || a == atom.Style:
+				skip++
+			case breaksLine(a):
+				b.WriteByte('\n')
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch a := atom.Lookup(name); {
+			case (a == atom.Script || a == atom.Style) && skip > 0:
+				skip--
+			case breaksLine(a):
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+func breaksLine(a atom.Atom) bool {
+	switch a {
+	case atom.Br, atom.P, atom.Div, atom.Li, atom.Ul, atom.Ol, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote:
+		return true
+	}
+	return false
+}
+
+// normalizeSpace collapses whitespace within each line and drops blank lines.
+func normalizeSpace(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,31 @@
+package htmltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Plain(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "Heat", want: "Heat"},
+		{name: "tags", in: "<p>A <em>crime</em> epic.</p>", want: "A crime epic."},
+		{name: "named entities", in: "Tom &amp; Jerry &quot;live&quot; &mdash; tonight", want: "Tom & Jerry \"live\" — tonight"},
+		{name: "numeric entities", in: "Director&#39;s cut &#x2014; 35mm", want: "Director's cut — 35mm"},
+		{name: "entities without tags", in: "Q&amp;A after the film", want: "Q&A after the film"},
+		{name: "line breaks", in: "<p>First.</p><p>Second.<br/>Third.</p>", want: "First.\nSecond.\nThird."},
+		{name: "whitespace", in: "  A\t\tcrime\n\n\n  epic  ", want: "A crime\nepic"},
+		{name: "script skipped", in: "Heat<script>alert('x')</script> (1995)", want: "Heat (1995)"},
+		{name: "encoded tag stays text", in: "<p>&lt;b&gt;bold&lt;/b&gt;</p>", want: "<b>bold</b>"},
+		{name: "bare ampersand", in: "Rock & Roll", want: "Rock & Roll"},
+		{name: "empty", in: "", want: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Plain(tc.in))
+		})
+	}
+}
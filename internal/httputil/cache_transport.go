@@ -3,10 +3,12 @@ package httputil
 import (
 	"bytes"
 	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/golang-lru/v2"
@@ -15,9 +17,10 @@ import (
 const defaultLRUMaxEntries = 1000
 
 // CacheTransport is an http.RoundTripper that caches GET responses by request key (Method + URL).
-// Cache hits are served from memory; misses are forwarded to Base and cached on success (2xx).
-// The cache uses LRU eviction when it reaches MaxEntries. Concurrent requests do not block each other;
-// duplicate requests for the same key may both hit the backend.
+// Cache hits are served from memory, then from Disk if set; misses are forwarded to Base and cached
+// on success (2xx).
+// The cache uses LRU eviction when it reaches MaxEntries, or when cached bodies exceed MaxBytes if set.
+// Concurrent requests do not block each other; duplicate requests for the same key may both hit the backend.
 type CacheTransport struct {
 	Base http.RoundTripper
 
@@ -25,13 +28,43 @@ type CacheTransport struct {
 	// Zero means defaultLRUMaxEntries (1000).
 	MaxEntries int
 
-	// OnCacheHit, if set, is called for every RoundTrip with the cache key and whether it was a hit.
-	// Useful for audit/logging.
-	OnCacheHit func(cacheKey string, hit bool)
+	// MaxBytes, if positive, caps the total size of cached response bodies; least recently used
+	// entries are evicted to stay under it, and a single body larger than MaxBytes is not cached.
+	MaxBytes int64
+
+	// Disk, if set, persists responses beneath the memory cache so later processes can reuse them.
+	Disk *DiskCache
+
+	// OnCacheHit, if set, is called for every RoundTrip with the cache key and where the response
+	// came from. Useful for audit/logging.
+	OnCacheHit func(cacheKey string, hit CacheHit)
 
 	initOnce sync.Once
 	cache    *lru.Cache[string, *cachedResponse]
 	initErr  error
+	bytes    atomic.Int64 // total body size of cached entries
+}
+
+// CacheHit says where a CacheTransport served a response from.
+type CacheHit uint8
+
+const (
+	CacheMiss      CacheHit = iota // fetched from Base
+	CacheHitMemory                 // served from the in-memory LRU
+	CacheHitDisk                   // served from the DiskCache (and now also held in memory)
+)
+
+// Hit reports whether the response was served from either cache layer.
+func (h CacheHit) Hit() bool { return h != CacheMiss }
+
+func (h CacheHit) String() string {
+	switch h {
+	case CacheHitMemory:
+		return "memory"
+	case CacheHitDisk:
+		return "disk"
+	}
+	return "miss"
 }
 
 type cachedResponse struct {
@@ -47,7 +80,9 @@ func (t *CacheTransport) ensureCache() error {
 		if max <= 0 {
 			max = defaultLRUMaxEntries
 		}
-		t.cache, t.initErr = lru.New[string, *cachedResponse](max)
+		t.cache, t.initErr = lru.NewWithEvict(max, func(_ string, entry *cachedResponse) {
+			t.bytes.Add(-int64(len(entry.Body)))
+		})
 	})
 	return t.initErr
 }
@@ -57,7 +92,7 @@ func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if err := t.ensureCache(); err != nil {
 		return nil, err
 	}
-	key := req.Method + " " + req.URL.String()
+	key := cacheKey(req)
 	base := t.Base
 	if base == nil {
 		base = http.DefaultTransport
@@ -68,13 +103,18 @@ func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		// fall through to base
 	} else if entry, ok := t.cache.Get(key); ok {
 		if entry.Expires.IsZero() || time.Now().Before(entry.Expires) {
-			if t.OnCacheHit != nil {
-				t.OnCacheHit(key, true)
-			}
+			t.report(key, CacheHitMemory)
 			return t.responseFromCache(req, entry), nil
 		}
 		t.cache.Remove(key)
 	}
+	if t.Disk != nil && !requestWantsFresh(req) {
+		if entry, ok := t.Disk.load(key, time.Now()); ok {
+			t.add(key, entry)
+			t.report(key, CacheHitDisk)
+			return t.responseFromCache(req, entry), nil
+		}
+	}
 
 	resp, err := base.RoundTrip(req)
 	if err != nil {
@@ -82,16 +122,12 @@ func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 	// Only cache GET with 2xx and when response allows caching.
 	if req.Method != http.MethodGet || resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if t.OnCacheHit != nil {
-			t.OnCacheHit(key, false)
-		}
+		t.report(key, CacheMiss)
 		return resp, nil
 	}
 	noStore, maxAge := responseCacheControl(resp.Header)
 	if noStore {
-		if t.OnCacheHit != nil {
-			t.OnCacheHit(key, false)
-		}
+		t.report(key, CacheMiss)
 		return resp, nil
 	}
 	body, err := io.ReadAll(resp.Body)
@@ -105,15 +141,63 @@ func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		Body:    body,
 		Expires: cacheExpires(maxAge),
 	}
-	t.cache.Add(key, entry)
-	if t.OnCacheHit != nil {
-		t.OnCacheHit(key, false)
+	t.add(key, entry)
+	if t.Disk != nil {
+		if err := t.Disk.store(key, entry, time.Now()); err != nil {
+			slog.Debug("http disk cache: store failed", "key", key, "error", err)
+		}
 	}
+	t.report(key, CacheMiss)
 	resp.Body = io.NopCloser(bytes.NewReader(body))
 	resp.ContentLength = int64(len(body))
 	return resp, nil
 }
 
+func (t *CacheTransport) report(key string, hit CacheHit) {
+	if t.OnCacheHit != nil {
+		t.OnCacheHit(key, hit)
+	}
+}
+
+// add caches entry under key, then evicts least recently used entries while over MaxBytes.
+func (t *CacheTransport) add(key string, entry *cachedResponse) {
+	size := int64(len(entry.Body))
+	if t.MaxBytes > 0 && size > t.MaxBytes {
+		return
+	}
+	// Replacing a key in place doesn't fire the evict callback, so drop the old entry first to release its size.
+	t.cache.Remove(key)
+	t.cache.Add(key, entry)
+	t.bytes.Add(size)
+	for t.MaxBytes > 0 && t.bytes.Load() > t.MaxBytes {
+		if _, _, ok := t.cache.RemoveOldest(); !ok {
+			break
+		}
+	}
+}
+
+// Purge drops every cached response, releasing their memory (e.g. on server shutdown or between
+// long-running jobs). The transport stays usable and refills on later requests.
+func (t *CacheTransport) Purge() {
+	if err := t.ensureCache(); err != nil {
+		return
+	}
+	t.cache.Purge()
+}
+
+// Bytes returns the total body size of cached responses.
+func (t *CacheTransport) Bytes() int64 {
+	return t.bytes.Load()
+}
+
+// Len returns the number of cached responses.
+func (t *CacheTransport) Len() int {
+	if err := t.ensureCache(); err != nil {
+		return 0
+	}
+	return t.cache.Len()
+}
+
 func (t *CacheTransport) responseFromCache(req *http.Request, entry *cachedResponse) *http.Response {
 	return &http.Response{
 		Status:        http.StatusText(entry.Status),
@@ -179,3 +263,11 @@ func cacheExpires(maxAgeSeconds int) time.Time {
 	}
 	return time.Now().Add(time.Duration(maxAgeSeconds) * time.Second)
 }
+
+// cacheKey is the request's method and URL with query parameters sorted, so the same request built
+// from an options map (whose iteration order varies) always hits the same entry.
+func cacheKey(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery = u.Query().Encode()
+	return req.Method + " " + u.String()
+}
@@ -0,0 +1,182 @@
+package httputil
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_CacheTransport_Purge(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	transport := &CacheTransport{Base: server.Client().Transport}
+	client := &http.Client{Transport: transport}
+
+	get := func(path string) string {
+		t.Helper()
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	require.Equal(t, "/a", get("/a"))
+	require.Equal(t, "/b", get("/b"))
+	require.Equal(t, "/a", get("/a"))
+	require.Equal(t, int32(2), requests.Load(), "the repeat is served from cache")
+	require.Equal(t, 2, transport.Len())
+
+	transport.Purge()
+	require.Zero(t, transport.Len())
+
+	require.Equal(t, "/a", get("/a"))
+	require.Equal(t, int32(3), requests.Load(), "purged entries are fetched again")
+	require.Equal(t, "/a", get("/a"))
+	require.Equal(t, int32(3), requests.Load(), "and cached again")
+	require.Equal(t, 1, transport.Len())
+}
+
+func TestUnit_CacheTransport_MaxBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		_, _ = w.Write(bytes.Repeat([]byte("x"), size))
+	}))
+	t.Cleanup(server.Close)
+	transport := &CacheTransport{Base: server.Client().Transport, MaxBytes: 1000}
+	client := &http.Client{Transport: transport}
+	get := func(path string) {
+		t.Helper()
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	get("/a?size=400")
+	get("/b?size=400")
+	require.Equal(t, 2, transport.Len())
+	require.Equal(t, int64(800), transport.Bytes())
+
+	// A third 400-byte body takes the total to 1200, so the least recently used entry goes.
+	get("/c?size=400")
+	require.Equal(t, 2, transport.Len())
+	require.Equal(t, int64(800), transport.Bytes())
+	_, ok := transport.cache.Peek("GET " + server.URL + "/a?size=400")
+	require.False(t, ok, "oldest entry evicted")
+
+	// A body over the whole budget is passed through but never cached.
+	get("/huge?size=5000")
+	require.Equal(t, 2, transport.Len())
+	require.Equal(t, int64(800), transport.Bytes())
+
+	transport.Purge()
+	require.Zero(t, transport.Bytes())
+}
+
+func TestUnit_CacheTransport_Disk(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	disk := &DiskCache{Dir: t.TempDir(), TTL: time.Hour, Persist: func(key string) bool { return !strings.HasSuffix(key, "/search") }}
+	// newTransport stands in for a fresh process: an empty memory cache over the shared disk.
+	newTransport := func() (*http.Client, *[]CacheHit) {
+		var hits []CacheHit
+		transport := &CacheTransport{
+			Base:       server.Client().Transport,
+			Disk:       disk,
+			OnCacheHit: func(_ string, hit CacheHit) { hits = append(hits, hit) },
+		}
+		return &http.Client{Transport: transport}, &hits
+	}
+	get := func(client *http.Client, path string) {
+		t.Helper()
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, path, string(body))
+		require.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+	}
+
+	client, hits := newTransport()
+	get(client, "/movie/1")
+	get(client, "/movie/1")
+	get(client, "/search")
+	require.Equal(t, []CacheHit{CacheMiss, CacheHitMemory, CacheMiss}, *hits)
+	require.Equal(t, int32(2), requests.Load())
+
+	client, hits = newTransport()
+	get(client, "/movie/1")
+	get(client, "/movie/1")
+	get(client, "/search")
+	require.Equal(t, []CacheHit{CacheHitDisk, CacheHitMemory, CacheMiss}, *hits, "only persisted keys survive the process")
+	require.Equal(t, int32(3), requests.Load())
+
+	disk.TTL = time.Nanosecond // entries stored from now on are stale by the next lookup
+	client, _ = newTransport()
+	get(client, "/movie/2")
+	client, hits = newTransport()
+	get(client, "/movie/2")
+	require.Equal(t, []CacheHit{CacheMiss}, *hits, "expired entries are fetched again")
+}
+
+func TestUnit_CacheTransport_DiskNeverStoresAPIKey(t *testing.T) {
+	const secret = "s3cr3t-tmdb-key"
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = io.WriteString(w, `{"id": 949}`)
+	}))
+	t.Cleanup(server.Close)
+	dir := t.TempDir()
+	get := func() CacheHit {
+		t.Helper()
+		var hit CacheHit
+		transport := &CacheTransport{
+			Base:       server.Client().Transport,
+			Disk:       &DiskCache{Dir: dir},
+			OnCacheHit: func(_ string, h CacheHit) { hit = h },
+		}
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL + "/3/movie/949?api_key=" + secret + "&language=en-US")
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+		return hit
+	}
+
+	require.Equal(t, CacheMiss, get())
+	require.Equal(t, CacheHitDisk, get(), "the redacted key still finds the entry")
+	require.Equal(t, int32(1), requests.Load())
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	for _, path := range paths {
+		require.NotContains(t, path, secret)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), secret)
+		require.Contains(t, string(data), "language=en-US", "other parameters stay in the key")
+	}
+}
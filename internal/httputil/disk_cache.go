@@ -0,0 +1,135 @@
+package httputil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DiskCache is an on-disk layer under a CacheTransport's memory cache, so responses outlive the
+// process (e.g. across CLI invocations). Entries are JSON files keyed by the same Method + URL key
+// as the memory cache, minus credential query parameters (see redactedParams), and are replaced
+// atomically; corrupt, expired, or unreadable files count as misses.
+type DiskCache struct {
+	// Dir holds one file per cached response. It is created on first store.
+	Dir string
+
+	// TTL is how long a stored response stays fresh. Zero means it never expires.
+	TTL time.Duration
+
+	// Persist, if set, reports whether the response for a cache key should be written to disk;
+	// other responses are cached in memory only. Nil persists every cacheable response.
+	Persist func(cacheKey string) bool
+}
+
+// diskResponse is the on-disk form of a cachedResponse. A zero Expires never expires.
+type diskResponse struct {
+	Key     string      `json:"key"`
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header"`
+	Body    []byte      `json:"body"`
+	Expires time.Time   `json:"expires,omitzero"`
+}
+
+// redactedParams are query parameters that carry credentials (e.g. TMDB's api_key). They are
+// dropped from keys before hashing or storing, so secrets never reach the cache directory.
+var redactedParams = []string{"api_key", "access_token"}
+
+// diskKey returns key without credential query parameters.
+func diskKey(key string) string {
+	method, rawURL, ok := strings.Cut(key, " ")
+	if !ok {
+		return key
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return key
+	}
+	q := u.Query()
+	for _, param := range redactedParams {
+		q.Del(param)
+	}
+	u.RawQuery = q.Encode()
+	return method + " " + u.String()
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func (d *DiskCache) persists(key string) bool {
+	return d.Persist == nil || d.Persist(key)
+}
+
+// load returns the fresh response stored for key, if any.
+func (d *DiskCache) load(key string, now time.Time) (*cachedResponse, bool) {
+	if !d.persists(key) {
+		return nil, false
+	}
+	key = diskKey(key)
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Debug("http disk cache: read failed", "path", path, "error", err)
+		}
+		return nil, false
+	}
+	var stored diskResponse
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Key != key {
+		// Corrupt, or a hash collision: treat as a miss and let the next store replace it.
+		slog.Debug("http disk cache: ignoring unreadable entry", "path", path, "error", err)
+		return nil, false
+	}
+	if !stored.Expires.IsZero() && !now.Before(stored.Expires) {
+		return nil, false
+	}
+	return &cachedResponse{Status: stored.Status, Header: stored.Header, Body: stored.Body, Expires: stored.Expires}, true
+}
+
+// store writes entry for key via a temp file and rename, so readers never see a partial file. The
+// stored copy expires after TTL regardless of the entry's own expiry.
+func (d *DiskCache) store(key string, entry *cachedResponse, now time.Time) error {
+	if !d.persists(key) {
+		return nil
+	}
+	key = diskKey(key)
+	stored := diskResponse{Key: key, Status: entry.Status, Header: entry.Header, Body: entry.Body}
+	if d.TTL > 0 {
+		stored.Expires = now.Add(d.TTL)
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("encode http disk cache entry: %w", err)
+	}
+	if err := os.MkdirAll(d.Dir, 0o750); err != nil {
+		return fmt.Errorf("create http disk cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(d.Dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create http disk cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write http disk cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write http disk cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		return fmt.Errorf("replace http disk cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package httputil
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// LoggingTransport is an http.RoundTripper that logs each request's method, URL, response status,
+// and duration at debug level. Requests are forwarded to Base (http.DefaultTransport when nil).
+type LoggingTransport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	duration := time.Since(start)
+	if err != nil {
+		slog.DebugContext(req.Context(), "http request failed",
+			"method", req.Method,
+			"url", req.URL.String(),
+			"duration", duration,
+			"error", err,
+		)
+		return nil, err
+	}
+	slog.DebugContext(req.Context(), "http request",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"status", resp.StatusCode,
+		"duration", duration,
+	)
+	return resp, nil
+}
@@ -0,0 +1,132 @@
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// PageToken is replaced with the page number (starting at 1) in FetchPaged URL templates.
+const PageToken = "{page}"
+
+const (
+	defaultPagedConcurrency = 4
+	defaultPagedMaxPages    = 100
+)
+
+// sharedCacheTransport backs SharedCacheTransport.
+var sharedCacheTransport = sync.OnceValue(func() *CacheTransport {
+	return &CacheTransport{Base: http.DefaultTransport}
+})
+
+// SharedCacheTransport returns the process-wide response cache used by FetchPaged (when no client is
+// given) and other helpers that fetch the same URLs repeatedly.
+func SharedCacheTransport() *CacheTransport {
+	return sharedCacheTransport()
+}
+
+// PagedOption configures FetchPaged.
+type PagedOption func(*pagedFetch)
+
+// PagedWithClient sets the client pages are fetched with. Default: a client over the shared cache transport.
+func PagedWithClient(client *http.Client) PagedOption {
+	return func(p *pagedFetch) {
+		if client != nil {
+			p.client = client
+		}
+	}
+}
+
+// PagedWithConcurrency bounds how many pages are requested at once (default 4). Up to n-1 pages past
+// the last one may be requested and discarded.
+func PagedWithConcurrency(n int) PagedOption {
+	return func(p *pagedFetch) {
+		if n > 0 {
+			p.concurrency = n
+		}
+	}
+}
+
+// PagedWithMaxPages stops after n pages even if hasNext keeps returning true (default 100).
+func PagedWithMaxPages(n int) PagedOption {
+	return func(p *pagedFetch) {
+		if n > 0 {
+			p.maxPages = n
+		}
+	}
+}
+
+type pagedFetch struct {
+	client      *http.Client
+	concurrency int
+	maxPages    int
+}
+
+// FetchPaged GETs urlTemplate for pages 1, 2, ... (PageToken in the template is replaced with the page
+// number) and returns the page bodies in order. hasNext is called for each page in order; the page is
+// kept, and fetching stops after the first page it returns false for (e.g. an empty result list).
+// Pages are requested in batches of at most the configured concurrency. Any non-2xx page is an error.
+func FetchPaged(ctx context.Context, urlTemplate string, hasNext func(page int, body []byte) bool, opts ...PagedOption) ([][]byte, error) {
+	if !strings.Contains(urlTemplate, PageToken) {
+		return nil, fmt.Errorf("url template %q has no %s placeholder", urlTemplate, PageToken)
+	}
+	p := &pagedFetch{
+		concurrency: defaultPagedConcurrency,
+		maxPages:    defaultPagedMaxPages,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.client == nil {
+		p.client = &http.Client{Transport: sharedCacheTransport()}
+	}
+
+	var pages [][]byte
+	for first := 1; first <= p.maxPages; first += p.concurrency {
+		last := min(first+p.concurrency-1, p.maxPages)
+		batch := make([][]byte, last-first+1)
+		g, gctx := errgroup.WithContext(ctx)
+		for page := first; page <= last; page++ {
+			g.Go(func() error {
+				body, err := p.fetch(gctx, strings.ReplaceAll(urlTemplate, PageToken, strconv.Itoa(page)))
+				if err != nil {
+					return fmt.Errorf("fetch page %d: %w", page, err)
+				}
+				batch[page-first] = body
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+		for i, body := range batch {
+			pages = append(pages, body)
+			if !hasNext(first+i, body) {
+				return pages, nil
+			}
+		}
+	}
+	return pages, nil
+}
+
+func (p *pagedFetch) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,122 @@
+package httputil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pagedServer serves ?page=N as a JSON array of ids, perPage per page, until total items are exhausted.
+type pagedServer struct {
+	total, perPage int
+	requests       atomic.Int32
+	inFlight       atomic.Int32
+	maxInFlight    atomic.Int32
+}
+
+func (s *pagedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.maxInFlight.Load()
+		if n <= peak || s.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond) // let concurrent requests overlap
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		http.Error(w, "bad page", http.StatusBadRequest)
+		return
+	}
+	ids := []int{}
+	for id := (page-1)*s.perPage + 1; id <= min(page*s.perPage, s.total); id++ {
+		ids = append(ids, id)
+	}
+	_ = json.NewEncoder(w).Encode(ids)
+}
+
+func nonEmptyPage(_ int, body []byte) bool {
+	var ids []int
+	return json.Unmarshal(body, &ids) == nil && len(ids) > 0
+}
+
+func TestUnit_FetchPaged(t *testing.T) {
+	srv := &pagedServer{total: 23, perPage: 5}
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+
+	pages, err := FetchPaged(t.Context(), server.URL+"/items?page={page}", nonEmptyPage,
+		PagedWithClient(server.Client()), PagedWithConcurrency(2))
+	require.NoError(t, err)
+
+	// Five pages of items, then the empty page that ended the sequence.
+	require.Len(t, pages, 6)
+	var ids []int
+	for _, body := range pages {
+		var page []int
+		require.NoError(t, json.Unmarshal(body, &page))
+		ids = append(ids, page...)
+	}
+	require.Len(t, ids, 23)
+	for i, id := range ids {
+		require.Equal(t, i+1, id, "pages should be returned in order")
+	}
+	require.LessOrEqual(t, srv.maxInFlight.Load(), int32(2), "concurrency should be bounded")
+	require.LessOrEqual(t, srv.requests.Load(), int32(7), "at most concurrency-1 pages past the end")
+}
+
+func TestUnit_FetchPaged_MaxPages(t *testing.T) {
+	srv := &pagedServer{total: 1000, perPage: 10}
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+
+	pages, err := FetchPaged(t.Context(), server.URL+"/items?page={page}", nonEmptyPage,
+		PagedWithClient(server.Client()), PagedWithMaxPages(3))
+	require.NoError(t, err)
+	require.Len(t, pages, 3)
+	require.Equal(t, int32(3), srv.requests.Load())
+}
+
+func TestUnit_FetchPaged_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		_, _ = fmt.Fprint(w, "[1]")
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := FetchPaged(t.Context(), server.URL+"/items?page={page}", nonEmptyPage, PagedWithClient(server.Client()))
+	require.ErrorContains(t, err, "fetch page 2")
+
+	_, err = FetchPaged(context.Background(), server.URL+"/items", nonEmptyPage)
+	require.ErrorContains(t, err, PageToken)
+}
+
+func TestUnit_FetchPaged_SharedCache(t *testing.T) {
+	srv := &pagedServer{total: 8, perPage: 5}
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+	url := server.URL + "/items?page={page}"
+
+	first, err := FetchPaged(t.Context(), url, nonEmptyPage, PagedWithConcurrency(1))
+	require.NoError(t, err)
+	requests := srv.requests.Load()
+
+	second, err := FetchPaged(t.Context(), url, nonEmptyPage, PagedWithConcurrency(1))
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+	require.Equal(t, requests, srv.requests.Load(), "second fetch should be served from the shared cache")
+}
@@ -0,0 +1,39 @@
+package httputil
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRetryAfter caps how long RetryAfter will ask callers to wait, so a misbehaving upstream
+// can't stall a scrape indefinitely.
+const MaxRetryAfter = 2 * time.Minute
+
+// RetryAfter parses a response's Retry-After header, in either its delay-seconds ("120") or
+// HTTP-date ("Wed, 21 Oct 2026 07:28:00 GMT") form, into how long to wait from now. The wait is
+// capped at limit (MaxRetryAfter when limit <= 0); dates in the past mean no wait. ok is false when the
+// header is missing or malformed, in which case callers should fall back to their own backoff.
+func RetryAfter(header http.Header, now time.Time, limit time.Duration) (wait time.Duration, ok bool) {
+	if limit <= 0 {
+		limit = MaxRetryAfter
+	}
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		wait = time.Duration(seconds) * time.Second
+	} else {
+		at, err := http.ParseTime(value)
+		if err != nil {
+			return 0, false
+		}
+		wait = max(0, at.Sub(now))
+	}
+	return min(wait, limit), true
+}
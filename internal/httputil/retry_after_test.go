@@ -0,0 +1,44 @@
+package httputil
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_RetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	header := func(v string) http.Header {
+		h := http.Header{}
+		if v != "" {
+			h.Set("Retry-After", v)
+		}
+		return h
+	}
+
+	for _, tc := range []struct {
+		name  string
+		value string
+		limit time.Duration
+		want  time.Duration
+		ok    bool
+	}{
+		{name: "seconds", value: "30", want: 30 * time.Second, ok: true},
+		{name: "zero seconds", value: "0", want: 0, ok: true},
+		{name: "http date", value: "Thu, 15 Oct 2026 12:00:45 GMT", want: 45 * time.Second, ok: true},
+		{name: "http date in the past", value: "Thu, 15 Oct 2026 11:59:00 GMT", want: 0, ok: true},
+		{name: "seconds capped", value: "86400", want: MaxRetryAfter, ok: true},
+		{name: "http date capped by limit", value: "Thu, 15 Oct 2026 13:00:00 GMT", limit: 10 * time.Second, want: 10 * time.Second, ok: true},
+		{name: "missing", value: "", ok: false},
+		{name: "negative", value: "-5", ok: false},
+		{name: "garbage", value: "soon", ok: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := RetryAfter(header(tc.value), now, tc.limit)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
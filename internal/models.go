@@ -1,28 +1,101 @@
 package internal
 
 import (
+	"strings"
 	"time"
 
+	"github.com/drewfead/pdx-watcher/internal/geo"
 	"github.com/drewfead/pdx-watcher/proto"
 )
 
 type SourceShowtime struct {
 	ID           string        `json:"id"`
 	Summary      string        `json:"summary"`
-	Description  string        `json:"description"`
+	Description  *string       `json:"description"` // nil = source has no description; "" = source explicitly provides an empty one
 	StartTime    time.Time     `json:"start_time"`
 	EndTime      time.Time     `json:"end_time"`
 	Location     string        `json:"location"`
+	VenueName    string        `json:"venue_name,omitempty"` // short theater name, e.g. "Cinema 21"; Location is the full address
 	Screening    ScreeningInfo `json:"screening"`
 	TitleHint    string        `json:"title_hint"`
 	DirectorHint string        `json:"director_hint,omitempty"` // from calendar-events for TMDB matching
 	RuntimeHint  time.Duration `json:"runtime_hint,omitempty"`  // from calendar-events for TMDB matching (0 = unknown)
+	TMDBIDHint   string        `json:"tmdb_id_hint,omitempty"`  // TMDB movie id when the venue provides one; an exact match
+	Admission    string        `json:"admission,omitempty"`     // price as listed, e.g. "$9"; AdmissionFree for no charge; "" = unknown
+	// RuntimeSource records where RuntimeHint came from; set it via SetRuntimeHint.
+	RuntimeSource RuntimeSource `json:"runtime_source,omitempty"`
+}
+
+// AdmissionFree is the Admission of a screening that costs nothing to attend.
+const AdmissionFree = "Free"
+
+// Free reports whether the screening is listed as free admission.
+func (s SourceShowtime) Free() bool {
+	return strings.EqualFold(s.Admission, AdmissionFree)
+}
+
+// VenueInfo describes the theater a site lists showtimes for.
+type VenueInfo struct {
+	Name     string
+	Address  string
+	Timezone string    // IANA timezone of the venue; showtimes render in it unless an output timezone is set
+	Location geo.Point // zero = unknown
+}
+
+// RuntimeSource identifies where a RuntimeHint came from. Higher values take precedence:
+// a calendar start/end delta beats an explicit runtime string, which beats TMDB details.
+type RuntimeSource uint8
+
+const (
+	RuntimeSourceUnknown  RuntimeSource = iota
+	RuntimeSourceTMDB                   // runtime from TMDB movie details
+	RuntimeSourceListing                // explicit runtime string/number from the venue listing (e.g. "81 mins")
+	RuntimeSourceCalendar               // end minus start of a calendar event
+)
+
+func (s RuntimeSource) String() string {
+	switch s {
+	case RuntimeSourceTMDB:
+		return "tmdb"
+	case RuntimeSourceListing:
+		return "listing"
+	case RuntimeSourceCalendar:
+		return "calendar"
+	}
+	return "unknown"
+}
+
+// Runtimes outside [MinPlausibleRuntime, MaxPlausibleRuntime] are bad data (e.g. 0 or 9999 minutes)
+// and are treated as unknown.
+const (
+	MinPlausibleRuntime = time.Minute
+	MaxPlausibleRuntime = 600 * time.Minute
+)
+
+// PlausibleRuntime returns d, or zero (unknown) when d is outside the plausible bounds.
+func PlausibleRuntime(d time.Duration) time.Duration {
+	if d < MinPlausibleRuntime || d > MaxPlausibleRuntime {
+		return 0
+	}
+	return d
+}
+
+// SetRuntimeHint sets RuntimeHint to d unless the current hint came from a higher-precedence source.
+// Implausible durations (see PlausibleRuntime) are ignored. Reports whether the hint was updated.
+func (s *SourceShowtime) SetRuntimeHint(d time.Duration, src RuntimeSource) bool {
+	if PlausibleRuntime(d) == 0 || src < s.RuntimeSource {
+		return false
+	}
+	s.RuntimeHint = d
+	s.RuntimeSource = src
+	return true
 }
 
 type EnrichedShowtime struct {
-	Source SourceShowtime    `json:"showtime"`
-	Movie  MovieInfo         `json:"movie"`
-	Audits []EnrichmentAudit `json:"audits"`
+	Source  SourceShowtime    `json:"showtime"`
+	Movie   MovieInfo         `json:"movie"`
+	SoldOut *bool             `json:"sold_out,omitempty"` // nil = availability not checked
+	Audits  []EnrichmentAudit `json:"audits"`
 }
 
 type EnrichmentResult uint8
@@ -40,31 +113,131 @@ type EnrichmentAudit struct {
 	Annotations map[string]any   `json:"annotations"`
 }
 
+// AnnotationMatchCandidates is the EnrichmentAudit annotation key holding the []MatchCandidate
+// a provider scored while choosing a movie match.
+const AnnotationMatchCandidates = "candidates"
+
+// MatchCandidate is one search result an enrichment provider considered when matching a showtime.
+type MatchCandidate struct {
+	Title         string `json:"title"`
+	TMDBID        int64  `json:"tmdb_id"`
+	DirectorMatch bool   `json:"director_match"`
+	RuntimeDiff   int    `json:"runtime_diff_mins"` // minutes; -1 when not scored (no runtime hint or details)
+	Chosen        bool   `json:"chosen"`
+}
+
 type ScreeningInfo struct {
 	Title  string `json:"title"`
 	Subhed string `json:"subhed,omitempty"` // e.g. "in 35mm" from Hollywood listing, appended after TMDB title in summary
 	Series string `json:"series"`
 	Host   string `json:"host"`
 	Links  []Link `json:"links"`
+	// Accessibility lists the screening's access features (Accessibility constants), e.g. open captions.
+	Accessibility []string `json:"accessibility,omitempty"`
 }
 
+// Accessibility features scrapers recognize in listings.
+const (
+	AccessibilityOpenCaptions     = "open-captions"     // subtitles burned into the projection
+	AccessibilityAudioDescription = "audio-description" // narrated description track for blind and low-vision viewers
+	AccessibilitySensoryFriendly  = "sensory-friendly"  // lights up, sound down
+)
+
+// AccessibilityFeatures lists the Accessibility constants, for validating filters.
+var AccessibilityFeatures = []string{AccessibilityOpenCaptions, AccessibilityAudioDescription, AccessibilitySensoryFriendly}
+
 type MovieInfo struct {
 	Title    string `json:"title"`
 	Tagline  string `json:"tagline"`
 	Overview string `json:"overview"`
 	Links    []Link `json:"links"`
+	TMDBID   int64  `json:"tmdb_id,omitempty"` // 0 = not matched on TMDB
+	// Collection is the TMDB collection (franchise) the film belongs to, e.g. "Alien Collection", for grouping.
+	Collection string `json:"collection,omitempty"`
 }
 
 type Link struct {
 	Href    string `json:"href"`
 	Display string `json:"display"`
+	Rel     string `json:"rel,omitempty"` // what the link points at (one of the LinkRel constants) so clients needn't parse Display
 }
 
+// Link relations set by scrapers and enrichment providers.
+const (
+	LinkRelTickets = "tickets" // buy tickets for this screening
+	LinkRelInfo    = "info"    // the venue's page for the movie or event
+	LinkRelSeries  = "series"  // the series or program the screening is part of
+	LinkRelTMDB    = "tmdb"    // the movie's TMDB page
+)
+
 type ListShowtimesRequest struct {
-	After  time.Time `json:"after"`
-	Before time.Time `json:"before"`
-	Limit  int       `json:"limit"`
-	Anchor string    `json:"anchor"`
+	After   time.Time `json:"after"`
+	Before  time.Time `json:"before"`
+	Limit   int       `json:"limit"`
+	Anchor  string    `json:"anchor"`
+	Refresh bool      `json:"refresh,omitempty"` // skip cache reads but store the fresh results; not part of the cache key
+	// Progress, if set, is called as a scraper works through a multi-request fan-out (e.g. one request per date).
+	Progress ProgressFunc `json:"-"`
+	// Skips, if set, is called once per scrape with the listings the scraper dropped.
+	Skips SkipFunc `json:"-"`
+	// Deadline, if set, is when scrapers wrapped with UntilDeadline stop and keep what they produced (--deadline).
+	Deadline time.Time `json:"-"`
+	// Incomplete, if set, is called when a scraper's stream ends early (e.g. after a recovered panic),
+	// so its results are partial and mustn't be cached.
+	Incomplete func() `json:"-"`
+}
+
+// Progress is a snapshot of a scraper's fan-out: Done of Total units (e.g. "dates") fetched so far.
+type Progress struct {
+	Site  proto.PdxSite
+	Unit  string
+	Done  int
+	Total int
+}
+
+// ProgressFunc receives scrape progress updates.
+type ProgressFunc func(Progress)
+
+// ReportProgress calls r.Progress if set.
+func (r ListShowtimesRequest) ReportProgress(p Progress) {
+	if r.Progress != nil {
+		r.Progress(p)
+	}
+}
+
+// SkipCounts tallies the listings a scrape dropped, by reason.
+type SkipCounts struct {
+	After  int // started at or before the requested After
+	Before int // started at or after the requested Before
+	Parse  int // unparseable date or time, or missing required fields
+}
+
+// Total returns the number of listings skipped for any reason.
+func (c SkipCounts) Total() int {
+	return c.After + c.Before + c.Parse
+}
+
+// Add returns the sum of c and o.
+func (c SkipCounts) Add(o SkipCounts) SkipCounts {
+	return SkipCounts{After: c.After + o.After, Before: c.Before + o.Before, Parse: c.Parse + o.Parse}
+}
+
+// SkipFunc receives a site's skip counts at the end of a scrape. It may be called concurrently
+// when several sites are scraped at once.
+type SkipFunc func(site proto.PdxSite, skips SkipCounts)
+
+// ReportIncomplete calls r.Incomplete if set.
+func (r ListShowtimesRequest) ReportIncomplete() {
+	if r.Incomplete != nil {
+		r.Incomplete()
+	}
+}
+
+// ReportSkips calls r.Skips if set.
+func (r ListShowtimesRequest) ReportSkips(site proto.PdxSite, skips SkipCounts) {
+	if r.Skips != nil {
+		r.Skips(site, skips)
+	}
 }
 
 type ShowtimeListItem struct {
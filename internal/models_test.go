@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_SourceShowtime_SetRuntimeHint(t *testing.T) {
+	tests := []struct {
+		name       string
+		sets       []RuntimeSource // applied in order, each with a distinct duration
+		wantSource RuntimeSource
+	}{
+		{"tmdb alone", []RuntimeSource{RuntimeSourceTMDB}, RuntimeSourceTMDB},
+		{"listing beats tmdb", []RuntimeSource{RuntimeSourceTMDB, RuntimeSourceListing}, RuntimeSourceListing},
+		{"tmdb does not override listing", []RuntimeSource{RuntimeSourceListing, RuntimeSourceTMDB}, RuntimeSourceListing},
+		{"calendar beats listing", []RuntimeSource{RuntimeSourceListing, RuntimeSourceCalendar}, RuntimeSourceCalendar},
+		{"calendar beats everything", []RuntimeSource{RuntimeSourceCalendar, RuntimeSourceListing, RuntimeSourceTMDB}, RuntimeSourceCalendar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s SourceShowtime
+			want := map[RuntimeSource]time.Duration{}
+			for i, src := range tt.sets {
+				d := time.Duration(90+i) * time.Minute
+				want[src] = d
+				s.SetRuntimeHint(d, src)
+			}
+			assert.Equal(t, tt.wantSource, s.RuntimeSource)
+			assert.Equal(t, want[tt.wantSource], s.RuntimeHint)
+		})
+	}
+}
+
+func TestUnit_SourceShowtime_SetRuntimeHintIgnoresZero(t *testing.T) {
+	var s SourceShowtime
+	s.SetRuntimeHint(100*time.Minute, RuntimeSourceTMDB)
+	assert.False(t, s.SetRuntimeHint(0, RuntimeSourceCalendar))
+	assert.Equal(t, 100*time.Minute, s.RuntimeHint)
+	assert.Equal(t, RuntimeSourceTMDB, s.RuntimeSource)
+}
+
+func TestUnit_PlausibleRuntime(t *testing.T) {
+	tests := []struct {
+		name string
+		in   time.Duration
+		want time.Duration
+	}{
+		{"zero", 0, 0},
+		{"negative", -90 * time.Minute, 0},
+		{"typical", 101 * time.Minute, 101 * time.Minute},
+		{"upper bound", 600 * time.Minute, 600 * time.Minute},
+		{"too large", 9999 * time.Minute, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PlausibleRuntime(tt.in))
+		})
+	}
+}
+
+func TestUnit_SourceShowtime_SetRuntimeHintIgnoresImplausible(t *testing.T) {
+	var s SourceShowtime
+	assert.False(t, s.SetRuntimeHint(9999*time.Minute, RuntimeSourceListing))
+	assert.Zero(t, s.RuntimeHint)
+	assert.Equal(t, RuntimeSourceUnknown, s.RuntimeSource)
+}
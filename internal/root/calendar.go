@@ -0,0 +1,411 @@
+package root
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// calendarRefresh is how often subscribed calendar apps are asked to re-fetch the feed.
+const calendarRefresh = time.Hour
+
+// calendarDefaultDuration is the event length used when a showtime has no end time.
+const calendarDefaultDuration = 2 * time.Hour
+
+// calendarContentType is served with GET /calendar.ics.
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// calendarFeedUIDSuffix qualifies feed event UIDs. Subscribed apps match events by UID across
+// refreshes, so it must not change.
+const calendarFeedUIDSuffix = "@pdx-watcher"
+
+// writeCalendarHeader starts a VCALENDAR. A non-zero refresh adds the REFRESH-INTERVAL and
+// X-PUBLISHED-TTL hints subscribed calendar apps poll by.
+func writeCalendarHeader(w io.Writer, refresh time.Duration) error {
+	return writeICSLines(w, calendarHeader(refresh)...)
+}
+
+// calendarHeader returns the content lines writeCalendarHeader writes.
+func calendarHeader(refresh time.Duration) []string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//pdx-watcher//showtimes//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Portland showtimes",
+	}
+	if refresh > 0 {
+		d := icsDuration(refresh)
+		lines = append(lines, "REFRESH-INTERVAL;VALUE=DURATION:"+d, "X-PUBLISHED-TTL:"+d)
+	}
+	return lines
+}
+
+// writeCalendarFooter ends a VCALENDAR started by writeCalendarHeader.
+func writeCalendarFooter(w io.Writer) error {
+	return writeICSLines(w, "END:VCALENDAR")
+}
+
+// writeCalendarEvent writes s as a feed VEVENT with times local to loc, or UTC when loc is nil (see calendarEvent).
+func writeCalendarEvent(w io.Writer, s *proto.Showtime, stamp time.Time, loc *time.Location) error {
+	return writeICSLines(w, calendarEvent(s, s.GetId()+calendarFeedUIDSuffix, stamp, loc)...)
+}
+
+// calendarEvent returns the content lines of s as a VEVENT with uid, or nil for a showtime without
+// a start time. A missing end time becomes start+2h. Times are UTC unless loc is set, in which case
+// they are local to loc and tagged with its TZID. The description ends with the showtime's ticket links.
+func calendarEvent(s *proto.Showtime, uid string, stamp time.Time, loc *time.Location) []string {
+	if s.GetStartTime() == nil {
+		return nil
+	}
+	start := s.GetStartTime().AsTime()
+	end := start.Add(calendarDefaultDuration)
+	if s.GetEndTime() != nil && s.GetEndTime().AsTime().After(start) {
+		end = s.GetEndTime().AsTime()
+	}
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + icsEscape(uid),
+		"DTSTAMP:" + icsTime(stamp),
+		icsTimeProperty("DTSTART", start, loc),
+		icsTimeProperty("DTEND", end, loc),
+		"SUMMARY:" + icsEscape(s.GetSummary()),
+	}
+	if location := cmp.Or(s.GetLocation(), s.GetVenueName()); location != "" {
+		lines = append(lines, "LOCATION:"+icsEscape(location))
+	}
+	if description := calendarDescription(s); description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsEscape(description))
+	}
+	if href := ticketHref(s); href != "" {
+		lines = append(lines, "URL:"+href)
+	}
+	return append(lines, "END:VEVENT")
+}
+
+// calendarDescription joins the showtime's description (else the movie's overview) and its ticket links.
+func calendarDescription(s *proto.Showtime) string {
+	var parts []string
+	if d := strings.TrimSpace(cmp.Or(s.GetDescription(), s.GetMovie().GetOverview())); d != "" {
+		parts = append(parts, d)
+	}
+	for _, link := range s.GetScreening().GetLinks() {
+		if link.GetRel() == internal.LinkRelTickets && link.GetHref() != "" {
+			parts = append(parts, "Tickets: "+link.GetHref())
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ticketHref returns the first ticket link of s, or "".
+func ticketHref(s *proto.Showtime) string {
+	for _, link := range s.GetScreening().GetLinks() {
+		if link.GetRel() == internal.LinkRelTickets && link.GetHref() != "" {
+			return link.GetHref()
+		}
+	}
+	return ""
+}
+
+// icsTime formats t as an RFC 5545 UTC date-time.
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsTimeProperty formats a date-time property: UTC when loc is nil, else local to loc with its TZID.
+// Calendar apps resolve IANA TZIDs themselves, so no VTIMEZONE is written.
+func icsTimeProperty(name string, t time.Time, loc *time.Location) string {
+	if loc == nil {
+		return name + ":" + icsTime(t)
+	}
+	return name + ";TZID=" + loc.String() + ":" + t.In(loc).Format("20060102T150405")
+}
+
+// icsDuration formats d as an RFC 5545 duration in whole minutes, e.g. PT1H or PT90M.
+func icsDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("PT%dH", d/time.Hour)
+	}
+	return fmt.Sprintf("PT%dM", d/time.Minute)
+}
+
+// icsEscape escapes an RFC 5545 TEXT value.
+var icsEscape = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+).Replace
+
+// writeICSLines writes each content line with CRLF endings, folded at 75 octets as RFC 5545
+// requires. Folds never split a UTF-8 sequence.
+func writeICSLines(w io.Writer, lines ...string) error {
+	_, err := io.WriteString(w, foldICS(lines...))
+	return err
+}
+
+// foldICS joins content lines as writeICSLines writes them.
+func foldICS(lines ...string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		limit := 75
+		for len(line) > limit {
+			cut := limit
+			for cut > 0 && !isRuneStart(line[cut]) {
+				cut--
+			}
+			b.WriteString(line[:cut])
+			b.WriteString("\r\n ")
+			line = line[cut:]
+			limit = 74 // continuation lines start with a space
+		}
+		b.WriteString(line)
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
+
+// icsOutputFormat renders list-showtimes as an iCalendar file to import into a calendar app, with
+// each showtime's id as its event UID. routeOutput sends the output through a delimitedWriter, so
+// --delimiter never reaches the file: the first event also writes the VCALENDAR header, showtimes
+// without a start time write nothing, and closing the output writes END:VCALENDAR. Times carry the
+// --output-timezone (or --timezone) TZID, else are UTC.
+type icsOutputFormat struct {
+	now func() time.Time // nil = time.Now; stamps each event's DTSTAMP
+
+	mu     sync.Mutex
+	opened bool
+}
+
+func (f *icsOutputFormat) Name() string { return "ics" }
+
+func (f *icsOutputFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg protobuf.Message) error {
+	resp, ok := msg.(*proto.ListShowtimesResponse)
+	if !ok {
+		return fmt.Errorf("ics: unsupported message %T", msg)
+	}
+	var loc *time.Location
+	if tz := cmp.Or(cmd.String("output-timezone"), cmd.String("timezone")); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid --timezone %q: %w", tz, err)
+		}
+	}
+	now := f.now
+	if now == nil {
+		now = time.Now
+	}
+	event := calendarEvent(resp.GetShowtime(), resp.GetShowtime().GetId(), now(), loc)
+	if event == nil {
+		return nil
+	}
+	if dw, ok := w.(*delimitedWriter); ok {
+		w = dw.w
+	}
+	f.mu.Lock()
+	if !f.opened {
+		event = append(calendarHeader(0), event...)
+		f.opened = true
+	}
+	f.mu.Unlock()
+	return writeICSLines(w, event...)
+}
+
+// routeOutput runs as a before-command hook: when --format is ics it wraps the command's output in a
+// delimitedWriter (see routeDelimited) that ends the calendar when the command closes it.
+func (f *icsOutputFormat) routeOutput(_ context.Context, cmd *cli.Command) error {
+	if cmd.String("format") != f.Name() {
+		return nil
+	}
+	dw, err := routeDelimited(cmd)
+	if err != nil {
+		return err
+	}
+	dw.closing = f.endCalendar
+	return nil
+}
+
+// endCalendar writes END:VCALENDAR, or an empty calendar when no event was written.
+func (f *icsOutputFormat) endCalendar(w io.Writer) error {
+	f.mu.Lock()
+	var lines []string
+	if !f.opened {
+		lines = calendarHeader(0)
+	}
+	f.opened = false
+	f.mu.Unlock()
+	return writeICSLines(w, append(lines, "END:VCALENDAR")...)
+}
+
+// calendarHandler serves GET /calendar.ics: a live feed of upcoming showtimes that calendar apps
+// can subscribe to. Query parameters mirror the CLI: from (repeatable; a site, a group, or all),
+// after and before (RFC3339 or YYYY-MM-DD), limit (default none), and timezone. Like dense output,
+// event times are local to each showtime's venue unless timezone is set. service returns nil until
+// the daemon has built the service, in which case the feed answers 503.
+func calendarHandler(service func() proto.ShowtimeServiceServer, now func() time.Time) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar.ics", func(w http.ResponseWriter, r *http.Request) {
+		svc := service()
+		if svc == nil {
+			http.Error(w, "service is starting", http.StatusServiceUnavailable)
+			return
+		}
+		req, loc, err := calendarRequest(r, now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		stream := &collectingStream{ctx: r.Context()}
+		if err := svc.ListShowtimes(req, stream); err != nil {
+			slog.Error("calendar feed failed", "error", err)
+			http.Error(w, "list showtimes failed", http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", calendarContentType)
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(calendarRefresh/time.Second)))
+		stamp := now()
+		if err := writeCalendarHeader(w, calendarRefresh); err != nil {
+			return
+		}
+		for _, resp := range stream.responses {
+			eventLoc := loc
+			if eventLoc == nil {
+				eventLoc = siteLocation(resp.GetSite(), nil)
+			}
+			if err := writeCalendarEvent(w, resp.GetShowtime(), stamp, eventLoc); err != nil {
+				return
+			}
+		}
+		_ = writeCalendarFooter(w)
+	})
+	return mux
+}
+
+// calendarRequest builds the ListShowtimesRequest for a feed request, and returns the timezone
+// parameter's location (nil when unset). Without after, the feed starts now so subscribers only
+// see upcoming showtimes; without limit, it lists every showtime in range.
+func calendarRequest(r *http.Request, now time.Time) (*proto.ListShowtimesRequest, *time.Location, error) {
+	query := r.URL.Query()
+	req := &proto.ListShowtimesRequest{Limit: ptr(int32(0))}
+	setFrom(req, query["from"], nil)
+	var loc *time.Location
+	boundsLoc := time.Local
+	if tz := query.Get("timezone"); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+		boundsLoc = loc
+	}
+	after, before := now, time.Time{}
+	if v := query.Get("after"); v != "" {
+		t, err := parseTimeBound(v, boundsLoc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid after: %w", err)
+		}
+		after = t
+	}
+	if v := query.Get("before"); v != "" {
+		t, err := parseTimeBound(v, boundsLoc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid before: %w", err)
+		}
+		before = t
+	}
+	req.After = timestamppb.New(after)
+	if !before.IsZero() {
+		req.Before = timestamppb.New(before)
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			return nil, nil, fmt.Errorf("invalid limit %q", v)
+		}
+		req.Limit = ptr(int32(n))
+	}
+	return req, loc, nil
+}
+
+// collectingStream buffers a ListShowtimes response stream for the calendar feed.
+type collectingStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*proto.ListShowtimesResponse
+}
+
+func (s *collectingStream) Context() context.Context { return s.ctx }
+
+func (s *collectingStream) Send(resp *proto.ListShowtimesResponse) error {
+	if resp.GetShowtime() != nil {
+		s.responses = append(s.responses, resp)
+	}
+	return nil
+}
+
+// withCalendarFeed adds --calendar-addr to daemonize. When set, the daemon also serves
+// GET /calendar.ics over HTTP at that address, answering from the same service as gRPC.
+func withCalendarFeed(rootCmd *cli.Command, service *atomic.Pointer[proto.ShowtimeServiceServer], now func() time.Time) {
+	for _, cmd := range rootCmd.Commands {
+		if cmd.Name != "daemonize" {
+			continue
+		}
+		cmd.Flags = append(cmd.Flags, &cli.StringFlag{
+			Name:  "calendar-addr",
+			Usage: "Also serve an ICS calendar feed at GET /calendar.ics on this address (e.g. :8080)",
+		})
+		action := cmd.Action
+		cmd.Action = func(ctx context.Context, cmd *cli.Command) error {
+			addr := cmd.String("calendar-addr")
+			if addr == "" {
+				return action(ctx, cmd)
+			}
+			lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on --calendar-addr %s: %w", addr, err)
+			}
+			server := &http.Server{
+				Handler: calendarHandler(func() proto.ShowtimeServiceServer {
+					if svc := service.Load(); svc != nil {
+						return *svc
+					}
+					return nil
+				}, now),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			go func() {
+				slog.Info("Serving calendar feed", "address", lis.Addr().String(), "path", "/calendar.ics")
+				if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error("calendar feed server failed", "error", err)
+				}
+			}()
+			defer func() {
+				shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+				defer cancel()
+				_ = server.Shutdown(shutdownCtx)
+			}()
+			return action(ctx, cmd)
+		}
+	}
+}
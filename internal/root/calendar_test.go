@@ -0,0 +1,196 @@
+package root
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/scraper"
+	"github.com/drewfead/pdx-watcher/internal/scraper/scrapertest"
+	"github.com/drewfead/pdx-watcher/internal/services"
+	"github.com/drewfead/pdx-watcher/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestUnit_CalendarFeed(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: []internal.SourceShowtime{
+		{
+			ID: "heat-1", Summary: "Heat, Director's Cut", StartTime: start, EndTime: start.Add(170 * time.Minute),
+			Location: "616 NW 21st Ave, Portland, OR 97209",
+			Screening: internal.ScreeningInfo{Links: []internal.Link{
+				{Href: "https://example.com/tickets/heat-1", Rel: internal.LinkRelTickets},
+			}},
+		},
+		{ID: "thief-1", Summary: "Thief", StartTime: start.Add(3 * time.Hour), Description: ptr("Michael Mann's first feature; a safecracker wants out.")},
+	}}))
+	svc := services.ShowtimesService(registry)
+	server := httptest.NewServer(calendarHandler(func() proto.ShowtimeServiceServer { return svc }, func() time.Time { return now }))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/calendar.ics?from=cinema21&before=2026-02-21T00:00:00Z")
+	require.NoError(t, err, "GET /calendar.ics")
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "ReadAll")
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.Equal(t, "text/calendar; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	ics := string(body)
+	require.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"), ics)
+	require.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"), ics)
+	require.Contains(t, ics, "REFRESH-INTERVAL;VALUE=DURATION:PT1H\r\n")
+	require.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT\r\n"), ics)
+	require.Equal(t, 2, strings.Count(ics, "END:VEVENT\r\n"), ics)
+	require.Contains(t, ics, "UID:heat-1@pdx-watcher\r\n", "feed UIDs stay stable for subscribers")
+	require.Contains(t, ics, "SUMMARY:Heat\\, Director's Cut\r\n")
+	require.Contains(t, ics, "DTSTART;TZID=America/Los_Angeles:20260220T110000\r\nDTEND;TZID=America/Los_Angeles:20260220T135000\r\n",
+		"times are local to the venue")
+	require.Contains(t, ics, "LOCATION:616 NW 21st Ave\\, Portland\\, OR 97209\r\n")
+	require.Contains(t, ics, "DESCRIPTION:Tickets: https://example.com/tickets/heat-1\r\n")
+	require.Contains(t, ics, "DTSTART;TZID=America/Los_Angeles:20260220T140000\r\nDTEND;TZID=America/Los_Angeles:20260220T160000\r\n",
+		"missing end time falls back to start+2h")
+	for line := range strings.SplitSeq(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75, "lines are folded at 75 octets: %q", line)
+	}
+
+	resp, err = http.Get(server.URL + "/calendar.ics?from=cinema21&before=2026-02-21T00:00:00Z&timezone=UTC")
+	require.NoError(t, err, "GET /calendar.ics")
+	body, err = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err, "ReadAll")
+	require.Contains(t, string(body), "DTSTART;TZID=UTC:20260220T190000\r\n", "timezone overrides the venue's")
+
+	for _, query := range []string{"after=tomorrow", "timezone=Nowhere/Special"} {
+		resp, err = http.Get(server.URL + "/calendar.ics?" + query)
+		require.NoError(t, err, "GET /calendar.ics")
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestUnit_CalendarRequest_NoDefaultLimit(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	request := func(query string) *proto.ListShowtimesRequest {
+		t.Helper()
+		req, _, err := calendarRequest(httptest.NewRequest(http.MethodGet, "/calendar.ics?"+query, nil), now)
+		require.NoError(t, err)
+		return req
+	}
+
+	req := request("from=cinema21")
+	require.NotNil(t, req.Limit, "an unset limit would get the CLI's default of 100")
+	require.Zero(t, req.GetLimit(), "zero lists everything in range")
+	require.Equal(t, int32(10), request("limit=10").GetLimit())
+}
+
+func TestUnit_SetFrom(t *testing.T) {
+	req := &proto.ListShowtimesRequest{}
+	setFrom(req, []string{"cinema21, downtown", "cinemagic"}, []string{"eastside"})
+	require.Equal(t, []proto.PdxSite{proto.PdxSite_Cinema21, proto.PdxSite_Cinemagic}, req.GetFrom())
+	require.Equal(t, []string{"downtown", "eastside"}, req.GetFromGroup())
+
+	req = &proto.ListShowtimesRequest{}
+	setFrom(req, []string{"cinema21", "all"}, []string{"eastside"})
+	require.Empty(t, req.GetFrom())
+	require.Empty(t, req.GetFromGroup())
+}
+
+func TestUnit_CalendarFeed_ServiceStarting(t *testing.T) {
+	server := httptest.NewServer(calendarHandler(func() proto.ShowtimeServiceServer { return nil }, time.Now))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/calendar.ics")
+	require.NoError(t, err, "GET /calendar.ics")
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestUnit_WriteICSLines_FoldsWithoutSplittingRunes(t *testing.T) {
+	var b strings.Builder
+	line := "SUMMARY:" + strings.Repeat("é", 100)
+	require.NoError(t, writeICSLines(&b, line))
+	folded := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(folded), 1)
+	var unfolded strings.Builder
+	for i, part := range folded {
+		require.LessOrEqual(t, len(part), 75)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(part, " "))
+			part = part[1:]
+		}
+		unfolded.WriteString(part)
+	}
+	require.Equal(t, line, unfolded.String())
+}
+
+func TestUnit_ICSFormat(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: []internal.SourceShowtime{
+		{
+			ID: "heat-1", Summary: "Heat", StartTime: start, EndTime: start.Add(170 * time.Minute),
+			Location: "Cinema 21",
+			Screening: internal.ScreeningInfo{Links: []internal.Link{
+				{Href: "https://example.com/tickets/heat-1", Rel: internal.LinkRelTickets},
+			}},
+		},
+		{ID: "thief-1", Summary: "Thief", StartTime: start.Add(3 * time.Hour), Description: ptr("A safecracker wants out.")},
+	}}))
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		rootCmd, err := Root(t.Context(), WithRegistry(registry), WithClock(func() time.Time { return start.Add(-time.Hour) }))
+		require.NoError(t, err, "Root")
+		outputFile := filepath.Join(t.TempDir(), "showtimes.ics")
+		require.NoError(t, rootCmd.Run(t.Context(), append([]string{
+			"pdx-watcher", "list-showtimes",
+			"--after", "2026-02-20T00:00:00Z",
+			"--before", "2026-02-21T00:00:00Z",
+			"--format", "ics",
+			"--delimiter", ";",
+			"--output", outputFile,
+		}, args...)))
+		out, err := os.ReadFile(outputFile)
+		require.NoError(t, err, "ReadFile")
+		return string(out)
+	}
+
+	header := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//pdx-watcher//showtimes//EN\r\nCALSCALE:GREGORIAN\r\nMETHOD:PUBLISH\r\nX-WR-CALNAME:Portland showtimes\r\n"
+	require.Equal(t, header+
+		"BEGIN:VEVENT\r\nUID:heat-1\r\nDTSTAMP:20260220T180000Z\r\n"+
+		"DTSTART;TZID=America/Los_Angeles:20260220T110000\r\nDTEND;TZID=America/Los_Angeles:20260220T135000\r\n"+
+		"SUMMARY:Heat\r\nLOCATION:Cinema 21\r\nDESCRIPTION:Tickets: https://example.com/tickets/heat-1\r\n"+
+		"URL:https://example.com/tickets/heat-1\r\nEND:VEVENT\r\n"+
+		"BEGIN:VEVENT\r\nUID:thief-1\r\nDTSTAMP:20260220T180000Z\r\n"+
+		"DTSTART;TZID=America/Los_Angeles:20260220T140000\r\nDTEND;TZID=America/Los_Angeles:20260220T160000\r\n"+
+		"SUMMARY:Thief\r\nDESCRIPTION:A safecracker wants out.\r\nEND:VEVENT\r\n"+
+		"END:VCALENDAR\r\n",
+		run(t, "--from", "cinema21", "--timezone", "America/Los_Angeles"))
+
+	require.Contains(t, run(t, "--from", "cinema21"),
+		"DTSTART:20260220T190000Z\r\nDTEND:20260220T215000Z\r\n", "UTC without --timezone")
+
+	require.Equal(t, header+"END:VCALENDAR\r\n",
+		run(t, "--from", "none"), "an empty calendar when nothing matches")
+}
+
+func TestUnit_ICSFormat_SkipsShowtimesWithoutStart(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cli.Command{Flags: []cli.Flag{&cli.StringFlag{Name: "output-timezone"}, &cli.StringFlag{Name: "timezone"}}}
+	f := &icsOutputFormat{}
+	err := f.Format(t.Context(), cmd, &delimitedWriter{w: &buf}, &proto.ListShowtimesResponse{Showtime: &proto.Showtime{Id: "tba", Summary: "Heat"}})
+	require.NoError(t, err)
+	require.Empty(t, buf.String(), "no event, header, or blank line")
+
+	require.NoError(t, f.endCalendar(&buf))
+	require.NotContains(t, buf.String(), "\r\n\r\n")
+	require.True(t, strings.HasSuffix(buf.String(), "X-WR-CALNAME:Portland showtimes\r\nEND:VCALENDAR\r\n"))
+}
@@ -2,22 +2,35 @@ package root
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/browser"
 	"github.com/drewfead/pdx-watcher/internal/enrichment"
+	"github.com/drewfead/pdx-watcher/internal/httputil"
 	"github.com/drewfead/pdx-watcher/internal/scraper"
 	"github.com/drewfead/pdx-watcher/internal/services"
 	"github.com/drewfead/pdx-watcher/proto"
 	protocli "github.com/drewfead/proto-cli"
 	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
 	protobuf "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -41,7 +54,10 @@ func (w *syncWriter) Write(p []byte) (n int, err error) {
 type RootOption func(*rootConfig)
 
 type rootConfig struct {
-	registry scraper.Registry
+	registry      scraper.Registry
+	now           func() time.Time
+	outputFormats []protocli.OutputFormat
+	enrichment    []internal.EnrichmentProvider
 }
 
 // WithRegistry sets the scraper registry. Use in tests to inject a registry that uses
@@ -52,15 +68,54 @@ func WithRegistry(registry scraper.Registry) RootOption {
 	}
 }
 
+// WithClock sets the clock used for --since-last bookkeeping. Use in tests to simulate runs at fixed times.
+func WithClock(now func() time.Time) RootOption {
+	return func(c *rootConfig) {
+		c.now = now
+	}
+}
+
+// WithEnrichment adds enrichment providers that run after the configured ones (TMDB, synopses,
+// tickets). Use in tests with enrichment.Static to exercise enrichment without network access.
+func WithEnrichment(providers ...internal.EnrichmentProvider) RootOption {
+	return func(c *rootConfig) {
+		c.enrichment = append(c.enrichment, providers...)
+	}
+}
+
+// WithOutputFormat registers an additional --format for list-showtimes, listed after the built-ins.
+// A format whose name matches a built-in is shadowed by it.
+func WithOutputFormat(f protocli.OutputFormat) RootOption {
+	return func(c *rootConfig) {
+		c.outputFormats = append(c.outputFormats, f)
+	}
+}
+
 // denseOutputFormat renders ListShowtimesResponse in a compact one-line format.
 // It reads --timezone (or --output-timezone) from the command and displays times in that
-// IANA timezone, or the CLI's local time if not set.
+// IANA timezone; if not set, times render in the venue's timezone (or the CLI's local time
+// when the venue is unknown). With --summary it counts what it renders, and writeSummary
+// (an after-command hook) ends the list with the totals.
 type denseOutputFormat struct {
 	templateStr string
+	venue       func(proto.PdxSite) (internal.VenueInfo, bool) // nil = scraper.Venue
+
+	mu        sync.Mutex
+	showtimes int
+	films     map[string]struct{}
 }
 
 func (f *denseOutputFormat) Name() string { return "dense" }
 
+func (f *denseOutputFormat) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "summary",
+			Usage: "End the dense list with a line counting its showtimes and distinct films",
+		},
+	}
+}
+
 func (f *denseOutputFormat) Format(ctx context.Context, cmd *cli.Command, w io.Writer, msg protobuf.Message) error {
 	tzStr := cmd.String("output-timezone")
 	if tzStr == "" {
@@ -73,6 +128,8 @@ func (f *denseOutputFormat) Format(ctx context.Context, cmd *cli.Command, w io.W
 		if err != nil {
 			return fmt.Errorf("invalid --timezone %q: %w", tzStr, err)
 		}
+	} else if venueLoc := f.venueLocation(msg); venueLoc != nil {
+		loc = venueLoc
 	}
 	funcMap := template.FuncMap{}
 	for k, v := range protocli.DefaultTemplateFunctions() {
@@ -96,6 +153,26 @@ func (f *denseOutputFormat) Format(ctx context.Context, cmd *cli.Command, w io.W
 	funcMap["padSite"] = func(s string) string {
 		return fmt.Sprintf("%-*s", siteColumnWidth, s)
 	}
+	// candidateLine renders one --explain match candidate (from protoFields) as "* Title (tmdb 123) director=yes runtime_diff=3m".
+	funcMap["candidateLine"] = func(v any) string {
+		c, ok := v.(map[string]any)
+		if !ok {
+			return ""
+		}
+		marker := "-"
+		if chosen, _ := c["chosen"].(bool); chosen {
+			marker = "*"
+		}
+		director := "no"
+		if match, _ := c["directorMatch"].(bool); match {
+			director = "yes"
+		}
+		runtimeDiff := "n/a"
+		if d, ok := c["runtimeDiffMinutes"].(float64); ok {
+			runtimeDiff = fmt.Sprintf("%dm", int(d))
+		}
+		return fmt.Sprintf("%s %s (tmdb %v) director=%s runtime_diff=%s", marker, c["title"], c["tmdbId"], director, runtimeDiff)
+	}
 	funcMap["orStr"] = func(a any, b string) string {
 		if a == nil {
 			return b
@@ -141,30 +218,423 @@ func (f *denseOutputFormat) Format(ctx context.Context, cmd *cli.Command, w io.W
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return err
 	}
-	_, err = w.Write(buf.Bytes())
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if cmd.Bool("summary") {
+		f.count(msg)
+	}
+	return nil
+}
+
+// count records msg's showtime for the --summary line.
+func (f *denseOutputFormat) count(msg protobuf.Message) {
+	resp, ok := msg.(*proto.ListShowtimesResponse)
+	if !ok || resp.GetShowtime() == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.showtimes++
+	if f.films == nil {
+		f.films = make(map[string]struct{})
+	}
+	f.films[filmKey(resp.GetShowtime())] = struct{}{}
+}
+
+// filmKey identifies the film a showtime screens: its TMDB title when matched, else its listed title.
+func filmKey(s *proto.Showtime) string {
+	title := s.GetMovie().GetTitle()
+	if title == "" {
+		title = cmp.Or(s.GetScreening().GetTitle(), s.GetSummary())
+	}
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// writeSummary writes the --summary line, e.g. "12 showtimes, 9 films", after the dense list.
+func (f *denseOutputFormat) writeSummary(_ context.Context, cmd *cli.Command) error {
+	f.mu.Lock()
+	showtimes, films := f.showtimes, len(f.films)
+	f.showtimes, f.films = 0, nil
+	f.mu.Unlock()
+	if cmd.String("format") != f.Name() || !cmd.Bool("summary") {
+		return nil
+	}
+	w, done, err := commandOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("reopen output for summary: %w", err)
+	}
+	defer done()
+	_, err = fmt.Fprintf(w, "%s, %s\n", plural(showtimes, "showtime"), plural(films, "film"))
+	return err
+}
+
+// plural formats n with noun, adding an "s" unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// venueLocation returns the timezone of the venue msg's showtime is at, or nil if unknown.
+func (f *denseOutputFormat) venueLocation(msg protobuf.Message) *time.Location {
+	resp, ok := msg.(*proto.ListShowtimesResponse)
+	if !ok {
+		return nil
+	}
+	return siteLocation(resp.GetSite(), f.venue)
+}
+
+// siteLocation returns the timezone of site's venue as venue (nil = scraper.Venue) knows it, or nil if unknown.
+func siteLocation(site proto.PdxSite, venue func(proto.PdxSite) (internal.VenueInfo, bool)) *time.Location {
+	if venue == nil {
+		venue = scraper.Venue
+	}
+	info, ok := venue(site)
+	if !ok || info.Timezone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(info.Timezone)
+	if err != nil {
+		slog.Warn("invalid venue timezone", "site", site, "timezone", info.Timezone, "error", err)
+		return nil
+	}
+	return loc
+}
+
+// denseTemplate is the dense format's per-message template.
+const denseTemplate = `{{$f := protoFields .Message}}{{$s := $f.showtime}}{{if $f.groupStart}}== {{$f.group}} ==
+{{end}}{{shortTime $s.startTime}} | {{padSite (siteDisplay $f.site)}} | {{$s.summary}}{{with $s.admission}} ({{.}}){{end}}{{range $f.matchCandidates}}
+    {{candidateLine .}}{{end}}`
+
+// jsonOutputFormat renders each message as JSON (newline-delimited when streaming). --pretty
+// controls indentation; when it isn't set, output is indented for a terminal and compact
+// otherwise, so piped and --output files stay one message per line.
+type jsonOutputFormat struct {
+	terminal func(io.Writer) bool // nil = isTerminal
+}
+
+func (f *jsonOutputFormat) Name() string { return "json" }
+
+func (f *jsonOutputFormat) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "pretty",
+			Usage: "Indent JSON output (default: indented on a terminal, compact when piped or written to a file)",
+		},
+		&cli.StringFlag{
+			Name:  "json-indent",
+			Usage: "Indent JSON with \"tab\" or a number of spaces and sort object keys, for diff-friendly snapshots (overrides --pretty)",
+		},
+	}
+}
+
+func (f *jsonOutputFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg protobuf.Message) error {
+	terminal := f.terminal
+	if terminal == nil {
+		terminal = isTerminal
+	}
+	indent, err := jsonIndent(cmd.String("json-indent"))
+	if err != nil {
+		return err
+	}
+	jsonBytes, err := marshalJSON(msg, indent == "" && prettyJSON(cmd, w, terminal))
+	if err != nil {
+		return err
+	}
+	if indent != "" {
+		if jsonBytes, err = sortedJSON(jsonBytes, indent); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// jsonIndent turns a --json-indent value ("tab" or a number of spaces) into an indent string; "" means unset.
+func jsonIndent(value string) (string, error) {
+	switch value {
+	case "":
+		return "", nil
+	case "tab":
+		return "\t", nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > 8 {
+		return "", fmt.Errorf("invalid --json-indent %q (want tab or 1-8 spaces)", value)
+	}
+	return strings.Repeat(" ", n), nil
+}
+
+// sortedJSON re-encodes jsonBytes with object keys sorted and the given indent. protojson orders
+// fields by their proto declaration and doesn't promise a stable layout, so snapshots go through here.
+func sortedJSON(jsonBytes []byte, indent string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+	dec.UseNumber() // keep int64s and float formatting as protojson wrote them
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON for sorting: %w", err)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	// encoding/json writes map keys in sorted order.
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode sorted JSON: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// prettyJSON reports whether JSON output should be indented: as --pretty says when set,
+// otherwise only when w is a terminal.
+func prettyJSON(cmd *cli.Command, w io.Writer, terminal func(io.Writer) bool) bool {
+	if cmd.IsSet("pretty") {
+		return cmd.Bool("pretty")
+	}
+	return terminal(w)
+}
+
+// marshalJSON marshals msg as protojson, indented when pretty is set.
+func marshalJSON(msg protobuf.Message, pretty bool) ([]byte, error) {
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+	if pretty {
+		marshaler.Indent = "  "
+	}
+	jsonBytes, err := marshaler.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return jsonBytes, nil
+}
+
+// isTerminal reports whether w writes to a terminal.
+func isTerminal(w io.Writer) bool {
+	if sw, ok := w.(*syncWriter); ok {
+		w = sw.f
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// jsonlOutputFormat renders each message as one compact JSON object per line (JSON Lines), for
+// piping into jq or log processors as the stream arrives. The generated command ends each message
+// with --delimiter, so pinLines fixes that to a newline and has stdout synced after every line.
+type jsonlOutputFormat struct{}
+
+func (f *jsonlOutputFormat) Name() string { return "jsonl" }
+
+func (f *jsonlOutputFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg protobuf.Message) error {
+	jsonBytes, err := marshalJSON(msg, false)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// pinLines runs as a before-command hook: when --format is jsonl it sets --delimiter to a newline,
+// and wraps a stdout file in a syncWriter so each line is flushed as it is written.
+func (f *jsonlOutputFormat) pinLines(_ context.Context, cmd *cli.Command) error {
+	if cmd.String("format") != f.Name() {
+		return nil
+	}
+	if err := cmd.Set("delimiter", "\n"); err != nil {
+		return fmt.Errorf("set jsonl delimiter: %w", err)
+	}
+	if path := cmd.String("output"); path != "" && path != "-" {
+		return nil
+	}
+	w := cmd.Writer
+	if w == nil {
+		w = cmd.Root().Writer
+	}
+	if w == nil {
+		w = os.Stdout
+	}
+	if file, ok := w.(*os.File); ok {
+		cmd.Writer = &syncWriter{f: file}
+	}
+	return nil
+}
+
+// jsonArrayOutputFormat renders a stream as a single JSON array: "[" before the first message and
+// "," between messages. Each element is written in one Write as it arrives, nothing is held back,
+// so memory stays bounded however large the result. The closing "]" is written by closeArray, which
+// runs as an after-command hook so the array is closed even when a scraper or the stream fails
+// midway. Honors --pretty.
+type jsonArrayOutputFormat struct {
+	mu     sync.Mutex
+	opened bool
+}
+
+func (f *jsonArrayOutputFormat) Name() string { return "json-array" }
+
+func (f *jsonArrayOutputFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg protobuf.Message) error {
+	jsonBytes, err := marshalJSON(msg, prettyJSON(cmd, w, isTerminal))
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	sep := ","
+	if !f.opened {
+		sep = "["
+		f.opened = true
+	}
+	f.mu.Unlock()
+	_, err = w.Write(append([]byte(sep), jsonBytes...))
+	return err
+}
+
+// closeArray writes "]" (or "[]" when nothing was streamed) to the command's output.
+func (f *jsonArrayOutputFormat) closeArray(_ context.Context, cmd *cli.Command) error {
+	if cmd.String("format") != f.Name() {
+		return nil
+	}
+	f.mu.Lock()
+	closing := "]\n"
+	if !f.opened {
+		closing = "[]\n"
+	}
+	f.opened = false
+	f.mu.Unlock()
+
+	w, done, err := commandOutput(cmd)
+	if err != nil {
+		return fmt.Errorf("reopen output to close JSON array: %w", err)
+	}
+	defer done()
+	_, err = io.WriteString(w, closing)
+	return err
+}
+
+// commandOutput returns where an after-command hook should write to follow the command's output.
+// The generated command has already closed an --output file by the time after hooks run, so it is
+// reopened for appending; call done when finished.
+func commandOutput(cmd *cli.Command) (w io.Writer, done func(), err error) {
+	switch path := cmd.String("output"); path {
+	case "", "-":
+		w = cmd.Root().Writer
+		if cmd.Writer != nil {
+			w = cmd.Writer
+		}
+		if w == nil {
+			w = os.Stdout
+		}
+		return w, func() {}, nil
+	default:
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		return file, func() { _ = file.Close() }, nil
+	}
+}
+
+// protobufOutputFormat writes each showtime as a length-delimited binary Showtime message (see
+// protodelim), for Go clients to read back with protodelim.UnmarshalFrom. The generated command
+// writes --delimiter and a trailing newline around messages, which would corrupt the framing, so
+// routeOutput points the command at a delimitedWriter that only passes the frames through.
+type protobufOutputFormat struct{}
+
+func (f *protobufOutputFormat) Name() string { return "protobuf" }
+
+func (f *protobufOutputFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg protobuf.Message) error {
+	if resp, ok := msg.(*proto.ListShowtimesResponse); ok && resp.GetShowtime() != nil {
+		msg = resp.GetShowtime()
+	}
+	if dw, ok := w.(*delimitedWriter); ok {
+		w = dw.w
+	}
+	if _, err := protodelim.MarshalTo(w, msg); err != nil {
+		return fmt.Errorf("marshal delimited protobuf: %w", err)
+	}
+	return nil
+}
+
+// routeOutput runs as a before-command hook: when --format is protobuf it wraps the command's output
+// in a delimitedWriter (see routeDelimited).
+func (f *protobufOutputFormat) routeOutput(_ context.Context, cmd *cli.Command) error {
+	if cmd.String("format") != f.Name() {
+		return nil
+	}
+	_, err := routeDelimited(cmd)
+	return err
+}
+
+// routeDelimited wraps the command's output in a delimitedWriter, opening --output itself so the
+// generated command writes to cmd.Writer.
+func routeDelimited(cmd *cli.Command) (*delimitedWriter, error) {
+	dw := &delimitedWriter{}
+	switch path := cmd.String("output"); path {
+	case "", "-":
+		dw.w = cmd.Root().Writer
+		if cmd.Writer != nil {
+			dw.w = cmd.Writer
+		}
+		if dw.w == nil {
+			dw.w = os.Stdout
+		}
+	default:
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("open %s output: %w", cmd.String("format"), err)
+		}
+		if err := cmd.Set("output", "-"); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("route %s output: %w", cmd.String("format"), err)
+		}
+		dw.w, dw.file = file, file
+	}
+	cmd.Writer = dw
+	return dw, nil
+}
+
+// delimitedWriter drops the command's own Writes (delimiters and the trailing newline) so only what
+// the format writes to the underlying writer reaches the output.
+type delimitedWriter struct {
+	w       io.Writer
+	file    *os.File              // non-nil when routeDelimited opened --output; closed by the generated command
+	closing func(io.Writer) error // if set, writes the output's last bytes on Close
+}
+
+func (w *delimitedWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func (w *delimitedWriter) Close() error {
+	var err error
+	if w.closing != nil {
+		err = w.closing(w.w)
+	}
+	if w.file != nil {
+		err = errors.Join(err, w.file.Close())
+	}
 	return err
 }
 
 func Root(ctx context.Context, opts ...RootOption) (*cli.Command, error) {
-	cfg := &rootConfig{}
+	cfg := &rootConfig{now: time.Now}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	registry := cfg.registry
-	if registry == nil {
-		registry = scraper.NewRegistry(
-			scraper.WithScraperForSite(proto.PdxSite_None, scraper.None()),
-			scraper.WithScraperForSite(proto.PdxSite_HollywoodTheatre, scraper.HollywoodTheatre(), scraper.Cached(64, 5*time.Minute)),
-			scraper.WithScraperForSite(proto.PdxSite_Cinemagic, scraper.Cinemagic(), scraper.Cached(64, 5*time.Minute)),
-			scraper.WithScraperForSite(proto.PdxSite_Cinema21, scraper.Cinema21(), scraper.Cached(64, 5*time.Minute)),
-		)
-	}
+	// The calendar feed answers from whichever service the factory built last (the daemon's).
+	var latest atomic.Pointer[proto.ShowtimeServiceServer]
+
 	// Pass a factory so the CLI can create the service when --config is used (CallFactory expects a function that returns exactly one value).
-	factory := func(cfg *proto.ShowtimeConfig) proto.ShowtimeServiceServer {
+	factory := func(showtimeCfg *proto.ShowtimeConfig) proto.ShowtimeServiceServer {
+		registry := cfg.registry
+		if registry == nil {
+			registry = defaultRegistry(showtimeCfg)
+		}
 		var enrichmentProviders []internal.EnrichmentProvider
-		if cfg != nil && cfg.Tmdb != nil && cfg.Tmdb.ApiKey != "" {
-			tmdbClient, err := enrichment.TMDB(cfg.Tmdb.ApiKey)
+		if showtimeCfg != nil && showtimeCfg.Tmdb != nil && showtimeCfg.Tmdb.ApiKey != "" {
+			tmdbClient, err := enrichment.TMDB(showtimeCfg.Tmdb.ApiKey,
+				enrichment.TMDBWithDiskCache(tmdbCacheDir(showtimeCfg.Tmdb), tmdbCacheTTL))
 			if err != nil {
 				slog.Info("TMDB enrichment not configured", "reason", "client init failed", "error", err)
 			} else {
@@ -174,89 +644,606 @@ func Root(ctx context.Context, opts ...RootOption) (*cli.Command, error) {
 		} else {
 			slog.Info("TMDB enrichment not configured", "reason", "no api_key or config")
 		}
-		return services.ShowtimesService(registry, enrichmentProviders...)
+		if showtimeCfg.GetFetchSynopses() {
+			enrichmentProviders = append(enrichmentProviders, enrichment.Synopsis())
+		}
+		if showtimeCfg.GetCheckTickets() {
+			enrichmentProviders = append(enrichmentProviders, enrichment.TicketAvailability())
+		}
+		enrichmentProviders = append(enrichmentProviders, cfg.enrichment...)
+		svc := services.ShowtimesService(registry, enrichmentProviders...)
+		latest.Store(&svc)
+		return svc
 	}
 
-	denseFormat := &denseOutputFormat{
-		templateStr: `{{$f := protoFields .Message}}{{$s := $f.showtime}}{{shortTime $s.startTime}} | {{padSite (siteDisplay $f.site)}} | {{$s.summary}}`,
-	}
+	formats, jsonArrayFormat, denseFormat, icsFormat := outputFormats(cfg.outputFormats...)
+	icsFormat.now = cfg.now
 
 	showtimesCLI := proto.ShowtimeServiceCommand(ctx, factory,
-		protocli.WithOutputFormats(
-			denseFormat,
-			protocli.JSON(),
-			protocli.YAML(),
-		),
+		protocli.WithOutputFormats(formats...),
+		protocli.BeforeCommand((&protobufOutputFormat{}).routeOutput),
+		protocli.BeforeCommand((&jsonlOutputFormat{}).pinLines),
+		protocli.BeforeCommand(icsFormat.routeOutput),
+		protocli.AfterCommand(jsonArrayFormat.closeArray),
+		protocli.AfterCommand(denseFormat.writeSummary),
 		protocli.WithFlagDeserializer("google.protobuf.Timestamp", timestampDeserializer),
-		protocli.WithFlagDeserializer("showtimes.ListShowtimesRequest", listShowtimesRequestDeserializer),
+		protocli.WithFlagDeserializer("showtimes.ListShowtimesRequest", cfg.listShowtimesRequestDeserializer),
 	)
 
 	// Create root command with config support
 	rootCmd, err := protocli.RootCommand("pdx-watcher",
 		protocli.Service(showtimesCLI, protocli.Hoisted()),
-		protocli.WithEnvPrefix("PDX_WATCHER"),
+		protocli.WithEnvPrefix(envPrefix),
 		protocli.WithConfigManagementCommands(&proto.ShowtimeConfig{}, "pdx-watcher", "showtimeservice"),
 	)
 	if err != nil {
 		slog.Error("failed to create root command", "error", err)
 		return nil, fmt.Errorf("failed to create root command: %w", err)
 	}
+	rootCmd.Commands = append(rootCmd.Commands, formatsCommand(formats), versionCommand())
+	withQuietFlag(rootCmd)
+	withSinceLastState(rootCmd, cfg)
+	withCalendarFeed(rootCmd, &latest, cfg.now)
 
 	return rootCmd, nil
 }
 
+// withQuietFlag adds --quiet, which silences everything below error level regardless of --verbosity.
+// It wraps the root Before hook so it runs after protocli has configured slog from --verbosity.
+func withQuietFlag(rootCmd *cli.Command) {
+	rootCmd.Flags = append(rootCmd.Flags, &cli.BoolFlag{
+		Name:    "quiet",
+		Aliases: []string{"q"},
+		Usage:   "Only log errors (overrides --verbosity)",
+	})
+	before := rootCmd.Before
+	rootCmd.Before = func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		if before != nil {
+			var err error
+			if ctx, err = before(ctx, cmd); err != nil {
+				return ctx, err
+			}
+		}
+		if cmd.Root().Bool("quiet") {
+			slog.SetDefault(slog.New(slog.NewTextHandler(cmd.Root().ErrWriter, &slog.HandlerOptions{Level: slog.LevelError})))
+		}
+		return ctx, nil
+	}
+}
+
+// outputFormats returns the output formats list-showtimes accepts. The json-array, dense and ics
+// formats are also returned on their own since their closing bracket, --summary line and
+// END:VCALENDAR are written by after-command hooks.
+func outputFormats(extra ...protocli.OutputFormat) ([]protocli.OutputFormat, *jsonArrayOutputFormat, *denseOutputFormat, *icsOutputFormat) {
+	jsonArrayFormat := &jsonArrayOutputFormat{}
+	denseFormat := &denseOutputFormat{templateStr: denseTemplate}
+	icsFormat := &icsOutputFormat{}
+	formats := []protocli.OutputFormat{
+		denseFormat,
+		&jsonOutputFormat{},
+		jsonArrayFormat,
+		&jsonlOutputFormat{},
+		protocli.YAML(),
+		&protobufOutputFormat{},
+		icsFormat,
+	}
+	return append(formats, extra...), jsonArrayFormat, denseFormat, icsFormat
+}
+
+// OutputFormats returns the names of the built-in output formats --format accepts.
+func OutputFormats() []string {
+	formats, _, _, _ := outputFormats()
+	return formatNames(formats)
+}
+
+func formatNames(formats []protocli.OutputFormat) []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Name()
+	}
+	return names
+}
+
+// formatsCommand lists the output formats, one per line.
+func formatsCommand(formats []protocli.OutputFormat) *cli.Command {
+	return &cli.Command{
+		Name:  "formats",
+		Usage: "List the output formats accepted by --format",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			for _, name := range formatNames(formats) {
+				if _, err := fmt.Fprintln(cmd.Root().Writer, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// defaultRegistry builds the registry of live scrapers. It runs inside the service factory so
+// config-level flags (e.g. --log-http) are applied before any scraper is constructed.
+func defaultRegistry(cfg *proto.ShowtimeConfig) scraper.Registry {
+	client := scraper.DefaultHTTPClient()
+	if cfg.GetLogHttp() {
+		client = &http.Client{
+			Timeout:   client.Timeout,
+			Transport: &httputil.LoggingTransport{Base: client.Transport},
+		}
+	}
+	// Hollywood and Cinemagic share one chrome, each scrape in its own tab. --no-browser hands them
+	// one that fails fast instead, and both fall back to HTTP with client (logged under --log-http).
+	// Cinema21 already uses plain HTTP.
+	var b browser.Interface
+	if cfg.GetNoBrowser() {
+		b = browser.Disabled()
+	} else {
+		b = browser.Headless(browser.WithRetries(2, 500*time.Millisecond))
+	}
+	opts := []scraper.RegistryOption{
+		scraper.WithDefaultMiddleware(scraper.Cached(64, 5*time.Minute, scraper.CacheWithJitter(0.1), scraper.CachedWithDiskStore(scrapeCacheDir()))),
+		scraper.WithScraperForSite(proto.PdxSite_None, scraper.None()),
+		scraper.WithScraperForSite(proto.PdxSite_HollywoodTheatre, scraper.HollywoodTheatre(scraper.WithBrowser(b), scraper.HollywoodWithFallbackClient(client))),
+		scraper.WithScraperForSite(proto.PdxSite_Cinemagic, scraper.Cinemagic(scraper.CinemagicWithBrowser(b), scraper.CinemagicWithFallbackClient(client))),
+		scraper.WithScraperForSite(proto.PdxSite_Cinema21, scraper.Cinema21(scraper.Cinema21WithClient(client))),
+	}
+	for name, group := range cfg.GetGroups() {
+		opts = append(opts, scraper.WithSiteGroup(name, group.GetSites()...))
+	}
+	return scraper.NewRegistry(opts...)
+}
+
+// scrapeCacheDir is where scrape results persist between CLI runs, or "" (memory only) when the
+// user cache directory is unknown.
+func scrapeCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		slog.Debug("scrape cache kept in memory only", "reason", err)
+		return ""
+	}
+	return filepath.Join(dir, "pdx-watcher", "scrapes")
+}
+
+// tmdbCacheTTL is how long cached TMDB movie details are reused; titles, runtimes, and credits
+// rarely change once a film is listed.
+const tmdbCacheTTL = 30 * 24 * time.Hour
+
+// tmdbCacheDir is where TMDB movie details persist between CLI runs: the configured cache_dir, else
+// under the user cache directory, or "" (memory only) when that is unknown.
+func tmdbCacheDir(cfg *proto.TMDBConfig) string {
+	if dir := cfg.GetCacheDir(); dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		slog.Debug("TMDB cache kept in memory only", "reason", err)
+		return ""
+	}
+	return filepath.Join(dir, "pdx-watcher", "tmdb")
+}
+
 func timestampDeserializer(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
 	timeStr := flags.String()
 	if timeStr == "" {
 		return &timestamppb.Timestamp{}, nil
 	}
-	t, err := time.Parse(time.RFC3339, timeStr)
+	loc, err := flagLocation(flags)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTimeBound(timeStr, loc)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format (expected RFC3339): %w", err)
+		return nil, fmt.Errorf("invalid timestamp format: %w", err)
 	}
 	return timestamppb.New(t), nil
 }
 
+// parseTimeBound parses an RFC3339 time, or a YYYY-MM-DD date meaning midnight in loc.
+func parseTimeBound(value string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	if day, dayErr := time.ParseInLocation(time.DateOnly, value, loc); dayErr == nil {
+		return day, nil
+	}
+	return time.Time{}, fmt.Errorf("expected RFC3339 or YYYY-MM-DD: %w", err)
+}
+
+// flagLocation returns the --output-timezone (or --timezone) location, or the CLI's local time if neither is set.
+func flagLocation(flags protocli.FlagContainer) (*time.Location, error) {
+	tz := flags.StringNamed("output-timezone")
+	if tz == "" {
+		tz = flags.StringNamed("timezone")
+	}
+	if tz == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --timezone %q: %w", tz, err)
+	}
+	return loc, nil
+}
+
+// allSitesSentinel is the --from value that selects every registered site.
+const allSitesSentinel = "all"
+
+// setFrom sets req's sites and groups from --from values (sites or group names, repeated or
+// comma-separated) and --from-group names. "all" anywhere leaves both empty, which the service
+// expands to registry.AllSites().
+func setFrom(req *proto.ListShowtimesRequest, from, groups []string) {
+	for _, value := range from {
+		for s := range strings.SplitSeq(value, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			if strings.EqualFold(s, allSitesSentinel) {
+				req.From, req.FromGroup = nil, nil
+				return
+			}
+			site, err := parsePdxSite(s)
+			if err != nil {
+				// Groups live in the service config, so the service reports unknown names.
+				req.FromGroup = append(req.FromGroup, s)
+				continue
+			}
+			req.From = append(req.From, site)
+		}
+	}
+	req.FromGroup = append(req.FromGroup, groups...)
+}
+
+// envPrefix prefixes environment variables the CLI reads (e.g. PDX_WATCHER_AFTER).
+const envPrefix = "PDX_WATCHER"
+
 // listShowtimesRequestDeserializer builds ListShowtimesRequest from flags.
-// Supports multiple --from (StringSlice); omitted --from means "all" (handled by service).
-func listShowtimesRequestDeserializer(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
+// Supports multiple --from (StringSlice); omitted --from or --from all means "all" (handled by service).
+func (c *rootConfig) listShowtimesRequestDeserializer(ctx context.Context, flags protocli.FlagContainer) (protobuf.Message, error) {
 	req := &proto.ListShowtimesRequest{}
-	for _, s := range flags.StringSliceNamed("from") {
-		site, err := parsePdxSite(s)
+	from := flags.StringSliceNamed("from")
+	if path := flags.StringNamed("from-file"); path != "" {
+		listed, err := readFromFile(path)
 		if err != nil {
 			return nil, err
 		}
-		req.From = append(req.From, site)
+		from = append(from, listed...)
 	}
-	if afterStr := flags.StringNamed("after"); afterStr != "" {
-		t, err := time.Parse(time.RFC3339, afterStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid --after (expected RFC3339): %w", err)
-		}
-		req.After = timestamppb.New(t)
+	setFrom(req, from, flags.StringSliceNamed("from-group"))
+	if tz := flags.StringNamed("output-timezone"); tz != "" {
+		req.OutputTimezone = &tz
+	} else if tz := flags.StringNamed("timezone"); tz != "" {
+		req.OutputTimezone = &tz
 	}
-	if beforeStr := flags.StringNamed("before"); beforeStr != "" {
-		t, err := time.Parse(time.RFC3339, beforeStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid --before (expected RFC3339): %w", err)
-		}
-		req.Before = timestamppb.New(t)
+	loc, err := outputLocation(req)
+	if err != nil {
+		return nil, err
+	}
+	after, err := timeFlagOrEnv(flags, "after", loc)
+	if err != nil {
+		return nil, err
+	}
+	if !after.IsZero() {
+		req.After = timestamppb.New(after)
+	}
+	before, err := timeFlagOrEnv(flags, "before", loc)
+	if err != nil {
+		return nil, err
+	}
+	if !before.IsZero() {
+		req.Before = timestamppb.New(before)
+	}
+	start, end, err := c.rangeShortcut(flags, loc)
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectCombined(flags, "upcoming", "after", "date", "since-last"); err != nil {
+		return nil, err
+	}
+	if err := rejectCombined(flags, "date", "after", "before", "since-last"); err != nil {
+		return nil, err
+	}
+	if !start.IsZero() {
+		req.After = timestamppb.New(start)
+		req.Before = timestamppb.New(end)
 	}
 	if flags.IsSetNamed("limit") {
 		n := flags.IntNamed("limit")
 		req.Limit = ptr(int32(n))
 	}
+	if flags.IsSetNamed("limit-per-day") {
+		n := flags.IntNamed("limit-per-day")
+		req.LimitPerDay = ptr(int32(n))
+	}
+	if near := flags.StringNamed("near"); near != "" {
+		req.Near = &near
+	}
+	if flags.IsSetNamed("radius") {
+		req.RadiusKm = ptr(flags.FloatNamed("radius"))
+	}
+	req.ExcludeTitle = flags.StringSliceNamed("exclude-title")
+	req.ExcludeId = flags.StringSliceNamed("exclude-id")
+	req.Accessibility = flags.StringSliceNamed("accessibility")
 	if anchor := flags.StringNamed("anchor"); anchor != "" {
 		req.Anchor = &anchor
 	}
-	if tz := flags.StringNamed("output-timezone"); tz != "" {
-		req.OutputTimezone = &tz
-	} else if tz := flags.StringNamed("timezone"); tz != "" {
-		req.OutputTimezone = &tz
+	if flags.BoolNamed("explain") {
+		req.Explain = ptr(true)
+	}
+	if flags.BoolNamed("refresh") {
+		req.Refresh = ptr(true)
+	}
+	if flags.BoolNamed("progress") {
+		req.Progress = ptr(true)
+	}
+	if flags.BoolNamed("trace") {
+		req.Trace = ptr(true)
+	}
+	if flags.BoolNamed("explain-skips") {
+		req.ExplainSkips = ptr(true)
+	}
+	if flags.BoolNamed("bookable") {
+		req.Bookable = ptr(true)
+	}
+	if flags.BoolNamed("free") {
+		req.Free = ptr(true)
+	}
+	if flags.BoolNamed("no-subhed") {
+		req.NoSubhed = ptr(true)
+	}
+	if flags.BoolNamed("prefer-listed-title") {
+		req.PreferListedTitle = ptr(true)
+	}
+	if flags.IsSetNamed("strip-html") {
+		req.StripHtml = ptr(flags.BoolNamed("strip-html"))
+	}
+	if style := flags.StringNamed("summary-style"); style != "" {
+		req.SummaryStyle = &style
+	}
+	if deadline := flags.StringNamed("deadline"); deadline != "" {
+		req.Deadline = &deadline
+	}
+	if groupBy := flags.StringNamed("group-by"); groupBy != "" {
+		req.GroupBy = &groupBy
+	}
+	if maxRange := flags.StringNamed("max-range"); maxRange != "" {
+		req.MaxRange = &maxRange
+	}
+	if flags.BoolNamed("upcoming") {
+		req.After = timestamppb.New(c.now().In(loc))
+	}
+	if flags.BoolNamed("since-last") {
+		path, err := stateFile(flags.StringNamed("state-file"))
+		if err != nil {
+			return nil, err
+		}
+		last, err := readLastRun(path)
+		if err != nil {
+			return nil, err
+		}
+		if !last.IsZero() {
+			req.After = timestamppb.New(last)
+		}
+	}
+	if date := flags.StringNamed("date"); date != "" {
+		start, end, err := dayBounds(date, loc)
+		if err != nil {
+			return nil, err
+		}
+		req.After = timestamppb.New(start)
+		req.Before = timestamppb.New(end)
 	}
 	return req, nil
 }
 
+// rangeFlags are the boolean shortcuts for common --after/--before ranges.
+var rangeFlags = []string{"today", "tomorrow", "this-week"}
+
+// rangeShortcut resolves --today, --tomorrow, or --this-week into day-aligned bounds in loc, or
+// returns zero times when none is set. Only one may be given, and not alongside --after or --before
+// (including from the environment), --date, or --upcoming.
+func (c *rootConfig) rangeShortcut(flags protocli.FlagContainer, loc *time.Location) (time.Time, time.Time, error) {
+	var set []string
+	for _, name := range rangeFlags {
+		if flags.BoolNamed(name) {
+			set = append(set, "--"+name)
+		}
+	}
+	if len(set) == 0 {
+		return time.Time{}, time.Time{}, nil
+	}
+	if len(set) > 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%s can't be combined", strings.Join(set, " and "))
+	}
+	for _, name := range []string{"after", "before", "date", "upcoming"} {
+		if source := timeFlagSource(flags, name); source != "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("%s can't be combined with %s", set[0], source)
+		}
+	}
+	now := c.now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	switch set[0] {
+	case "--tomorrow":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), nil
+	case "--this-week":
+		// Weeks end on Sunday, so on a Sunday this is just today.
+		daysLeft := (7 - int(today.Weekday())) % 7
+		return today, today.AddDate(0, 0, daysLeft+1), nil
+	}
+	return today, today.AddDate(0, 0, 1), nil
+}
+
+// timeFlagSource returns "--name" if the time-range flag name is set, or "" if it isn't. --after
+// and --before set from the environment (see timeFlagOrEnv) return the variable's name.
+func timeFlagSource(flags protocli.FlagContainer, name string) string {
+	switch name {
+	case "after", "before":
+		_, source := timeFlagOrEnvValue(flags, name)
+		return source
+	case "date":
+		if flags.StringNamed(name) == "" {
+			return ""
+		}
+	default:
+		if !flags.BoolNamed(name) {
+			return ""
+		}
+	}
+	return "--" + name
+}
+
+// rejectCombined returns an error if --name is set along with any of the time-range flags in
+// others, since each would silently override the other's bounds.
+func rejectCombined(flags protocli.FlagContainer, name string, others ...string) error {
+	if timeFlagSource(flags, name) == "" {
+		return nil
+	}
+	for _, other := range others {
+		if source := timeFlagSource(flags, other); source != "" {
+			return fmt.Errorf("--%s can't be combined with %s", name, source)
+		}
+	}
+	return nil
+}
+
+// readFromFile returns the --from values listed in path, one per line, skipping blank lines and
+// lines starting with #.
+func readFromFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read --from-file: %w", err)
+	}
+	var values []string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values = append(values, line)
+	}
+	return values, nil
+}
+
+// timeFlagOrEnv parses the --name flag, falling back to PDX_WATCHER_<NAME> when the flag is absent
+// (e.g. for containerized cron jobs). Values are RFC3339 or YYYY-MM-DD (midnight in loc).
+// Returns the zero time when neither is set.
+func timeFlagOrEnv(flags protocli.FlagContainer, name string, loc *time.Location) (time.Time, error) {
+	value, source := timeFlagOrEnvValue(flags, name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := parseTimeBound(value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", source, err)
+	}
+	return t, nil
+}
+
+// timeFlagOrEnvValue returns the raw value timeFlagOrEnv parses and where it came from ("--name" or
+// the environment variable), or two empty strings when neither is set.
+func timeFlagOrEnvValue(flags protocli.FlagContainer, name string) (value, source string) {
+	if value := flags.StringNamed(name); value != "" {
+		return value, "--" + name
+	}
+	source = envPrefix + "_" + strings.ToUpper(name)
+	if value := os.Getenv(source); value != "" {
+		return value, source
+	}
+	return "", ""
+}
+
+// outputLocation returns the request's output timezone, or the CLI's local time if it has none.
+func outputLocation(req *proto.ListShowtimesRequest) (*time.Location, error) {
+	tz := req.GetOutputTimezone()
+	if tz == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --timezone %q: %w", tz, err)
+	}
+	return loc, nil
+}
+
+// stateFile returns where --since-last keeps its timestamp: path (--state-file) if set, else a file
+// in the user's config directory.
+func stateFile(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate --since-last state file (set --state-file): %w", err)
+	}
+	return filepath.Join(dir, "pdx-watcher", "since-last"), nil
+}
+
+// readLastRun returns the RFC3339 time stored at path, or the zero time if the file doesn't exist yet.
+func readLastRun(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("read --since-last state: %w", err)
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since-last state in %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// withSinceLastState wraps list-showtimes so --since-last records the run only once it has listed
+// everything: after a failed or interrupted run the previous timestamp stays, and the next run lists
+// the missed showtimes again. protocli's after-command hooks run regardless of the outcome.
+func withSinceLastState(rootCmd *cli.Command, cfg *rootConfig) {
+	for _, cmd := range rootCmd.Commands {
+		if cmd.Name != "list-showtimes" {
+			continue
+		}
+		action := cmd.Action
+		cmd.Action = func(ctx context.Context, cmd *cli.Command) error {
+			if !cmd.Bool("since-last") {
+				return action(ctx, cmd)
+			}
+			path, err := stateFile(cmd.String("state-file"))
+			if err != nil {
+				return err
+			}
+			// Taken before listing, so showtimes added while it runs are picked up next time.
+			started := cfg.now()
+			if err := action(ctx, cmd); err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			return writeLastRun(path, started)
+		}
+	}
+}
+
+// writeLastRun records t at path, creating parent directories as needed.
+func writeLastRun(path string, t time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create --since-last state dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(t.UTC().Format(time.RFC3339)+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write --since-last state: %w", err)
+	}
+	return nil
+}
+
+// dayBounds returns the start of date (YYYY-MM-DD) in loc and the start of the following day.
+func dayBounds(date string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --date (expected YYYY-MM-DD): %w", err)
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
+
 func parsePdxSite(value string) (proto.PdxSite, error) {
 	switch strings.ToLower(value) {
 	case "hollywoodtheatre", "hollywood-theatre":
@@ -265,8 +1252,10 @@ func parsePdxSite(value string) (proto.PdxSite, error) {
 		return proto.PdxSite_Cinemagic, nil
 	case "cinema21":
 		return proto.PdxSite_Cinema21, nil
+	case "none":
+		return proto.PdxSite_None, nil
 	}
-	return 0, fmt.Errorf("invalid site %q (valid: hollywood-theatre, cinemagic, cinema21)", value)
+	return 0, fmt.Errorf("invalid site %q (valid: hollywood-theatre, cinemagic, cinema21, none, all)", value)
 }
 
 func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,742 @@
+package root
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/browser"
+	"github.com/drewfead/pdx-watcher/internal/scraper"
+	"github.com/drewfead/pdx-watcher/internal/scraper/scrapertest"
+	"github.com/drewfead/pdx-watcher/proto"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protodelim"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUnit_DayBounds(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	start, end, err := dayBounds("2026-02-20", loc)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 2, 20, 0, 0, 0, 0, loc), start)
+	require.Equal(t, time.Date(2026, 2, 21, 0, 0, 0, 0, loc), end)
+	require.Equal(t, "2026-02-20T08:00:00Z", start.UTC().Format(time.RFC3339))
+
+	// A DST transition day is 23 hours long; bounds still land on local midnights.
+	start, end, err = dayBounds("2026-03-08", loc)
+	require.NoError(t, err)
+	require.Equal(t, 23*time.Hour, end.Sub(start))
+
+	_, _, err = dayBounds("02/20/2026", loc)
+	require.Error(t, err)
+}
+
+func TestUnit_DenseFormat_VenueTimezone(t *testing.T) {
+	format := &denseOutputFormat{
+		templateStr: denseTemplate,
+		venue: func(site proto.PdxSite) (internal.VenueInfo, bool) {
+			if site != proto.PdxSite_Cinema21 {
+				return internal.VenueInfo{}, false
+			}
+			return internal.VenueInfo{Name: "Cinema 21", Timezone: "America/New_York"}, true
+		},
+	}
+	msg := &proto.ListShowtimesResponse{
+		Showtime: &proto.Showtime{
+			Summary:   "Heat",
+			StartTime: timestamppb.New(time.Date(2026, 2, 20, 3, 30, 0, 0, time.UTC)),
+		},
+		Site: ptr(proto.PdxSite_Cinema21),
+	}
+	render := func(t *testing.T, args ...string) string {
+		t.Helper()
+		var out string
+		cmd := &cli.Command{
+			Name: "test",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "output-timezone"},
+				&cli.StringFlag{Name: "timezone"},
+			},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				var buf bytes.Buffer
+				err := format.Format(ctx, cmd, &buf, msg)
+				out = buf.String()
+				return err
+			},
+		}
+		require.NoError(t, cmd.Run(t.Context(), append([]string{"test"}, args...)))
+		return out
+	}
+
+	t.Run("venue timezone by default", func(t *testing.T) {
+		out := render(t)
+		require.True(t, strings.HasPrefix(out, "Feb 19 10:30 PM | cinema21"), out)
+	})
+
+	t.Run("output timezone overrides venue", func(t *testing.T) {
+		require.True(t, strings.HasPrefix(render(t, "--output-timezone", "UTC"), "Feb 20 03:30 AM | cinema21"))
+	})
+
+	t.Run("group header before the first of a group", func(t *testing.T) {
+		msg.Group, msg.GroupStart = ptr("70mm"), true
+		require.True(t, strings.HasPrefix(render(t), "== 70mm ==\nFeb 19 10:30 PM | cinema21"), render(t))
+		msg.GroupStart = false
+		require.True(t, strings.HasPrefix(render(t), "Feb 19 10:30 PM"), "no header within a group")
+	})
+}
+
+// deserializeListShowtimes runs the list-showtimes request deserializer against args.
+func deserializeListShowtimes(t *testing.T, c *rootConfig, args ...string) (*proto.ListShowtimesRequest, error) {
+	t.Helper()
+	var req *proto.ListShowtimesRequest
+	var deserializeErr error
+	cmd := &cli.Command{
+		Name: "test",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "after"},
+			&cli.StringFlag{Name: "before"},
+			&cli.StringFlag{Name: "timezone"},
+			&cli.BoolFlag{Name: "upcoming"},
+			&cli.StringFlag{Name: "summary-style"},
+			&cli.StringSliceFlag{Name: "from"},
+			&cli.StringSliceFlag{Name: "from-group"},
+			&cli.StringFlag{Name: "from-file"},
+			&cli.StringFlag{Name: "date"},
+			&cli.BoolFlag{Name: "today"},
+			&cli.BoolFlag{Name: "tomorrow"},
+			&cli.BoolFlag{Name: "this-week"},
+			&cli.BoolFlag{Name: "since-last"},
+			&cli.StringFlag{Name: "state-file"},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			msg, err := c.listShowtimesRequestDeserializer(ctx, protocli.NewFlagContainer(cmd, ""))
+			if err != nil {
+				deserializeErr = err
+				return nil
+			}
+			var ok bool
+			req, ok = msg.(*proto.ListShowtimesRequest)
+			require.True(t, ok)
+			return nil
+		},
+	}
+	require.NoError(t, cmd.Run(t.Context(), append([]string{"test"}, args...)))
+	return req, deserializeErr
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_Upcoming(t *testing.T) {
+	now := time.Date(2026, 2, 20, 18, 45, 0, 0, time.UTC)
+	c := &rootConfig{now: func() time.Time { return now }}
+
+	req, err := deserializeListShowtimes(t, c, "--upcoming", "--timezone", "America/Los_Angeles")
+	require.NoError(t, err)
+	require.True(t, req.GetAfter().AsTime().Equal(now), "After should be now, got %s", req.GetAfter().AsTime())
+
+	_, err = deserializeListShowtimes(t, c, "--upcoming", "--after", "2026-01-01T00:00:00Z")
+	require.ErrorContains(t, err, "--upcoming can't be combined with --after")
+	_, err = deserializeListShowtimes(t, c, "--upcoming", "--date", "2026-02-21")
+	require.ErrorContains(t, err, "--upcoming can't be combined with --date")
+	_, err = deserializeListShowtimes(t, c, "--upcoming", "--tomorrow")
+	require.ErrorContains(t, err, "--tomorrow can't be combined with --upcoming")
+	_, err = deserializeListShowtimes(t, c, "--upcoming", "--since-last", "--state-file", filepath.Join(t.TempDir(), "since-last"))
+	require.ErrorContains(t, err, "--upcoming can't be combined with --since-last")
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_RangeShortcuts(t *testing.T) {
+	now := time.Date(2026, 2, 20, 18, 45, 0, 0, time.UTC) // a Friday
+	c := &rootConfig{now: func() time.Time { return now }}
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		flag          string
+		after, before time.Time
+	}{
+		{"--today", time.Date(2026, 2, 20, 0, 0, 0, 0, loc), time.Date(2026, 2, 21, 0, 0, 0, 0, loc)},
+		{"--tomorrow", time.Date(2026, 2, 21, 0, 0, 0, 0, loc), time.Date(2026, 2, 22, 0, 0, 0, 0, loc)},
+		{"--this-week", time.Date(2026, 2, 20, 0, 0, 0, 0, loc), time.Date(2026, 2, 23, 0, 0, 0, 0, loc)},
+	} {
+		t.Run(tc.flag, func(t *testing.T) {
+			req, err := deserializeListShowtimes(t, c, tc.flag, "--timezone", "America/Los_Angeles")
+			require.NoError(t, err)
+			require.True(t, req.GetAfter().AsTime().Equal(tc.after), "after: got %s", req.GetAfter().AsTime())
+			require.True(t, req.GetBefore().AsTime().Equal(tc.before), "before: got %s", req.GetBefore().AsTime())
+		})
+	}
+
+	_, err = deserializeListShowtimes(t, c, "--today", "--after", "2026-02-01T00:00:00Z")
+	require.ErrorContains(t, err, "--today can't be combined with --after")
+	_, err = deserializeListShowtimes(t, c, "--this-week", "--before", "2026-03-01T00:00:00Z")
+	require.ErrorContains(t, err, "--this-week can't be combined with --before")
+	_, err = deserializeListShowtimes(t, c, "--today", "--tomorrow")
+	require.ErrorContains(t, err, "--today and --tomorrow can't be combined")
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_Date(t *testing.T) {
+	c := &rootConfig{now: time.Now}
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	req, err := deserializeListShowtimes(t, c, "--date", "2026-02-21", "--timezone", "America/Los_Angeles")
+	require.NoError(t, err)
+	require.True(t, req.GetAfter().AsTime().Equal(time.Date(2026, 2, 21, 0, 0, 0, 0, loc)), "after: got %s", req.GetAfter().AsTime())
+	require.True(t, req.GetBefore().AsTime().Equal(time.Date(2026, 2, 22, 0, 0, 0, 0, loc)), "before: got %s", req.GetBefore().AsTime())
+
+	_, err = deserializeListShowtimes(t, c, "--date", "2026-02-21", "--after", "2026-02-01T00:00:00Z")
+	require.ErrorContains(t, err, "--date can't be combined with --after")
+	_, err = deserializeListShowtimes(t, c, "--date", "2026-02-21", "--before", "2026-03-01T00:00:00Z")
+	require.ErrorContains(t, err, "--date can't be combined with --before")
+	_, err = deserializeListShowtimes(t, c, "--date", "2026-02-21", "--since-last", "--state-file", filepath.Join(t.TempDir(), "since-last"))
+	require.ErrorContains(t, err, "--date can't be combined with --since-last")
+
+	t.Run("bounds from the environment conflict too", func(t *testing.T) {
+		t.Setenv("PDX_WATCHER_AFTER", "2026-02-01T00:00:00Z")
+		_, err := deserializeListShowtimes(t, c, "--date", "2026-02-21")
+		require.ErrorContains(t, err, "--date can't be combined with PDX_WATCHER_AFTER")
+		_, err = deserializeListShowtimes(t, c, "--today")
+		require.ErrorContains(t, err, "--today can't be combined with PDX_WATCHER_AFTER")
+	})
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_FromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# repertory houses\ncinema21\n\n  hollywood-theatre  \n"), 0o600))
+
+	req, err := deserializeListShowtimes(t, &rootConfig{now: time.Now}, "--from", "cinemagic", "--from-file", path)
+	require.NoError(t, err)
+	require.Equal(t, []proto.PdxSite{proto.PdxSite_Cinemagic, proto.PdxSite_Cinema21, proto.PdxSite_HollywoodTheatre}, req.GetFrom())
+	require.Empty(t, req.GetFromGroup(), "the comment is not read as a group name")
+
+	_, err = deserializeListShowtimes(t, &rootConfig{now: time.Now}, "--from-file", filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorContains(t, err, "read --from-file")
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_TimeBoundsFromEnv(t *testing.T) {
+	c := &rootConfig{now: time.Now}
+	t.Setenv("PDX_WATCHER_AFTER", "2026-02-01T00:00:00Z")
+	t.Setenv("PDX_WATCHER_BEFORE", "2026-03-01T00:00:00Z")
+
+	t.Run("used when flags are omitted", func(t *testing.T) {
+		req, err := deserializeListShowtimes(t, c)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), req.GetAfter().AsTime())
+		require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), req.GetBefore().AsTime())
+	})
+
+	t.Run("flags win", func(t *testing.T) {
+		req, err := deserializeListShowtimes(t, c, "--after", "2026-02-15T00:00:00Z")
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), req.GetAfter().AsTime())
+		require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), req.GetBefore().AsTime())
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		t.Setenv("PDX_WATCHER_AFTER", "last tuesday")
+		_, err := deserializeListShowtimes(t, c)
+		require.ErrorContains(t, err, "PDX_WATCHER_AFTER")
+	})
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_DateOnlyBounds(t *testing.T) {
+	c := &rootConfig{now: time.Now}
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	req, err := deserializeListShowtimes(t, c,
+		"--after", "2026-02-20", "--before", "2026-02-22T12:00:00Z", "--timezone", "America/Los_Angeles")
+	require.NoError(t, err)
+	require.True(t, req.GetAfter().AsTime().Equal(time.Date(2026, 2, 20, 0, 0, 0, 0, loc)))
+	require.Equal(t, "2026-02-20T08:00:00Z", req.GetAfter().AsTime().Format(time.RFC3339))
+	require.Equal(t, "2026-02-22T12:00:00Z", req.GetBefore().AsTime().Format(time.RFC3339), "RFC3339 still works")
+
+	_, err = deserializeListShowtimes(t, c, "--after", "02/20/2026")
+	require.ErrorContains(t, err, "YYYY-MM-DD")
+}
+
+func TestUnit_JSONFormat_Pretty(t *testing.T) {
+	msg := &proto.ListShowtimesResponse{Showtime: &proto.Showtime{Summary: "Heat"}}
+	render := func(t *testing.T, terminal bool, args ...string) string {
+		t.Helper()
+		format := &jsonOutputFormat{terminal: func(io.Writer) bool { return terminal }}
+		var buf bytes.Buffer
+		cmd := &cli.Command{
+			Name:  "test",
+			Flags: format.Flags(),
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				return format.Format(ctx, cmd, &buf, msg)
+			},
+		}
+		require.NoError(t, cmd.Run(t.Context(), append([]string{"test"}, args...)))
+		return buf.String()
+	}
+
+	for _, tc := range []struct {
+		name     string
+		terminal bool
+		args     []string
+		indented bool
+	}{
+		{name: "piped defaults to compact", terminal: false, indented: false},
+		{name: "terminal defaults to pretty", terminal: true, indented: true},
+		{name: "--pretty when piped", terminal: false, args: []string{"--pretty"}, indented: true},
+		{name: "--pretty=false on terminal", terminal: true, args: []string{"--pretty=false"}, indented: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := render(t, tc.terminal, tc.args...)
+			require.Contains(t, out, "Heat")
+			require.Equal(t, tc.indented, strings.Contains(out, "\n  "), out)
+		})
+	}
+}
+
+func TestUnit_OutputFormats(t *testing.T) {
+	require.Subset(t, OutputFormats(), []string{"dense", "json", "json-array", "jsonl", "yaml"})
+
+	rootCmd, err := Root(t.Context())
+	require.NoError(t, err, "Root")
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	require.NoError(t, rootCmd.Run(t.Context(), []string{"pdx-watcher", "formats"}))
+	require.Equal(t, strings.Join(OutputFormats(), "\n")+"\n", buf.String())
+}
+
+func TestUnit_Quiet(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	rootCmd, err := Root(t.Context())
+	require.NoError(t, err, "Root")
+	var stderr bytes.Buffer
+	rootCmd.Writer = io.Discard
+	rootCmd.ErrWriter = &stderr
+	require.NoError(t, rootCmd.Run(t.Context(), []string{"pdx-watcher", "--verbosity", "debug", "--quiet", "formats"}))
+
+	slog.Debug("debug message")
+	slog.Info("info message")
+	require.Empty(t, stderr.String(), "--quiet should drop info and debug logs")
+
+	slog.Error("error message")
+	require.Contains(t, stderr.String(), "error message")
+}
+
+// titleOutputFormat is a minimal custom format that writes each showtime's summary; protocli ends each message with a newline.
+type titleOutputFormat struct{}
+
+func (titleOutputFormat) Name() string { return "titles" }
+
+func (titleOutputFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg protobuf.Message) error {
+	resp, ok := msg.(*proto.ListShowtimesResponse)
+	if !ok || resp.GetShowtime() == nil {
+		return nil
+	}
+	_, err := io.WriteString(w, resp.GetShowtime().GetSummary())
+	return err
+}
+
+func TestUnit_WithOutputFormat(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: []internal.SourceShowtime{
+		{ID: "a", Summary: "Heat", StartTime: start},
+		{ID: "b", Summary: "Thief", StartTime: start.Add(3 * time.Hour)},
+	}}))
+	rootCmd, err := Root(t.Context(), WithRegistry(registry), WithOutputFormat(titleOutputFormat{}))
+	require.NoError(t, err, "Root")
+
+	var formats bytes.Buffer
+	rootCmd.Writer = &formats
+	require.NoError(t, rootCmd.Run(t.Context(), []string{"pdx-watcher", "formats"}))
+	require.Contains(t, strings.Split(strings.TrimSpace(formats.String()), "\n"), "titles")
+
+	rootCmd, err = Root(t.Context(), WithRegistry(registry), WithOutputFormat(titleOutputFormat{}))
+	require.NoError(t, err, "Root")
+	outputFile := filepath.Join(t.TempDir(), "output.txt")
+	require.NoError(t, rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "cinema21",
+		"--after", "2026-02-20T00:00:00Z",
+		"--before", "2026-02-21T00:00:00Z",
+		"--format", "titles",
+		"--output", outputFile,
+	}))
+	out, err := os.ReadFile(outputFile)
+	require.NoError(t, err, "ReadFile")
+	require.Equal(t, "Heat\nThief\n", string(out))
+}
+
+func TestUnit_SinceLast_RecordsOnlySuccessfulRuns(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "since-last")
+	now := time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)
+	run := func(s internal.Scraper, args ...string) error {
+		t.Helper()
+		registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, s))
+		rootCmd, err := Root(t.Context(), WithRegistry(registry), WithClock(func() time.Time { return now }))
+		require.NoError(t, err, "Root")
+		rootCmd.Writer = io.Discard
+		return rootCmd.Run(t.Context(), append([]string{
+			"pdx-watcher", "list-showtimes",
+			"--from", "cinema21",
+			"--since-last",
+			"--state-file", stateFile,
+			"--output", filepath.Join(t.TempDir(), "output.jsonl"),
+		}, args...))
+	}
+
+	require.Error(t, run(scrapertest.Static{Err: errors.New("site down")}))
+	_, err := os.Stat(stateFile)
+	require.ErrorIs(t, err, fs.ErrNotExist, "a failed run should not record state")
+
+	require.Error(t, run(scrapertest.Static{}, "--date", "2026-02-25"))
+	_, err = os.Stat(stateFile)
+	require.ErrorIs(t, err, fs.ErrNotExist, "a rejected run should not record state")
+
+	require.NoError(t, run(scrapertest.Static{}))
+	last, err := readLastRun(stateFile)
+	require.NoError(t, err)
+	require.Equal(t, now, last)
+}
+
+func TestUnit_ListShowtimesRequestDeserializer_SummaryStyle(t *testing.T) {
+	c := &rootConfig{now: time.Now}
+
+	req, err := deserializeListShowtimes(t, c, "--summary-style", "series-title")
+	require.NoError(t, err)
+	require.Equal(t, "series-title", req.GetSummaryStyle())
+
+	req, err = deserializeListShowtimes(t, c)
+	require.NoError(t, err)
+	require.Nil(t, req.SummaryStyle, "unset leaves the service default")
+}
+
+// writeRecorder records each Write separately.
+type writeRecorder struct {
+	writes []string
+}
+
+func (w *writeRecorder) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestUnit_JSONLFormat_OneLinePerMessage(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: []internal.SourceShowtime{
+		{ID: "a", Summary: "Heat", StartTime: start},
+		{ID: "b", Summary: "Thief", StartTime: start.Add(3 * time.Hour)},
+	}}))
+	rootCmd, err := Root(t.Context(), WithRegistry(registry))
+	require.NoError(t, err, "Root")
+	outputFile := filepath.Join(t.TempDir(), "output.jsonl")
+	require.NoError(t, rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "cinema21",
+		"--after", "2026-02-20T00:00:00Z",
+		"--before", "2026-02-21T00:00:00Z",
+		"--format", "jsonl",
+		"--delimiter", ";",
+		"--output", outputFile,
+	}))
+	out, err := os.ReadFile(outputFile)
+	require.NoError(t, err, "ReadFile")
+	require.True(t, strings.HasSuffix(string(out), "}\n"), "every line ends in a newline, whatever --delimiter says: %q", out)
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for i, want := range []string{"Heat", "Thief"} {
+		var resp struct {
+			Showtime struct {
+				Summary string `json:"summary"`
+			} `json:"showtime"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &resp), "line %d is one JSON object: %s", i, lines[i])
+		require.Equal(t, want, resp.Showtime.Summary)
+	}
+}
+
+func TestUnit_JSONArrayFormat_WritesEachElementAsItArrives(t *testing.T) {
+	format := &jsonArrayOutputFormat{}
+	cmd := &cli.Command{Flags: []cli.Flag{&cli.BoolFlag{Name: "pretty"}}}
+	w := &writeRecorder{}
+	for i := range 3 {
+		msg := &proto.ListShowtimesResponse{Showtime: &proto.Showtime{Id: fmt.Sprint(i)}}
+		require.NoError(t, format.Format(t.Context(), cmd, w, msg))
+		require.Len(t, w.writes, i+1, "message %d is written before the next one arrives", i)
+	}
+	require.True(t, strings.HasPrefix(w.writes[0], "["))
+	require.True(t, strings.HasPrefix(w.writes[1], ","))
+}
+
+func TestUnit_JSONArrayFormat_LargeStream(t *testing.T) {
+	const n = 5000
+	start := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	showtimes := make([]internal.SourceShowtime, n)
+	for i := range showtimes {
+		showtimes[i] = internal.SourceShowtime{ID: fmt.Sprint(i), Summary: "Heat", StartTime: start.Add(time.Duration(i) * time.Minute)}
+	}
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: showtimes}))
+	rootCmd, err := Root(t.Context(), WithRegistry(registry))
+	require.NoError(t, err, "Root")
+
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+	require.NoError(t, rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "cinema21",
+		"--after", "2026-02-19T00:00:00Z",
+		"--before", "2026-03-01T00:00:00Z",
+		"--limit", "0",
+		"--format", "json-array",
+		"--output", outputFile,
+	}))
+	out, err := os.ReadFile(outputFile)
+	require.NoError(t, err, "ReadFile")
+	var items []json.RawMessage
+	require.NoError(t, json.Unmarshal(out, &items), "output is one valid JSON array")
+	require.Len(t, items, n)
+}
+
+func TestUnit_DenseFormat_Summary(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	showtimes := []internal.SourceShowtime{
+		{ID: "1", Summary: "Heat", StartTime: start},
+		{ID: "2", Summary: "Alien", StartTime: start.Add(time.Hour)},
+		{ID: "3", Summary: "HEAT", StartTime: start.Add(24 * time.Hour)},
+	}
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: showtimes}))
+	run := func(t *testing.T, args ...string) []string {
+		t.Helper()
+		rootCmd, err := Root(t.Context(), WithRegistry(registry))
+		require.NoError(t, err, "Root")
+		outputFile := filepath.Join(t.TempDir(), "output.txt")
+		require.NoError(t, rootCmd.Run(t.Context(), append([]string{
+			"pdx-watcher", "list-showtimes",
+			"--from", "cinema21",
+			"--after", "2026-02-19T00:00:00Z",
+			"--before", "2026-03-01T00:00:00Z",
+			"--format", "dense",
+			"--output", outputFile,
+		}, args...)))
+		out, err := os.ReadFile(outputFile)
+		require.NoError(t, err, "ReadFile")
+		return strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	}
+
+	lines := run(t, "--summary")
+	require.Len(t, lines, 4, "three showtimes, then the summary")
+	require.Equal(t, "3 showtimes, 2 films", lines[3])
+
+	lines = run(t)
+	require.Len(t, lines, 3, "no summary unless asked")
+}
+
+func TestUnit_DenseFormat_Free(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	showtimes := []internal.SourceShowtime{
+		{ID: "1", Summary: "Heat", StartTime: start, Admission: "$9"},
+		{ID: "2", Summary: "Born Free", StartTime: start.Add(time.Hour)},
+		{ID: "3", Summary: "Thief", StartTime: start.Add(2 * time.Hour), Admission: internal.AdmissionFree},
+	}
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: showtimes}))
+	run := func(t *testing.T, args ...string) []string {
+		t.Helper()
+		rootCmd, err := Root(t.Context(), WithRegistry(registry))
+		require.NoError(t, err, "Root")
+		outputFile := filepath.Join(t.TempDir(), "output.txt")
+		require.NoError(t, rootCmd.Run(t.Context(), append([]string{
+			"pdx-watcher", "list-showtimes",
+			"--from", "cinema21",
+			"--after", "2026-02-19T00:00:00Z",
+			"--before", "2026-03-01T00:00:00Z",
+			"--timezone", "UTC",
+			"--format", "dense",
+			"--output", outputFile,
+		}, args...)))
+		out, err := os.ReadFile(outputFile)
+		require.NoError(t, err, "ReadFile")
+		return strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	}
+
+	lines := run(t)
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasSuffix(lines[0], "| Heat ($9)"), lines[0])
+	require.True(t, strings.HasSuffix(lines[1], "| Born Free"), "unknown admission is left off: %s", lines[1])
+
+	require.Equal(t, []string{"Feb 20 09:00 PM | cinema21             | Thief (Free)"}, run(t, "--free"))
+}
+
+func TestUnit_ProtobufFormat_DelimitedShowtimes(t *testing.T) {
+	const n = 50
+	start := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	showtimes := make([]internal.SourceShowtime, n)
+	for i := range showtimes {
+		showtimes[i] = internal.SourceShowtime{ID: fmt.Sprint(i), Summary: "Heat", StartTime: start.Add(time.Duration(i) * time.Minute)}
+	}
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: showtimes}))
+	args := []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "cinema21",
+		"--after", "2026-02-19T00:00:00Z",
+		"--before", "2026-03-01T00:00:00Z",
+		"--format", "protobuf",
+	}
+	decode := func(t *testing.T, out []byte) []*proto.Showtime {
+		t.Helper()
+		var got []*proto.Showtime
+		r := bufio.NewReader(bytes.NewReader(out))
+		for {
+			var st proto.Showtime
+			err := protodelim.UnmarshalFrom(r, &st)
+			if errors.Is(err, io.EOF) {
+				return got
+			}
+			require.NoError(t, err, "decode message %d", len(got))
+			got = append(got, &st)
+		}
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		rootCmd, err := Root(t.Context(), WithRegistry(registry))
+		require.NoError(t, err, "Root")
+		var out bytes.Buffer
+		rootCmd.Command("list-showtimes").Writer = &out
+		require.NoError(t, rootCmd.Run(t.Context(), args))
+		got := decode(t, out.Bytes())
+		require.Len(t, got, n)
+		require.Equal(t, "Heat", got[0].GetSummary())
+	})
+
+	t.Run("output file", func(t *testing.T) {
+		rootCmd, err := Root(t.Context(), WithRegistry(registry))
+		require.NoError(t, err, "Root")
+		outputFile := filepath.Join(t.TempDir(), "output.binpb")
+		require.NoError(t, rootCmd.Run(t.Context(), append(args, "--output", outputFile)))
+		out, err := os.ReadFile(outputFile)
+		require.NoError(t, err, "ReadFile")
+		require.Len(t, decode(t, out), n)
+	})
+}
+
+func TestUnit_JSONFormat_JSONIndentTabSortsKeys(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: []internal.SourceShowtime{
+		{ID: "a", Summary: "Heat", StartTime: start, Screening: internal.ScreeningInfo{Title: "Heat", Links: []internal.Link{{Href: "https://example.com/heat?a=1&b=2", Display: "Info"}}}},
+		{ID: "b", Summary: "Thief", StartTime: start.Add(3 * time.Hour)},
+	}}))
+	rootCmd, err := Root(t.Context(), WithRegistry(registry))
+	require.NoError(t, err, "Root")
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+	require.NoError(t, rootCmd.Run(t.Context(), []string{
+		"pdx-watcher", "list-showtimes",
+		"--from", "cinema21",
+		"--after", "2026-02-20T00:00:00Z",
+		"--before", "2026-02-21T00:00:00Z",
+		"--format", "json",
+		"--json-indent", "tab",
+		"--output", outputFile,
+	}))
+	out, err := os.ReadFile(outputFile)
+	require.NoError(t, err, "ReadFile")
+
+	for line := range strings.Lines(string(out)) {
+		require.False(t, strings.HasPrefix(line, " "), "indented with spaces: %q", line)
+	}
+	require.Contains(t, string(out), "\n\t\"showtime\": {\n\t\t", "nested values are indented with tabs")
+	require.Contains(t, string(out), "a=1&b=2", "HTML characters are not escaped")
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	var docs int
+	for dec.More() {
+		requireSortedKeys(t, dec)
+		docs++
+	}
+	require.Equal(t, 2, docs)
+}
+
+// requireSortedKeys reads one JSON value from dec and fails if any object's keys are out of order.
+func requireSortedKeys(t *testing.T, dec *json.Decoder) {
+	t.Helper()
+	tok, err := dec.Token()
+	require.NoError(t, err)
+	switch tok {
+	case json.Delim('{'):
+		var keys []string
+		for dec.More() {
+			key, err := dec.Token()
+			require.NoError(t, err)
+			keys = append(keys, key.(string))
+			requireSortedKeys(t, dec)
+		}
+		_, err = dec.Token()
+		require.NoError(t, err)
+		require.True(t, slices.IsSorted(keys), "keys out of order: %v", keys)
+	case json.Delim('['):
+		for dec.More() {
+			requireSortedKeys(t, dec)
+		}
+		_, err = dec.Token()
+		require.NoError(t, err)
+	}
+}
+
+func TestUnit_DefaultRegistry_NoBrowser(t *testing.T) {
+	registry := defaultRegistry(&proto.ShowtimeConfig{NoBrowser: true, LogHttp: true})
+	// Cinemagic falls back to HTTP instead of reporting the disabled browser; a cancelled context
+	// stops the fallback before it reaches the network.
+	sc, err := registry.GetScraper(scraper.SiteDescriptor(proto.PdxSite_Cinemagic))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = sc.ScrapeShowtimes(ctx, internal.ListShowtimesRequest{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotErrorIs(t, err, browser.ErrDisabled)
+}
+
+func TestUnit_ListShowtimes_FromNone(t *testing.T) {
+	sc, err := defaultRegistry(&proto.ShowtimeConfig{NoBrowser: true}).GetScraper(scraper.SiteDescriptor(proto.PdxSite_None))
+	require.NoError(t, err, "default registry should register None")
+	require.Equal(t, scraper.SiteDescriptor(proto.PdxSite_None), sc.Descriptor())
+
+	// This registry has no None scraper; --from none still lists nothing rather than failing.
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Site: proto.PdxSite_Cinema21, Showtimes: []internal.SourceShowtime{
+		{ID: "a", Summary: "Heat", StartTime: time.Now().Add(time.Hour)},
+	}}))
+	rootCmd, err := Root(t.Context(), WithRegistry(registry))
+	require.NoError(t, err, "Root")
+	var out bytes.Buffer
+	rootCmd.Writer = &out
+	rootCmd.Command("list-showtimes").Writer = &out
+	require.NoError(t, rootCmd.Run(t.Context(), []string{"pdx-watcher", "list-showtimes", "--from", "none", "--format", "json"}))
+	require.Empty(t, strings.TrimSpace(out.String()))
+}
+
+func TestUnit_SiteGroups_FromConfig(t *testing.T) {
+	req, err := deserializeListShowtimes(t, &rootConfig{now: time.Now}, "--from", "repertory", "--from", "cinemagic")
+	require.NoError(t, err)
+	require.Equal(t, []proto.PdxSite{proto.PdxSite_Cinemagic}, req.GetFrom())
+	require.Equal(t, []string{"repertory"}, req.GetFromGroup())
+
+	registry := defaultRegistry(&proto.ShowtimeConfig{
+		NoBrowser: true,
+		Groups: map[string]*proto.SiteGroup{
+			"Repertory": {Sites: []proto.PdxSite{proto.PdxSite_HollywoodTheatre, proto.PdxSite_Cinema21}},
+		},
+	})
+	sites, ok := registry.SiteGroup("repertory")
+	require.True(t, ok)
+	require.Equal(t, []proto.PdxSite{proto.PdxSite_HollywoodTheatre, proto.PdxSite_Cinema21}, sites)
+}
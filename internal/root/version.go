@@ -0,0 +1,88 @@
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/urfave/cli/v3"
+)
+
+// Set at build time, e.g.
+//
+//	go build -ldflags "-X github.com/drewfead/pdx-watcher/internal/root.version=v1.2.3" ./cmd
+//
+// Anything left empty falls back to the module and VCS info Go embeds in the binary.
+var (
+	version string
+	commit  string
+	date    string
+)
+
+// BuildInfo identifies the running build for bug reports.
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// currentBuildInfo merges the ldflags values with debug.ReadBuildInfo.
+func currentBuildInfo() BuildInfo {
+	info := BuildInfo{Version: version, Commit: commit, Date: date}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch {
+			case s.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = s.Value
+			case s.Key == "vcs.time" && info.Date == "":
+				info.Date = s.Value
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "(devel)"
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.Date == "" {
+		info.Date = "unknown"
+	}
+	return info
+}
+
+func versionCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "version",
+		Usage: "Print the version, git commit, and build date",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "output",
+				Value: "text",
+				Usage: "Output format (text, json)",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			info := currentBuildInfo()
+			w := cmd.Root().Writer
+			switch cmd.String("output") {
+			case "json":
+				out, err := json.Marshal(info)
+				if err != nil {
+					return fmt.Errorf("marshal build info: %w", err)
+				}
+				_, err = fmt.Fprintln(w, string(out))
+				return err
+			case "text":
+				_, err := fmt.Fprintf(w, "version: %s\ncommit:  %s\ndate:    %s\n", info.Version, info.Commit, info.Date)
+				return err
+			default:
+				return fmt.Errorf("unknown --output %q (want text or json)", cmd.String("output"))
+			}
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package root
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_VersionCommand(t *testing.T) {
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		rootCmd, err := Root(t.Context())
+		require.NoError(t, err, "Root")
+		var buf bytes.Buffer
+		rootCmd.Writer = &buf
+		require.NoError(t, rootCmd.Run(t.Context(), append([]string{"pdx-watcher", "version"}, args...)))
+		return buf.String()
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out := run(t)
+		require.Contains(t, out, "version: ")
+		require.Contains(t, out, "commit: ")
+		require.Contains(t, out, "date: ")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var info map[string]string
+		require.NoError(t, json.Unmarshal([]byte(run(t, "--output", "json")), &info))
+		require.NotEmpty(t, info["version"])
+		require.NotEmpty(t, info["commit"])
+		require.NotEmpty(t, info["date"])
+	})
+
+	t.Run("ldflags win", func(t *testing.T) {
+		defer func(v, c, d string) { version, commit, date = v, c, d }(version, commit, date)
+		version, commit, date = "v1.2.3", "abc123", "2026-01-02T03:04:05Z"
+		var info BuildInfo
+		require.NoError(t, json.Unmarshal([]byte(run(t, "--output", "json")), &info))
+		require.Equal(t, BuildInfo{Version: "v1.2.3", Commit: "abc123", Date: "2026-01-02T03:04:05Z"}, info)
+	})
+}
@@ -9,8 +9,43 @@ type Scraper interface {
 	// Descriptor returns the site descriptor (e.g. for cache keys and registry lookup).
 	Descriptor() string
 	ScrapeShowtimes(ctx context.Context, req ListShowtimesRequest) (<-chan ShowtimeListItem, error)
+	// Capabilities reports which optional SourceShowtime fields the scraper populates.
+	Capabilities() Capabilities
 }
 
+// Invalidator is implemented by scrapers that cache results and can drop them on demand,
+// so a forced refresh (e.g. in watch mode) doesn't need a process restart.
+type Invalidator interface {
+	// Invalidate drops the cached result for req, if any.
+	Invalidate(req ListShowtimesRequest)
+	// InvalidateAll drops every cached result.
+	InvalidateAll()
+}
+
+// Capabilities is a set of optional SourceShowtime fields a scraper populates. Filters on a field
+// the selected scrapers don't provide can never match, so callers use this to warn about them.
+type Capabilities uint8
+
+const (
+	CapabilityDirector      Capabilities = 1 << iota // DirectorHint
+	CapabilityRuntime                                // RuntimeHint
+	CapabilityDescription                            // Description
+	CapabilityEndTime                                // EndTime
+	CapabilityAdmission                              // Admission
+	CapabilityAccessibility                          // Screening.Accessibility
+	CapabilityTickets                                // a Screening.Links entry with LinkRelTickets
+)
+
+// Has reports whether every capability in c is present in s.
+func (s Capabilities) Has(c Capabilities) bool {
+	return s&c == c
+}
+
+// NoCapabilities is embedded by scrapers that populate none of the optional fields.
+type NoCapabilities struct{}
+
+func (NoCapabilities) Capabilities() Capabilities { return 0 }
+
 // GoldenScraper extends Scraper with the ability to pull and write golden test data.
 type GoldenScraper interface {
 	Scraper
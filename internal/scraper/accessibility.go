@@ -0,0 +1,44 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/drewfead/pdx-watcher/internal"
+)
+
+// accessibilityPhrases maps listing wording to the access feature it announces. Matching is
+// case-insensitive and by substring, so "Open Captions" and "open-captioned" both count.
+var accessibilityPhrases = []struct {
+	phrase  string
+	feature string
+}{
+	{"open caption", internal.AccessibilityOpenCaptions},
+	{"open-caption", internal.AccessibilityOpenCaptions},
+	{"audio description", internal.AccessibilityAudioDescription},
+	{"audio-described", internal.AccessibilityAudioDescription},
+	{"audio described", internal.AccessibilityAudioDescription},
+	{"descriptive audio", internal.AccessibilityAudioDescription},
+	{"sensory friendly", internal.AccessibilitySensoryFriendly},
+	{"sensory-friendly", internal.AccessibilitySensoryFriendly},
+}
+
+// accessibilityFeatures returns the access features mentioned in texts (a title, subhed, or
+// format classes), in the order internal.AccessibilityFeatures lists them, or nil if none.
+func accessibilityFeatures(texts ...string) []string {
+	found := make(map[string]bool)
+	for _, text := range texts {
+		lower := strings.ToLower(text)
+		for _, p := range accessibilityPhrases {
+			if strings.Contains(lower, p.phrase) {
+				found[p.feature] = true
+			}
+		}
+	}
+	var features []string
+	for _, feature := range internal.AccessibilityFeatures {
+		if found[feature] {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
@@ -0,0 +1,34 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/drewfead/pdx-watcher/internal"
+)
+
+// freeAdmissionPhrases are listing wordings that announce a screening costs nothing. Bare "free"
+// is deliberately absent: it would match titles like "Free Solo" or "Born Free".
+var freeAdmissionPhrases = []string{
+	"free admission",
+	"admission is free",
+	"admission: free",
+	"free screening",
+	"free show",
+	"free event",
+	"free of charge",
+	"no charge",
+}
+
+// admission returns internal.AdmissionFree when texts (a title, series, subhed, or session
+// attributes) announce free admission, or "" when the listing doesn't say.
+func admission(texts ...string) string {
+	for _, text := range texts {
+		lower := strings.ToLower(text)
+		for _, phrase := range freeAdmissionPhrases {
+			if strings.Contains(lower, phrase) {
+				return internal.AdmissionFree
+			}
+		}
+	}
+	return ""
+}
@@ -3,9 +3,14 @@ package scraper
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
 	"github.com/hashicorp/golang-lru/v2/expirable"
 )
 
@@ -15,37 +20,136 @@ import (
 //	scraper.NewRegistry(scraper.WithScraperForSite(site, scraper.HollywoodTheatre(), scraper.Cached(64, 5*time.Minute)))
 //
 // maxEntries is the LRU size; ttl is how long entries stay valid (zero = no expiration).
-func Cached(maxEntries int, ttl time.Duration) ScraperMiddleware {
+func Cached(maxEntries int, ttl time.Duration, opts ...CacheOption) ScraperMiddleware {
 	return func(inner internal.Scraper) internal.Scraper {
 		if inner == nil {
 			return nil
 		}
-		return newCachingScraper(inner, maxEntries, ttl)
+		return newCachingScraper(inner, maxEntries, ttl, opts...)
+	}
+}
+
+// CachedWithSchedule is Cached for sites that only update on some days: live scrapes happen only when
+// allow(now) is true, and at other times results are served from the cache even once they are past ttl
+// (a request with nothing cached still scrapes). Pair it with ScheduleDays for cron setups:
+//
+//	scraper.CachedWithSchedule(64, 24*time.Hour, scraper.ScheduleDays(time.Tuesday, time.Friday))
+func CachedWithSchedule(maxEntries int, ttl time.Duration, allow func(time.Time) bool, opts ...CacheOption) ScraperMiddleware {
+	return Cached(maxEntries, ttl, append([]CacheOption{cacheWithSchedule(allow)}, opts...)...)
+}
+
+// ScheduleDays returns a schedule that allows live scrapes on the given weekdays, in Portland time.
+func ScheduleDays(days ...time.Weekday) func(time.Time) bool {
+	allowed := make(map[time.Weekday]bool, len(days))
+	for _, d := range days {
+		allowed[d] = true
+	}
+	return func(t time.Time) bool {
+		return allowed[t.In(portlandTZ).Weekday()]
+	}
+}
+
+// CacheOption configures the caching middleware.
+type CacheOption func(*cachingScraper)
+
+// CacheWithJitter varies each entry's TTL uniformly within ±fraction of the base TTL (e.g. 0.1 for ±10%),
+// so caches filled at the same moment don't all expire and refresh at the same instant.
+func CacheWithJitter(fraction float64) CacheOption {
+	return func(c *cachingScraper) {
+		if fraction > 0 && fraction < 1 {
+			c.jitter = fraction
+		}
+	}
+}
+
+// CacheWithClock sets the clock used for expiry and schedule checks. Use in tests to simulate fixed times.
+func CacheWithClock(now func() time.Time) CacheOption {
+	return func(c *cachingScraper) {
+		if now != nil {
+			c.now = now
+		}
+	}
+}
+
+func cacheWithSchedule(allow func(time.Time) bool) CacheOption {
+	return func(c *cachingScraper) {
+		c.schedule = allow
 	}
 }
 
 // newCachingScraper returns a Scraper that caches inner's results. Prefer using Caching middleware.
-func newCachingScraper(inner internal.Scraper, maxEntries int, ttl time.Duration) internal.Scraper {
+func newCachingScraper(inner internal.Scraper, maxEntries int, ttl time.Duration, opts ...CacheOption) internal.Scraper {
 	if inner == nil {
 		return nil
 	}
 	if maxEntries <= 0 {
 		maxEntries = 64
 	}
-	cache := expirable.NewLRU[string, []internal.ShowtimeListItem](maxEntries, nil, ttl)
-	return &cachingScraper{
+	c := &cachingScraper{
 		descriptor: inner.Descriptor(),
 		inner:      inner,
-		cache:      cache,
+		inflight:   make(map[string]*sharedScrape),
+		ttl:        ttl,
+		now:        time.Now,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	// The LRU's own TTL is the longest an entry can live; jittered entries expire earlier via cacheEntry.expires.
+	// A schedule keeps stale entries around for off-days, so expiry is left to cacheEntry.expires alone.
+	lruTTL := ttl + time.Duration(float64(ttl)*c.jitter)
+	if c.schedule != nil {
+		lruTTL = 0
+	}
+	c.cache = expirable.NewLRU[string, cacheEntry](maxEntries, nil, lruTTL)
+	return c
 }
 
 // cachingScraper wraps a Scraper and caches full scrape results by request (LRU + TTL).
-// The cache key is descriptor + request (after, before, limit, anchor). Only implements Scraper.
+// The cache key is descriptor + request (after, before, limit, anchor). Implements Scraper and Invalidator.
 type cachingScraper struct {
 	descriptor string
 	inner      internal.Scraper
-	cache      *expirable.LRU[string, []internal.ShowtimeListItem]
+	cache      *expirable.LRU[string, cacheEntry]
+	inflightMu sync.Mutex
+	inflight   map[string]*sharedScrape // scrapes in progress, by key
+	ttl        time.Duration
+	jitter     float64              // fraction of ttl each entry's expiry may vary by (0 = fixed ttl)
+	schedule   func(time.Time) bool // when live scrapes are allowed (nil = always)
+	now        func() time.Time
+	disk       *diskStore // nil = memory only
+}
+
+// cacheEntry is a cached result set with its own expiry (zero = rely on the LRU TTL alone).
+// skips holds the scrape's skip reports so cache hits can replay them.
+type cacheEntry struct {
+	items   []internal.ShowtimeListItem
+	skips   []skipReport
+	expires time.Time
+}
+
+// skipReport is one ReportSkips call made by the wrapped scraper.
+type skipReport struct {
+	site  proto.PdxSite
+	skips internal.SkipCounts
+}
+
+// replay returns a closed channel buffered with the entry's items, after replaying its skip reports to req.
+func (e cacheEntry) replay(req internal.ListShowtimesRequest) <-chan internal.ShowtimeListItem {
+	for _, r := range e.skips {
+		req.ReportSkips(r.site, r.skips)
+	}
+	return replay(e.items)
+}
+
+// newEntry wraps items with an expiry jittered around ttl.
+func (c *cachingScraper) newEntry(items []internal.ShowtimeListItem, now time.Time) cacheEntry {
+	entry := cacheEntry{items: items}
+	if c.ttl > 0 && (c.jitter > 0 || c.schedule != nil) {
+		spread := float64(c.ttl) * c.jitter
+		entry.expires = now.Add(c.ttl + time.Duration((rand.Float64()*2-1)*spread))
+	}
+	return entry
 }
 
 func cacheKey(req internal.ListShowtimesRequest) string {
@@ -67,30 +171,196 @@ func (c *cachingScraper) Descriptor() string {
 	return c.descriptor
 }
 
+func (c *cachingScraper) Capabilities() internal.Capabilities {
+	return c.inner.Capabilities()
+}
+
+func (c *cachingScraper) key(req internal.ListShowtimesRequest) string {
+	return c.descriptor + ":" + cacheKey(req)
+}
+
+func (c *cachingScraper) Invalidate(req internal.ListShowtimesRequest) {
+	key := c.key(req)
+	c.cache.Remove(key)
+	if c.disk != nil {
+		c.disk.remove(c.descriptor, key)
+	}
+}
+
+func (c *cachingScraper) InvalidateAll() {
+	c.cache.Purge()
+	if c.disk != nil {
+		c.disk.removeAll(c.descriptor)
+	}
+}
+
+// lookup returns the cached entry for key from memory, else from the disk store (promoting it to memory).
+func (c *cachingScraper) lookup(key string) (cacheEntry, bool) {
+	if entry, ok := c.cache.Get(key); ok {
+		return entry, true
+	}
+	if c.disk == nil {
+		return cacheEntry{}, false
+	}
+	entry, ok := c.disk.load(c.descriptor, key)
+	if ok {
+		c.cache.Add(key, entry)
+	}
+	return entry, ok
+}
+
+// persist writes entry to the disk store, if any, and prunes expired entries. Entries without their
+// own expiry get one from the TTL, since the LRU's TTL does not carry over to the next process.
+func (c *cachingScraper) persist(key string, entry cacheEntry, now time.Time) {
+	if c.disk == nil {
+		return
+	}
+	if entry.expires.IsZero() && c.ttl > 0 {
+		entry.expires = now.Add(c.ttl)
+	}
+	if err := c.disk.store(c.descriptor, key, entry); err != nil {
+		slog.Warn("scrape cache: could not persist results", "scraper", c.descriptor, "error", err)
+	}
+	cutoff := now
+	if c.schedule != nil {
+		// Off-days serve stale entries, so keep them around a while past expiry.
+		cutoff = now.Add(-staleDiskEntryGrace)
+	}
+	c.disk.prune(c.descriptor, cutoff)
+}
+
+// staleDiskEntryGrace is how long past expiry a scheduled scraper's disk entries are kept for
+// off-schedule reads before pruning.
+const staleDiskEntryGrace = 7 * 24 * time.Hour
+
 func (c *cachingScraper) ScrapeShowtimes(ctx context.Context, req internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
-	key := c.descriptor + ":" + cacheKey(req)
-	if list, ok := c.cache.Get(key); ok {
-		ch := make(chan internal.ShowtimeListItem, len(list))
-		for _, item := range list {
-			ch <- item
+	key := c.key(req)
+	now := c.now()
+	if entry, ok := c.lookup(key); ok {
+		if c.schedule != nil && !c.schedule(now) {
+			// Off-schedule: the site isn't expected to have changed, so serve what we have.
+			return entry.replay(req), nil
+		}
+		if !req.Refresh && (entry.expires.IsZero() || now.Before(entry.expires)) {
+			return entry.replay(req), nil
 		}
-		close(ch)
-		return ch, nil
 	}
-	ch, err := c.inner.ScrapeShowtimes(ctx, req)
-	if err != nil {
+	if err := ctx.Err(); err != nil {
+		// The scrape would outlive this caller; don't start one nobody is waiting for.
 		return nil, err
 	}
-	// Drain and cache, then replay
-	var list []internal.ShowtimeListItem
+	// Concurrent misses for the same key share one scrape instead of stampeding the site.
+	shared := c.join(ctx, key, req)
+	select {
+	case <-shared.done:
+	case <-ctx.Done():
+		// Out of time (deadline, interrupt): serve what has arrived and leave the scrape running
+		// for the other callers and the cache.
+		req.ReportIncomplete()
+		return shared.snapshot().replay(req), nil
+	}
+	if shared.err != nil {
+		return nil, shared.err
+	}
+	if shared.incomplete {
+		req.ReportIncomplete()
+	}
+	return shared.entry.replay(req), nil
+}
+
+// sharedScrapeTimeout bounds a shared scrape, which no single caller's context can cancel.
+const sharedScrapeTimeout = 10 * time.Minute
+
+// sharedScrape is one in-flight scrape that every concurrent miss for its key waits on. Its fields
+// are guarded by mu until done is closed, after which entry, incomplete, and err are final.
+type sharedScrape struct {
+	done       chan struct{}
+	mu         sync.Mutex
+	items      []internal.ShowtimeListItem
+	skips      []skipReport
+	entry      cacheEntry
+	incomplete bool
+	err        error
+}
+
+// snapshot returns what the scrape has produced so far.
+func (s *sharedScrape) snapshot() cacheEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cacheEntry{items: slices.Clone(s.items), skips: slices.Clone(s.skips)}
+}
+
+// join returns the in-flight scrape for key, starting one if there is none.
+func (c *cachingScraper) join(ctx context.Context, key string, req internal.ListShowtimesRequest) *sharedScrape {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	if shared, ok := c.inflight[key]; ok {
+		return shared
+	}
+	shared := &sharedScrape{done: make(chan struct{})}
+	c.inflight[key] = shared
+	go c.run(ctx, key, req, shared)
+	return shared
+}
+
+// run scrapes into shared on a context detached from the caller that started it, so one caller
+// giving up doesn't cut the others short, and caches the result unless it came back incomplete.
+func (c *cachingScraper) run(ctx context.Context, key string, req internal.ListShowtimesRequest, shared *sharedScrape) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedScrapeTimeout)
+	defer cancel()
+	defer func() {
+		c.inflightMu.Lock()
+		delete(c.inflight, key)
+		c.inflightMu.Unlock()
+		close(shared.done)
+	}()
+	innerReq := req
+	innerReq.Skips = func(site proto.PdxSite, counts internal.SkipCounts) {
+		shared.mu.Lock()
+		defer shared.mu.Unlock()
+		shared.skips = append(shared.skips, skipReport{site: site, skips: counts})
+	}
+	innerReq.Incomplete = func() {
+		shared.mu.Lock()
+		defer shared.mu.Unlock()
+		shared.incomplete = true
+	}
+	// A panic before the stream is returned ends this scrape, not the process.
+	defer recoverScrape(c.descriptor, innerReq)
+
+	ch, err := c.inner.ScrapeShowtimes(ctx, innerReq)
+	if err != nil {
+		shared.err = err
+		return
+	}
+	// Drain and cache; callers replay the entry
 	for item := range ch {
-		list = append(list, item)
+		shared.mu.Lock()
+		shared.items = append(shared.items, item)
+		shared.mu.Unlock()
+	}
+	scraped := c.now()
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	shared.entry = c.newEntry(shared.items, scraped)
+	shared.entry.skips = shared.skips
+	if ctx.Err() != nil || shared.incomplete {
+		// Cut short (timeout or panic): serve what arrived but don't let a partial listing stand
+		// in for the full one on later requests.
+		shared.incomplete = true
+		slog.Debug("scrape cache: not caching incomplete results", "scraper", c.descriptor, "items", len(shared.items))
+		return
 	}
-	c.cache.Add(key, list)
-	out := make(chan internal.ShowtimeListItem, len(list))
-	for _, item := range list {
+	c.cache.Add(key, shared.entry)
+	c.persist(key, shared.entry, scraped)
+}
+
+// replay returns a closed channel buffered with items.
+func replay(items []internal.ShowtimeListItem) <-chan internal.ShowtimeListItem {
+	out := make(chan internal.ShowtimeListItem, len(items))
+	for _, item := range items {
 		out <- item
 	}
 	close(out)
-	return out, nil
+	return out
 }
@@ -0,0 +1,319 @@
+package scraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Cached_JitteredTTL(t *testing.T) {
+	ttl := 5 * time.Minute
+	c, ok := newCachingScraper(&mockScraper{descriptor: "A"}, 64, ttl, CacheWithJitter(0.1)).(*cachingScraper)
+	require.True(t, ok)
+
+	now := time.Now()
+	expiries := make(map[time.Time]struct{})
+	for range 20 {
+		entry := c.newEntry(nil, now)
+		require.False(t, entry.expires.Before(now.Add(ttl*9/10)), "expiry below -10%")
+		require.False(t, entry.expires.After(now.Add(ttl*11/10)), "expiry above +10%")
+		expiries[entry.expires] = struct{}{}
+	}
+	require.Greater(t, len(expiries), 1, "entries filled together should not all expire at the same instant")
+}
+
+func TestUnit_Cached_NoJitterUsesFixedTTL(t *testing.T) {
+	c, ok := newCachingScraper(&mockScraper{descriptor: "A"}, 64, 5*time.Minute).(*cachingScraper)
+	require.True(t, ok)
+	require.True(t, c.newEntry(nil, time.Now()).expires.IsZero(), "without jitter the LRU TTL alone applies")
+}
+
+// countingScraper returns a single item whose ID records how many times it was scraped.
+type countingScraper struct {
+	internal.NoCapabilities
+	calls int
+}
+
+func (s *countingScraper) Descriptor() string { return "counting" }
+
+func (s *countingScraper) ScrapeShowtimes(context.Context, internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	s.calls++
+	ch := make(chan internal.ShowtimeListItem, 1)
+	ch <- internal.ShowtimeListItem{Showtime: internal.SourceShowtime{ID: strconv.Itoa(s.calls)}}
+	close(ch)
+	return ch, nil
+}
+
+func TestUnit_Cached_RefreshReplacesEntry(t *testing.T) {
+	inner := &countingScraper{}
+	c := newCachingScraper(inner, 64, time.Hour)
+	scrape := func(req internal.ListShowtimesRequest) string {
+		ch, err := c.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err)
+		item := <-ch
+		return item.Showtime.ID
+	}
+
+	require.Equal(t, "1", scrape(internal.ListShowtimesRequest{}))
+	require.Equal(t, "1", scrape(internal.ListShowtimesRequest{}), "second query should hit the cache")
+	require.Equal(t, "2", scrape(internal.ListShowtimesRequest{Refresh: true}), "refresh should bypass the cache")
+	require.Equal(t, "2", scrape(internal.ListShowtimesRequest{}), "refresh should replace the cached entry")
+	require.Equal(t, 2, inner.calls)
+}
+
+func TestUnit_Cached_Invalidate(t *testing.T) {
+	inner := &countingScraper{}
+	registry := NewRegistry(WithScraperForSite(proto.PdxSite_Cinema21, inner, Cached(64, time.Hour)))
+	c, err := registry.GetScraper(SiteDescriptor(proto.PdxSite_Cinema21))
+	require.NoError(t, err)
+	inv, ok := c.(internal.Invalidator)
+	require.True(t, ok, "the caching scraper should be an Invalidator")
+	scrape := func(req internal.ListShowtimesRequest) string {
+		ch, err := c.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err)
+		item := <-ch
+		return item.Showtime.ID
+	}
+	week := internal.ListShowtimesRequest{Limit: 7}
+	month := internal.ListShowtimesRequest{Limit: 30}
+
+	require.Equal(t, "1", scrape(week))
+	require.Equal(t, "2", scrape(month))
+
+	inv.Invalidate(week)
+	require.Equal(t, "3", scrape(week), "invalidated request should re-run the inner scraper")
+	require.Equal(t, "2", scrape(month), "other requests stay cached")
+
+	registry.InvalidateAll()
+	require.Equal(t, "4", scrape(month), "after InvalidateAll the next scrape re-runs the inner scraper")
+	require.Equal(t, 4, inner.calls)
+}
+
+// blockingScraper counts scrapes and holds each one open until release is closed.
+type blockingScraper struct {
+	internal.NoCapabilities
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingScraper) Descriptor() string { return "blocking" }
+
+func (s *blockingScraper) ScrapeShowtimes(context.Context, internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+	}
+	<-s.release
+	ch := make(chan internal.ShowtimeListItem, 1)
+	ch <- internal.ShowtimeListItem{Showtime: internal.SourceShowtime{ID: "shared"}}
+	close(ch)
+	return ch, nil
+}
+
+func TestUnit_Cached_ConcurrentMissesShareOneScrape(t *testing.T) {
+	inner := &blockingScraper{started: make(chan struct{}), release: make(chan struct{})}
+	c := newCachingScraper(inner, 64, time.Hour)
+
+	var wg sync.WaitGroup
+	results := make([]string, 2)
+	for i := range results {
+		wg.Go(func() {
+			ch, err := c.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{})
+			assert.NoError(t, err)
+			for item := range ch {
+				results[i] = item.Showtime.ID
+			}
+		})
+	}
+	<-inner.started
+	time.Sleep(50 * time.Millisecond) // let the second request join the in-flight scrape
+	close(inner.release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), inner.calls.Load(), "identical concurrent requests should share one scrape")
+	require.Equal(t, []string{"shared", "shared"}, results)
+}
+
+func TestUnit_CachedWithSchedule_OffDayServesCache(t *testing.T) {
+	inner := &countingScraper{}
+	// 2026-02-03 is a Tuesday; 2026-02-05 a Thursday.
+	now := time.Date(2026, 2, 3, 9, 0, 0, 0, portlandTZ)
+	c := CachedWithSchedule(64, time.Hour, ScheduleDays(time.Tuesday, time.Friday), CacheWithClock(func() time.Time { return now }))(inner)
+	scrape := func(req internal.ListShowtimesRequest) string {
+		ch, err := c.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err)
+		item := <-ch
+		return item.Showtime.ID
+	}
+
+	require.Equal(t, "1", scrape(internal.ListShowtimesRequest{}), "scheduled day scrapes live")
+
+	now = now.Add(48 * time.Hour)
+	require.Equal(t, "1", scrape(internal.ListShowtimesRequest{}), "off-day serves the cache past its ttl")
+	require.Equal(t, "1", scrape(internal.ListShowtimesRequest{Refresh: true}), "off-day serves the cache even on refresh")
+	require.Equal(t, 1, inner.calls)
+
+	require.Equal(t, "2", scrape(internal.ListShowtimesRequest{Limit: 5}), "nothing cached still scrapes")
+
+	now = now.Add(24 * time.Hour)
+	require.Equal(t, "3", scrape(internal.ListShowtimesRequest{}), "expired entry is refreshed on a scheduled day")
+}
+
+func TestUnit_Cached_DiskStore(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	req := internal.ListShowtimesRequest{After: now, Before: now.Add(24 * time.Hour)}
+	scrape := func(c internal.Scraper) string {
+		t.Helper()
+		ch, err := c.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err)
+		item := <-ch
+		return item.Showtime.ID
+	}
+	files := func() []string {
+		t.Helper()
+		paths, err := filepath.Glob(filepath.Join(dir, "*"))
+		require.NoError(t, err)
+		return paths
+	}
+
+	inner := &countingScraper{}
+	require.Equal(t, "1", scrape(newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock))))
+	require.Len(t, files(), 1, "one entry, and no leftover temp file")
+
+	t.Run("a new process reads the entry back", func(t *testing.T) {
+		c := newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock))
+		require.Equal(t, "1", scrape(c))
+		require.Equal(t, 1, inner.calls, "served from disk")
+	})
+
+	t.Run("expired entries are ignored", func(t *testing.T) {
+		later := func() time.Time { return now.Add(6 * time.Minute) }
+		c := newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(later))
+		require.Equal(t, "2", scrape(c))
+	})
+
+	t.Run("corrupt entries are ignored", func(t *testing.T) {
+		paths := files()
+		require.Len(t, paths, 1)
+		require.NoError(t, os.WriteFile(paths[0], []byte(`{"items": [`), 0o600))
+		c := newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock))
+		require.Equal(t, "3", scrape(c))
+		require.Equal(t, "3", scrape(newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock))), "rewritten after the scrape")
+	})
+
+	t.Run("InvalidateAll removes the scraper's files", func(t *testing.T) {
+		other := newCachingScraper(&mockScraper{descriptor: "other"}, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock))
+		_, err := other.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err)
+		require.Len(t, files(), 2)
+
+		c, ok := newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock)).(*cachingScraper)
+		require.True(t, ok)
+		c.InvalidateAll()
+		require.Len(t, files(), 1, "only the other scraper's entry remains")
+	})
+
+	t.Run("expired entries are pruned on store", func(t *testing.T) {
+		c := newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(clock))
+		scrape(c)
+		require.Len(t, files(), 2)
+
+		// Like --upcoming: each run asks with a new after, so old keys are never read again.
+		later := now.Add(6 * time.Minute)
+		c = newCachingScraper(inner, 64, 5*time.Minute, CachedWithDiskStore(dir), CacheWithClock(func() time.Time { return later }))
+		_, err := c.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{After: later, Before: later.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, files(), 2, "the new entry replaces the expired one; the other scraper's is untouched")
+	})
+}
+
+func TestUnit_Cached_LeaderCancelDoesNotCutWaiters(t *testing.T) {
+	inner := &blockingScraper{started: make(chan struct{}), release: make(chan struct{})}
+	c, ok := newCachingScraper(inner, 64, time.Hour).(*cachingScraper)
+	require.True(t, ok)
+
+	leaderCtx, cancel := context.WithCancel(t.Context())
+	var leaderIncomplete bool
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		ch, err := c.ScrapeShowtimes(leaderCtx, internal.ListShowtimesRequest{Incomplete: func() { leaderIncomplete = true }})
+		assert.NoError(t, err)
+		for range ch {
+		}
+	}()
+	<-inner.started
+
+	var waiterIncomplete bool
+	var ids []string
+	waiterDone := make(chan struct{})
+	go func() {
+		defer close(waiterDone)
+		ch, err := c.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{Incomplete: func() { waiterIncomplete = true }})
+		assert.NoError(t, err)
+		for item := range ch {
+			ids = append(ids, item.Showtime.ID)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond) // let the waiter join the in-flight scrape
+
+	cancel()
+	<-leaderDone
+	require.True(t, leaderIncomplete, "the leader gave up, so its results are partial")
+
+	close(inner.release)
+	<-waiterDone
+	require.Equal(t, int32(1), inner.calls.Load())
+	require.Equal(t, []string{"shared"}, ids, "the waiter still gets the full result")
+	require.False(t, waiterIncomplete)
+	require.Equal(t, 1, c.cache.Len(), "the full result is cached")
+}
+
+func TestUnit_Cached_SkipsIncompleteScrapes(t *testing.T) {
+	base := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	items := []internal.ShowtimeListItem{{Showtime: internal.SourceShowtime{ID: "a", StartTime: base}}}
+	count := func(ch <-chan internal.ShowtimeListItem) int {
+		n := 0
+		for range ch {
+			n++
+		}
+		return n
+	}
+
+	t.Run("deadline", func(t *testing.T) {
+		dir := t.TempDir()
+		inner := &stallingScraper{mockScraper{descriptor: "slow", items: items}}
+		c := UntilDeadline(newCachingScraper(inner, 64, time.Hour, CachedWithDiskStore(dir)))
+		ch, err := c.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{Deadline: time.Now().Add(50 * time.Millisecond)})
+		require.NoError(t, err)
+		require.Equal(t, 1, count(ch), "what arrived before the deadline is still served")
+		paths, err := filepath.Glob(filepath.Join(dir, "*"))
+		require.NoError(t, err)
+		require.Empty(t, paths, "the truncated listing is not persisted")
+		require.Zero(t, c.(*deadlineScraper).inner.(*cachingScraper).cache.Len(), "nor kept in memory")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		inner := &panickingScraper{mockScraper: mockScraper{descriptor: "mid", items: items}}
+		c, ok := newCachingScraper(inner, 64, time.Hour).(*cachingScraper)
+		require.True(t, ok)
+		var reported bool
+		ch, err := c.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{Incomplete: func() { reported = true }})
+		require.NoError(t, err)
+		require.Equal(t, 1, count(ch))
+		require.True(t, reported, "the caller hears about the incomplete scrape too")
+		require.Zero(t, c.cache.Len())
+	})
+}
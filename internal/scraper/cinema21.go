@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
@@ -28,6 +30,16 @@ type cinema21Scraper struct {
 	uuidNamespace   uuid.UUID
 	httpClient      *http.Client
 	headlessBrowser browser.Interface
+
+	mu             sync.Mutex
+	lastPlayingNow *cinema21PlayingNow // last 200 response with validators, for conditional requests
+}
+
+// cinema21PlayingNow is a parsed playing-now payload with the validators needed to revalidate it.
+type cinema21PlayingNow struct {
+	etag         string
+	lastModified string
+	movies       []cinema21Movie
 }
 
 // Cinema21Option applies configuration to a Cinema 21 scraper.
@@ -72,7 +84,7 @@ func Cinema21(opts ...Cinema21Option) internal.Scraper {
 	s.uuidNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte(s.baseURL))
 	// Cinema 21's API is accessible via plain HTTP — no browser needed by default.
 	if s.headlessBrowser == nil && s.httpClient == nil {
-		s.httpClient = http.DefaultClient
+		s.httpClient = DefaultHTTPClient()
 	}
 	return s
 }
@@ -80,19 +92,25 @@ func Cinema21(opts ...Cinema21Option) internal.Scraper {
 const (
 	defaultCinema21BaseURL = "https://www.cinema21.com"
 	cinema21Location       = "Cinema 21, 616 NW 21st Ave, Portland, Oregon, 97209"
+	cinema21VenueName      = "Cinema 21"
 )
 
-var cinema21Descriptor = proto.PdxSite_Cinema21.Descriptor().Syntax().GoString()
+var cinema21Descriptor = SiteDescriptor(proto.PdxSite_Cinema21)
 
 func (s *cinema21Scraper) Descriptor() string {
 	return s.descriptor
 }
 
+func (s *cinema21Scraper) Capabilities() internal.Capabilities {
+	return internal.CapabilityDirector | internal.CapabilityRuntime | internal.CapabilityDescription | internal.CapabilityEndTime |
+		internal.CapabilityAdmission | internal.CapabilityAccessibility | internal.CapabilityTickets
+}
+
 func (s *cinema21Scraper) ScrapeShowtimes(
 	ctx context.Context,
 	listReq internal.ListShowtimesRequest,
 ) (<-chan internal.ShowtimeListItem, error) {
-	data, err := s.fetchPlayingNow(ctx)
+	movies, err := s.playingNow(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
@@ -100,7 +118,8 @@ func (s *cinema21Scraper) ScrapeShowtimes(
 	hits := make(chan internal.ShowtimeListItem)
 	go func() {
 		defer close(hits)
-		s.sendShowtimes(hits, data, listReq)
+		defer recoverScrape(s.Descriptor(), listReq)
+		s.sendShowtimes(hits, movies, listReq)
 	}()
 
 	return hits, nil
@@ -123,9 +142,16 @@ func (s *cinema21Scraper) MountGolden(_ context.Context, goldenDir string) (http
 		return nil, fmt.Errorf("failed to read playing-now golden file: %w", err)
 	}
 
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(playingNow))
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		if r.URL.Path == "/api/movie/playing-now" && r.Method == http.MethodGet {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
 			_, _ = w.Write(playingNow)
 			return
 		}
@@ -147,6 +173,70 @@ func (s *cinema21Scraper) fetchPlayingNow(ctx context.Context) ([]byte, error) {
 	return s.fetchPlayingNowViaHeadlessBrowser(ctx)
 }
 
+// playingNow returns the parsed playing-now payload. Over HTTP it revalidates the previous response
+// with If-None-Match/If-Modified-Since and reuses its parsed movies when upstream answers 304.
+func (s *cinema21Scraper) playingNow(ctx context.Context) ([]cinema21Movie, error) {
+	if s.httpClient == nil {
+		data, err := s.fetchPlayingNowViaHeadlessBrowser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return decodePlayingNow(data)
+	}
+
+	s.mu.Lock()
+	prev := s.lastPlayingNow
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.playingNowURL(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if prev != nil {
+		if prev.etag != "" {
+			req.Header.Set("If-None-Match", prev.etag)
+		}
+		if prev.lastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.lastModified)
+		}
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get playing-now: %w", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotModified && prev != nil {
+		slog.Debug("cinema21: playing-now not modified, reusing parsed payload", "etag", prev.etag)
+		return prev.movies, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", errHTTPRequestFailed, resp.Status)
+	}
+	movies, err := decodePlayingNow(body)
+	if err != nil {
+		return nil, err
+	}
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag != "" || lastModified != "" {
+		s.mu.Lock()
+		s.lastPlayingNow = &cinema21PlayingNow{etag: etag, lastModified: lastModified, movies: movies}
+		s.mu.Unlock()
+	}
+	return movies, nil
+}
+
+func decodePlayingNow(data []byte) ([]cinema21Movie, error) {
+	var movies []cinema21Movie
+	if err := json.Unmarshal(data, &movies); err != nil {
+		return nil, fmt.Errorf("unmarshal playing-now: %w", err)
+	}
+	return movies, nil
+}
+
 func (s *cinema21Scraper) fetchPlayingNowViaHTTP(ctx context.Context) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.playingNowURL(), nil)
 	if err != nil {
@@ -188,21 +278,14 @@ func (s *cinema21Scraper) fetchPlayingNowViaHeadlessBrowser(ctx context.Context)
 
 func (s *cinema21Scraper) sendShowtimes(
 	hits chan<- internal.ShowtimeListItem,
-	data []byte,
+	movies []cinema21Movie,
 	listReq internal.ListShowtimesRequest,
 ) {
-	var movies []cinema21Movie
-	if err := json.Unmarshal(data, &movies); err != nil {
-		slog.Warn("cinema21: failed to unmarshal playing-now", "error", err)
-		return
-	}
-
-	timeLayout := "3:04pm"
 	var items []internal.ShowtimeListItem
-	var skipped int
+	var skips internal.SkipCounts
 	for _, movie := range movies {
 		duration, _ := strconv.Atoi(movie.Duration)
-		runtimeHint := time.Duration(duration) * time.Minute
+		runtimeHint := internal.PlausibleRuntime(time.Duration(duration) * time.Minute)
 
 		var directorHint string
 		if movie.DirectorInfo != nil && len(movie.DirectorInfo.Director) > 0 {
@@ -212,12 +295,12 @@ func (s *cinema21Scraper) sendShowtimes(
 		for _, session := range movie.SessionTimes {
 			sessionDate, err := time.ParseInLocation(time.DateOnly, session.Date, portlandTZ)
 			if err != nil {
-				skipped++
+				skips.Parse++
 				continue
 			}
-			sessionTime, err := time.ParseInLocation(timeLayout, strings.ToLower(session.Time), portlandTZ)
+			sessionTime, err := parseClock(session.Time, portlandTZ)
 			if err != nil {
-				skipped++
+				skips.Parse++
 				continue
 			}
 			start := time.Date(
@@ -226,16 +309,16 @@ func (s *cinema21Scraper) sendShowtimes(
 			)
 
 			if !listReq.After.IsZero() && !start.After(listReq.After) {
-				skipped++
+				skips.After++
 				continue
 			}
 			if !listReq.Before.IsZero() && !start.Before(listReq.Before) {
-				skipped++
+				skips.Before++
 				continue
 			}
 
 			var endTime time.Time
-			if duration > 0 {
+			if runtimeHint > 0 {
 				endTime = start.Add(runtimeHint)
 			}
 
@@ -244,61 +327,51 @@ func (s *cinema21Scraper) sendShowtimes(
 				links = append(links, internal.Link{
 					Href:    s.baseURL + "/movie/" + movie.URL,
 					Display: "Info",
+					Rel:     internal.LinkRelInfo,
 				})
 			}
 			if session.BookingLink != "" {
 				links = append(links, internal.Link{
 					Href:    session.BookingLink,
 					Display: "Tickets",
+					Rel:     internal.LinkRelTickets,
 				})
 			}
 
-			items = append(items, internal.ShowtimeListItem{
-				Showtime: internal.SourceShowtime{
-					ID:          uuid.NewSHA1(s.uuidNamespace, []byte(session.ID)).String(),
-					Summary:     movie.Title,
-					Description: stripHTMLTags(movie.SynopsisShort),
-					StartTime:   start,
-					EndTime:     endTime,
-					Location:    cinema21Location,
-					Screening: internal.ScreeningInfo{
-						Title: movie.Title,
-						Links: links,
-					},
-					TitleHint:    movie.Title,
-					DirectorHint: directorHint,
-					RuntimeHint:  runtimeHint,
+			showtime := internal.SourceShowtime{
+				ID:          uuid.NewSHA1(s.uuidNamespace, []byte(session.ID)).String(),
+				Summary:     movie.Title,
+				Description: movie.SynopsisShort, // HTML; the service converts it to text (--strip-html)
+				StartTime:   start,
+				EndTime:     endTime,
+				Location:    cinema21Location,
+				VenueName:   cinema21VenueName,
+				Screening: internal.ScreeningInfo{
+					Title:         movie.Title,
+					Links:         links,
+					Accessibility: accessibilityFeatures(movie.Title),
 				},
-				Site: proto.PdxSite_Cinema21,
+				TitleHint:    movie.Title,
+				DirectorHint: directorHint,
+				Admission:    admission(append(session.attributeTexts(), movie.Title)...),
+			}
+			showtime.SetRuntimeHint(runtimeHint, internal.RuntimeSourceListing)
+			items = append(items, internal.ShowtimeListItem{
+				Showtime: showtime,
+				Site:     proto.PdxSite_Cinema21,
 			})
 		}
 	}
 
-	slices.SortFunc(items, func(a, b internal.ShowtimeListItem) int {
-		return a.Showtime.StartTime.Compare(b.Showtime.StartTime)
-	})
+	slices.SortFunc(items, compareItems)
 	for _, item := range items {
 		hits <- item
 	}
-	slog.Debug("cinema21: emitted showtimes", "sent", len(items), "skipped", skipped)
+	slog.Debug("cinema21: emitted showtimes", "sent", len(items), "skipped", skips.Total())
+	listReq.ReportSkips(proto.PdxSite_Cinema21, skips)
 }
 
-// stripHTMLTags removes HTML tags from a string for use as plain-text description.
-func stripHTMLTags(s string) string {
-	var b strings.Builder
-	inTag := false
-	for _, r := range s {
-		switch {
-		case r == '<':
-			inTag = true
-		case r == '>':
-			inTag = false
-		case !inTag:
-			b.WriteRune(r)
-		}
-	}
-	return strings.TrimSpace(b.String())
-}
+func ptr[T any](v T) *T { return &v }
 
 // cinema21Movie represents a movie from the /api/movie/playing-now response.
 type cinema21Movie struct {
@@ -308,7 +381,7 @@ type cinema21Movie struct {
 	Duration      string              `json:"duration"`
 	Classification string             `json:"classification"`
 	ReleaseDate   string              `json:"releaseDate"`
-	SynopsisShort string              `json:"synopsisShort"`
+	SynopsisShort *string             `json:"synopsisShort"`
 	Cast          []string            `json:"cast"`
 	DirectorInfo  *cinema21Director   `json:"director"`
 	SessionTimes  []cinema21Session   `json:"sessionTimes"`
@@ -322,9 +395,25 @@ type cinema21Director struct {
 
 // cinema21Session represents a single showtime session.
 type cinema21Session struct {
-	Date        string `json:"date"`
-	Time        string `json:"time"`
-	BookingLink string `json:"bookingLink"`
-	IsSoldOut   bool   `json:"isSoldOut"`
-	ID          string `json:"_id"`
+	Date        string              `json:"date"`
+	Time        string              `json:"time"`
+	BookingLink string              `json:"bookingLink"`
+	IsSoldOut   bool                `json:"isSoldOut"`
+	ID          string              `json:"_id"`
+	Attributes  []cinema21Attribute `json:"attributes"`
+}
+
+// cinema21Attribute is a per-session tag, e.g. {"shortName": "EARLY BIRD", "description": "special low price ..."}.
+type cinema21Attribute struct {
+	ShortName   string `json:"shortName"`
+	Description string `json:"description"`
+}
+
+// attributeTexts returns the names and descriptions of the session's attributes.
+func (s cinema21Session) attributeTexts() []string {
+	var texts []string
+	for _, attr := range s.Attributes {
+		texts = append(texts, attr.ShortName, attr.Description)
+	}
+	return texts
 }
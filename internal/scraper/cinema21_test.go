@@ -3,10 +3,16 @@ package scraper
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/httputil"
+	"github.com/drewfead/pdx-watcher/internal/scraper/scrapertest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,3 +51,139 @@ func TestUnit_Cinema21_ScrapeShowtimes(t *testing.T) {
 			"items[%d] should not be before items[%d]", i, i-1)
 	}
 }
+
+func TestUnit_Cinema21_LinkRels(t *testing.T) {
+	server := MountGoldenTestServer(t, "cinema21")
+	rels := goldenLinkRels(t, Cinema21(Cinema21WithBaseURL(server.URL), Cinema21WithClient(server.Client())))
+	require.Positive(t, rels[internal.LinkRelInfo], "movie pages")
+	require.Positive(t, rels[internal.LinkRelTickets], "booking links")
+	require.Subset(t, []string{internal.LinkRelInfo, internal.LinkRelTickets}, keys(rels))
+}
+
+func TestUnit_Cinema21_Capabilities(t *testing.T) {
+	caps := Cinema21().Capabilities()
+	assert.True(t, caps.Has(internal.CapabilityDirector), "director")
+	assert.True(t, caps.Has(internal.CapabilityRuntime), "runtime")
+}
+
+func TestUnit_Cinema21_LogsHTTP(t *testing.T) {
+	logs := scrapertest.CaptureLogs(t)
+
+	server := MountGoldenTestServer(t, "cinema21")
+	client := &http.Client{Transport: &httputil.LoggingTransport{Base: server.Client().Transport}}
+	s := Cinema21(Cinema21WithBaseURL(server.URL), Cinema21WithClient(client))
+
+	ch, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+	for range ch {
+	}
+
+	out := logs.String()
+	assert.Contains(t, out, "msg=\"http request\"")
+	assert.Contains(t, out, "method=GET")
+	assert.Contains(t, out, "/api/movie/playing-now")
+	assert.Contains(t, out, "status=200")
+	assert.Contains(t, out, "duration=")
+}
+
+func TestUnit_Cinema21_RevalidatesPlayingNow(t *testing.T) {
+	handler, err := Cinema21().(internal.GoldenScraper).MountGolden(t.Context(), filepath.Join(*goldenDir, "cinema21"))
+	require.NoError(t, err, "MountGolden")
+	var statuses []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		statuses = append(statuses, rec.Code)
+		maps.Copy(w.Header(), rec.Header())
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+	}))
+	t.Cleanup(server.Close)
+
+	s, ok := Cinema21(Cinema21WithBaseURL(server.URL), Cinema21WithClient(server.Client())).(*cinema21Scraper)
+	require.True(t, ok)
+	req := internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	scrape := func() []internal.ShowtimeListItem {
+		ch, err := s.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err, "ScrapeShowtimes")
+		var items []internal.ShowtimeListItem
+		for item := range ch {
+			items = append(items, item)
+		}
+		return items
+	}
+
+	first := scrape()
+	require.NotEmpty(t, first)
+	parsed := s.lastPlayingNow
+	require.NotNil(t, parsed, "validators should be stored after a 200")
+
+	second := scrape()
+	require.Equal(t, []int{http.StatusOK, http.StatusNotModified}, statuses)
+	require.Same(t, parsed, s.lastPlayingNow, "parsed payload should be reused on 304")
+	require.Equal(t, first, second)
+}
+
+func TestUnit_Cinema21_ImplausibleRuntimeIsUnknown(t *testing.T) {
+	s, ok := Cinema21().(*cinema21Scraper)
+	require.True(t, ok)
+	session := []cinema21Session{{Date: "2026-02-10", Time: "7:00pm", ID: "s"}}
+	movies := []cinema21Movie{
+		{Title: "Too Long", Duration: "9999", SessionTimes: session},
+		{Title: "Zero", Duration: "0", SessionTimes: session},
+		{Title: "Plausible", Duration: "101", SessionTimes: session},
+	}
+	hits := make(chan internal.ShowtimeListItem, len(movies))
+	s.sendShowtimes(hits, movies, internal.ListShowtimesRequest{})
+	close(hits)
+
+	byTitle := make(map[string]internal.SourceShowtime)
+	for item := range hits {
+		byTitle[item.Showtime.Summary] = item.Showtime
+	}
+	for _, title := range []string{"Too Long", "Zero"} {
+		assert.Zero(t, byTitle[title].RuntimeHint, title)
+		assert.True(t, byTitle[title].EndTime.IsZero(), "%s should have no computed end time", title)
+	}
+	assert.Equal(t, 101*time.Minute, byTitle["Plausible"].RuntimeHint)
+	assert.Equal(t, byTitle["Plausible"].StartTime.Add(101*time.Minute), byTitle["Plausible"].EndTime)
+}
+
+func TestUnit_Cinema21_SameTimeOrderIsStable(t *testing.T) {
+	s, ok := Cinema21().(*cinema21Scraper)
+	require.True(t, ok)
+	movies := []cinema21Movie{
+		{Title: "Heat", SessionTimes: []cinema21Session{{Date: "2026-02-10", Time: "7:00pm", ID: "s1"}}},
+		{Title: "Thief", SessionTimes: []cinema21Session{{Date: "2026-02-10", Time: "7:00pm", ID: "s2"}}},
+	}
+	scrape := func(movies []cinema21Movie) []string {
+		hits := make(chan internal.ShowtimeListItem, len(movies))
+		s.sendShowtimes(hits, movies, internal.ListShowtimesRequest{})
+		close(hits)
+		var ids []string
+		for item := range hits {
+			ids = append(ids, item.Showtime.ID)
+		}
+		return ids
+	}
+
+	want := scrape(movies)
+	require.Len(t, want, 2)
+	require.Less(t, want[0], want[1], "same-time showtimes should be ordered by ID")
+	for range 20 {
+		require.Equal(t, want, scrape(movies))
+		require.Equal(t, want, scrape([]cinema21Movie{movies[1], movies[0]}), "input order shouldn't matter")
+	}
+}
+
+func TestUnit_Cinema21_GoldenShowtimes(t *testing.T) {
+	requireGoldenShowtimes(t, "cinema21", func(baseURL string, client *http.Client) internal.Scraper {
+		return Cinema21(Cinema21WithBaseURL(baseURL), Cinema21WithClient(client))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ type cinemagicScraper struct {
 	uuidNamespace   uuid.UUID
 	httpClient      *http.Client
 	headlessBrowser browser.Interface
+	fallbackClient  *http.Client // used over HTTP when the browser can't launch; nil = no fallback
 }
 
 // CinemagicOption applies configuration to a Cinemagic scraper.
@@ -61,6 +63,16 @@ func CinemagicWithBrowser(b browser.Interface) CinemagicOption {
 	}
 }
 
+// CinemagicWithFallbackClient sets the HTTP client used when the browser fails to launch or is
+// disabled. Without one, the browser's error is returned.
+func CinemagicWithFallbackClient(client *http.Client) CinemagicOption {
+	return func(s *cinemagicScraper) {
+		if client != nil {
+			s.fallbackClient = client
+		}
+	}
+}
+
 func Cinemagic(opts ...CinemagicOption) internal.Scraper {
 	s := &cinemagicScraper{
 		baseURL:    defaultCinemagicBaseURL,
@@ -79,12 +91,13 @@ func Cinemagic(opts ...CinemagicOption) internal.Scraper {
 const (
 	defaultCinemagicBaseURL = "https://tickets.thecinemagictheater.com"
 	cinemagicLocation       = "Cinemagic, 2021 SE Hawthorne Blvd, Portland, Oregon, 97214"
+	cinemagicVenueName      = "Cinemagic"
 	cinemagicSiteIDInt      = 40
 	cinemagicCircuitID      = "39"
 	cinemagicSiteID         = "40"
 )
 
-var cinemagicDescriptor = proto.PdxSite_Cinemagic.Descriptor().Syntax().GoString()
+var cinemagicDescriptor = SiteDescriptor(proto.PdxSite_Cinemagic)
 
 var (
 	errGraphQLRequestFailed          = errors.New("graphql request failed")
@@ -125,6 +138,7 @@ const cinemagicShowingsQuery = `query ($date: String, $siteIds: [ID]) {
 
 // fetchPostJSONScript sends a POST GraphQL request from the page context with the required
 // INDY Cinema Group headers (circuit-id, site-id, client-type). Without these the API returns 403.
+// It resolves to a browser.FetchResponse.
 const fetchPostJSONScript = `(url, body, circuitID, siteID) => {
 	return fetch(url, {
 		method: 'POST',
@@ -137,10 +151,7 @@ const fetchPostJSONScript = `(url, body, circuitID, siteID) => {
 		},
 		credentials: 'include',
 		body: body
-	}).then(r => {
-		if (!r.ok) throw new Error('HTTP ' + r.status);
-		return r.json();
-	}).then(obj => JSON.stringify(obj));
+	}).then(r => r.text().then(body => ({status: r.status, retryAfter: r.headers.get('Retry-After') || '', body: body})));
 }`
 
 // waitForCookieScript polls document.cookie until the target cookie name appears (max ~10s).
@@ -165,6 +176,11 @@ func (s *cinemagicScraper) Descriptor() string {
 	return s.descriptor
 }
 
+func (s *cinemagicScraper) Capabilities() internal.Capabilities {
+	return internal.CapabilityDirector | internal.CapabilityRuntime | internal.CapabilityDescription | internal.CapabilityEndTime |
+		internal.CapabilityAdmission | internal.CapabilityAccessibility | internal.CapabilityTickets
+}
+
 func (s *cinemagicScraper) ScrapeShowtimes(
 	ctx context.Context,
 	listReq internal.ListShowtimesRequest,
@@ -177,6 +193,7 @@ func (s *cinemagicScraper) ScrapeShowtimes(
 	hits := make(chan internal.ShowtimeListItem)
 	go func() {
 		defer close(hits)
+		defer recoverScrape(s.Descriptor(), listReq)
 		s.sendShowtimes(hits, allJSON, listReq)
 	}()
 
@@ -185,7 +202,7 @@ func (s *cinemagicScraper) ScrapeShowtimes(
 
 // PullGolden fetches showings for 7 days starting today, saving dates.json and {date}.json per date.
 func (s *cinemagicScraper) PullGolden(ctx context.Context, goldenDir string) error {
-	now := time.Now().In(portlandTZ)
+	now := nowFunc().In(portlandTZ)
 	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, portlandTZ)
 	end := start.AddDate(0, 0, 7)
 
@@ -269,9 +286,14 @@ func (s *cinemagicScraper) MountGolden(_ context.Context, goldenDir string) (htt
 // Returns the raw datesWithShowing response and a map of date→showings response.
 func (s *cinemagicScraper) fetchShowings(ctx context.Context, listReq internal.ListShowtimesRequest) ([]byte, map[string][]byte, error) {
 	if s.httpClient != nil {
-		return s.fetchShowingsViaHTTP(ctx, listReq)
+		return s.fetchShowingsViaHTTP(ctx, s.httpClient, listReq)
 	}
-	return s.fetchShowingsViaHeadlessBrowser(ctx, listReq)
+	dates, results, err := s.fetchShowingsViaHeadlessBrowser(ctx, listReq)
+	if errors.Is(err, browser.ErrUnavailable) && s.fallbackClient != nil {
+		slog.Warn("cinemagic: browser unavailable, falling back to HTTP", "error", err)
+		return s.fetchShowingsViaHTTP(ctx, s.fallbackClient, listReq)
+	}
+	return dates, results, err
 }
 
 func (s *cinemagicScraper) datesRequestBody() ([]byte, error) {
@@ -326,8 +348,8 @@ func filterDatesToRange(dates []string, listReq internal.ListShowtimesRequest) [
 	return filtered
 }
 
-// postGraphQL sends a GraphQL request via httpClient and returns the response body.
-func (s *cinemagicScraper) postGraphQL(ctx context.Context, body []byte) ([]byte, error) {
+// postGraphQL sends a GraphQL request with client and returns the response body.
+func (s *cinemagicScraper) postGraphQL(ctx context.Context, client *http.Client, body []byte) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.graphqlURL(), strings.NewReader(string(body)))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
@@ -337,7 +359,7 @@ func (s *cinemagicScraper) postGraphQL(ctx context.Context, body []byte) ([]byte
 	req.Header.Set("Site-Id", cinemagicSiteID)
 	req.Header.Set("Client-Type", "consumer")
 	req.Header.Set("Is-Electron-Mode", "false")
-	resp, err := s.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("post: %w", err)
 	}
@@ -352,18 +374,25 @@ func (s *cinemagicScraper) postGraphQL(ctx context.Context, body []byte) ([]byte
 	return respBody, nil
 }
 
-// evalGraphQL executes a GraphQL POST from within the page context via fetch().
-func evalGraphQL(ctx context.Context, page *rod.Page, gqlURL string, body []byte) ([]byte, error) {
-	result, err := page.Context(ctx).Timeout(browser.PageStableTimeout).Eval(
-		fetchPostJSONScript, gqlURL, string(body), cinemagicCircuitID, cinemagicSiteID,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return []byte(result.Value.Str()), nil
+// evalGraphQL executes a GraphQL POST from within the page context via fetch(), retrying failures
+// per the browser's retry policy (see browser.WithRetries).
+func evalGraphQL(ctx context.Context, retry browser.RetryPolicy, page *rod.Page, gqlURL string, body []byte) ([]byte, error) {
+	var raw []byte
+	err := retry.Do(ctx, gqlURL, func() error {
+		resp, err := browser.EvalFetch(ctx, page, gqlURL,
+			fetchPostJSONScript, gqlURL, string(body), cinemagicCircuitID, cinemagicSiteID,
+		)
+		if err != nil {
+			return err
+		}
+		raw = []byte(resp)
+		return nil
+	})
+	return raw, err
 }
 
 // parseDatesResponse extracts date strings from a datesWithShowing GraphQL response.
+// The dates are returned sorted and de-duplicated so each date is fetched once, in order.
 // The API returns {data: {datesWithShowing: {value: "[\"2026-02-20\",...]", resultVersion: "..."}}}
 // where the value field is a JSON-encoded string array.
 func parseDatesResponse(body []byte) ([]string, error) {
@@ -381,16 +410,17 @@ func parseDatesResponse(body []byte) ([]string, error) {
 	if err := json.Unmarshal([]byte(resp.Data.DatesWithShowing.Value), &dates); err != nil {
 		return nil, fmt.Errorf("%w: %s", errUnexpectedDatesResponseFormat, resp.Data.DatesWithShowing.Value)
 	}
-	return dates, nil
+	slices.Sort(dates)
+	return slices.Compact(dates), nil
 }
 
-func (s *cinemagicScraper) fetchShowingsViaHTTP(ctx context.Context, listReq internal.ListShowtimesRequest) ([]byte, map[string][]byte, error) {
+func (s *cinemagicScraper) fetchShowingsViaHTTP(ctx context.Context, client *http.Client, listReq internal.ListShowtimesRequest) ([]byte, map[string][]byte, error) {
 	// 1. Discover available dates.
 	datesBody, err := s.datesRequestBody()
 	if err != nil {
 		return nil, nil, fmt.Errorf("marshal datesWithShowing: %w", err)
 	}
-	datesResp, err := s.postGraphQL(ctx, datesBody)
+	datesResp, err := s.postGraphQL(ctx, client, datesBody)
 	if err != nil {
 		return nil, nil, fmt.Errorf("fetch datesWithShowing: %w", err)
 	}
@@ -403,16 +433,17 @@ func (s *cinemagicScraper) fetchShowingsViaHTTP(ctx context.Context, listReq int
 
 	// 2. Fetch showings for each date.
 	results := make(map[string][]byte, len(dates))
-	for _, date := range dates {
+	for i, date := range dates {
 		body, err := s.showingsRequestBody(date)
 		if err != nil {
 			return nil, nil, fmt.Errorf("marshal showingsForDate %s: %w", date, err)
 		}
-		resp, err := s.postGraphQL(ctx, body)
+		resp, err := s.postGraphQL(ctx, client, body)
 		if err != nil {
 			return nil, nil, fmt.Errorf("fetch showingsForDate %s: %w", date, err)
 		}
 		results[date] = resp
+		listReq.ReportProgress(internal.Progress{Site: proto.PdxSite_Cinemagic, Unit: "dates", Done: i + 1, Total: len(dates)})
 	}
 	return datesResp, results, nil
 }
@@ -424,6 +455,7 @@ func (s *cinemagicScraper) fetchShowingsViaHeadlessBrowser(ctx context.Context,
 	)
 	homeURL := s.baseURL + "/"
 	gqlURL := s.graphqlURL()
+	retry := browser.RetryPolicyOf(s.headlessBrowser)
 
 	err := s.headlessBrowser.WithPage(ctx, homeURL, func(page *rod.Page) error {
 		// Wait for the Ahoy visit cookie — set by the SPA's JS after full initialization.
@@ -436,7 +468,7 @@ func (s *cinemagicScraper) fetchShowingsViaHeadlessBrowser(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("marshal datesWithShowing: %w", err)
 		}
-		datesResp, err := evalGraphQL(ctx, page, gqlURL, datesBody)
+		datesResp, err := evalGraphQL(ctx, retry, page, gqlURL, datesBody)
 		if err != nil {
 			return fmt.Errorf("fetch datesWithShowing: %w", err)
 		}
@@ -450,16 +482,17 @@ func (s *cinemagicScraper) fetchShowingsViaHeadlessBrowser(ctx context.Context,
 
 		// 2. Fetch showings for each date.
 		results = make(map[string][]byte, len(dates))
-		for _, date := range dates {
+		for i, date := range dates {
 			body, err := s.showingsRequestBody(date)
 			if err != nil {
 				return fmt.Errorf("marshal showingsForDate %s: %w", date, err)
 			}
-			resp, err := evalGraphQL(ctx, page, gqlURL, body)
+			resp, err := evalGraphQL(ctx, retry, page, gqlURL, body)
 			if err != nil {
 				return fmt.Errorf("fetch showingsForDate %s: %w", date, err)
 			}
 			results[date] = resp
+			listReq.ReportProgress(internal.Progress{Site: proto.PdxSite_Cinemagic, Unit: "dates", Done: i + 1, Total: len(dates)})
 		}
 		return nil
 	})
@@ -469,13 +502,31 @@ func (s *cinemagicScraper) fetchShowingsViaHeadlessBrowser(ctx context.Context,
 	return rawDatesResp, results, nil
 }
 
+// cinemagicNaiveLayouts are the offset-less forms some showing feeds use; they are Portland wall-clock times.
+var cinemagicNaiveLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// parseCinemagicTime parses a showing's time: RFC3339 with "Z" or a numeric offset (fractional
+// seconds allowed), or a naive local time taken as Portland time.
+func parseCinemagicTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range cinemagicNaiveLayouts {
+		if t, err := time.ParseInLocation(layout, s, portlandTZ); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized showing time %q", s)
+}
+
 func (s *cinemagicScraper) sendShowtimes(
 	hits chan<- internal.ShowtimeListItem,
 	allJSON map[string][]byte,
 	listReq internal.ListShowtimesRequest,
 ) {
 	var items []internal.ShowtimeListItem
-	var skipped int
+	var skips internal.SkipCounts
 	for dateStr, body := range allJSON {
 		var resp cinemagicGraphQLResponse
 		if err := json.Unmarshal(body, &resp); err != nil {
@@ -486,23 +537,31 @@ func (s *cinemagicScraper) sendShowtimes(
 			if !showing.Published {
 				continue
 			}
-			startTime, err := time.Parse(time.RFC3339, showing.Time)
+			if strings.TrimSpace(showing.Movie.Name) == "" {
+				// Upstream data glitch: without a title the showtime has no summary to show.
+				slog.Debug("cinemagic: skipping showing without a movie name", "date", dateStr, "showing_id", showing.ID)
+				skips.Parse++
+				continue
+			}
+			startTime, err := parseCinemagicTime(showing.Time)
 			if err != nil {
-				skipped++
+				slog.Debug("cinemagic: skipping showing with unparseable time", "date", dateStr, "showing_id", showing.ID, "error", err)
+				skips.Parse++
 				continue
 			}
 			if !listReq.After.IsZero() && !startTime.After(listReq.After) {
-				skipped++
+				skips.After++
 				continue
 			}
 			if !listReq.Before.IsZero() && !startTime.Before(listReq.Before) {
-				skipped++
+				skips.Before++
 				continue
 			}
 
+			runtime := internal.PlausibleRuntime(time.Duration(showing.Movie.Duration) * time.Minute)
 			var endTime time.Time
-			if showing.Movie.Duration > 0 {
-				endTime = startTime.Add(time.Duration(showing.Movie.Duration) * time.Minute)
+			if runtime > 0 {
+				endTime = startTime.Add(runtime)
 			}
 
 			var subhed string
@@ -518,38 +577,43 @@ func (s *cinemagicScraper) sendShowtimes(
 				links = append(links, internal.Link{
 					Href:    s.baseURL + "/movie/" + showing.Movie.URLSlug,
 					Display: "Tickets",
+					Rel:     internal.LinkRelTickets,
 				})
 			}
 
-			items = append(items, internal.ShowtimeListItem{
-				Showtime: internal.SourceShowtime{
-					ID:          uuid.NewSHA1(s.uuidNamespace, []byte(showing.ID)).String(),
-					Summary:     showing.Movie.Name,
-					Description: showing.Movie.Synopsis,
-					StartTime:   startTime,
-					EndTime:     endTime,
-					Location:    cinemagicLocation,
-					Screening: internal.ScreeningInfo{
-						Title:  showing.Movie.Name,
-						Subhed: subhed,
-						Links:  links,
-					},
-					TitleHint:    showing.Movie.Name,
-					DirectorHint: showing.Movie.DirectedBy,
-					RuntimeHint:  time.Duration(showing.Movie.Duration) * time.Minute,
+			showtime := internal.SourceShowtime{
+				ID:          uuid.NewSHA1(s.uuidNamespace, []byte(showing.ID)).String(),
+				Summary:     showing.Movie.Name,
+				Description: showing.Movie.Synopsis,
+				StartTime:   startTime,
+				EndTime:     endTime,
+				Location:    cinemagicLocation,
+				VenueName:   cinemagicVenueName,
+				Screening: internal.ScreeningInfo{
+					Title:         showing.Movie.Name,
+					Subhed:        subhed,
+					Links:         links,
+					Accessibility: accessibilityFeatures(showing.Movie.Name, subhed),
 				},
-				Site: proto.PdxSite_Cinemagic,
+				TitleHint:    showing.Movie.Name,
+				DirectorHint: showing.Movie.DirectedBy,
+				TMDBIDHint:   cinemagicTMDBID(showing.Movie.TMDBId),
+				Admission:    admission(showing.Movie.Name, subhed),
+			}
+			showtime.SetRuntimeHint(runtime, internal.RuntimeSourceListing)
+			items = append(items, internal.ShowtimeListItem{
+				Showtime: showtime,
+				Site:     proto.PdxSite_Cinemagic,
 			})
 		}
 	}
 
-	slices.SortFunc(items, func(a, b internal.ShowtimeListItem) int {
-		return a.Showtime.StartTime.Compare(b.Showtime.StartTime)
-	})
+	slices.SortFunc(items, compareItems)
 	for _, item := range items {
 		hits <- item
 	}
-	slog.Debug("cinemagic: emitted showtimes", "sent", len(items), "skipped", skipped)
+	slog.Debug("cinemagic: emitted showtimes", "sent", len(items), "skipped", skips.Total())
+	listReq.ReportSkips(proto.PdxSite_Cinemagic, skips)
 }
 
 // cinemagicGraphQLResponse is the top-level GraphQL response.
@@ -572,19 +636,29 @@ type cinemagicShowing struct {
 }
 
 type cinemagicMovie struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Synopsis   string `json:"synopsis"`
-	Starring   string `json:"starring"`
-	DirectedBy string `json:"directedBy"`
-	Duration   int    `json:"duration"`
-	Genre      string `json:"genre"`
-	AllGenres  string `json:"allGenres"`
-	Rating     string `json:"rating"`
-	TMDBId     string `json:"tmdbId"`
-	URLSlug    string `json:"urlSlug"`
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Synopsis   *string `json:"synopsis"`
+	Starring   string  `json:"starring"`
+	DirectedBy string  `json:"directedBy"`
+	Duration   int     `json:"duration"`
+	Genre      string  `json:"genre"`
+	AllGenres  string  `json:"allGenres"`
+	Rating     string  `json:"rating"`
+	TMDBId     string  `json:"tmdbId"`
+	URLSlug    string  `json:"urlSlug"`
 }
 
 type cinemagicDisplayMeta struct {
 	Classes string `json:"classes"`
 }
+
+// cinemagicTMDBID returns the listing's TMDB id, or "" when it is missing or obviously invalid
+// (Cinemagic sends "0" for movies it hasn't linked).
+func cinemagicTMDBID(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if id, err := strconv.Atoi(raw); err != nil || id <= 0 {
+		return ""
+	}
+	return raw
+}
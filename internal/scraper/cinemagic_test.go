@@ -1,12 +1,20 @@
 package scraper
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,6 +48,140 @@ func TestUnit_Cinemagic_ScrapeShowtimes(t *testing.T) {
 	}
 }
 
+func TestUnit_Cinemagic_BrowserLaunchFailureFallsBackToHTTP(t *testing.T) {
+	server := MountGoldenTestServer(t, "cinemagic")
+	s := Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithBrowser(unlaunchableBrowser{}), CinemagicWithFallbackClient(server.Client()))
+
+	ch, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	var n int
+	for range ch {
+		n++
+	}
+	require.Positive(t, n, "expected golden showtimes over HTTP")
+}
+
+func TestUnit_Cinemagic_TMDBIDHint(t *testing.T) {
+	server := MountGoldenTestServer(t, "cinemagic")
+	s := Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithClient(server.Client()))
+
+	ch, err := s.ScrapeShowtimes(context.Background(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+
+	hints := make(map[string]string)
+	for item := range ch {
+		hints[item.Showtime.Summary] = item.Showtime.TMDBIDHint
+	}
+	require.NotEmpty(t, hints)
+	assert.Equal(t, "804370", hints["Arco"])
+	assert.Equal(t, "593", hints["Solaris"])
+	for title, hint := range hints {
+		assert.NotEmpty(t, hint, "%s: every golden listing carries a TMDB id", title)
+	}
+}
+
+func TestUnit_Cinemagic_LinkRels(t *testing.T) {
+	server := MountGoldenTestServer(t, "cinemagic")
+	rels := goldenLinkRels(t, Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithClient(server.Client())))
+	require.Equal(t, []string{internal.LinkRelTickets}, keys(rels))
+}
+
+func TestUnit_CinemagicTMDBID(t *testing.T) {
+	for raw, want := range map[string]string{
+		"804370": "804370",
+		" 593 ":  "593",
+		"":       "",
+		"0":      "",
+		"-1":     "",
+		"null":   "",
+		"tt1234": "",
+	} {
+		assert.Equal(t, want, cinemagicTMDBID(raw), "cinemagicTMDBID(%q)", raw)
+	}
+}
+
+// graphQLDateCounter counts showingsForDate requests per date.
+type graphQLDateCounter struct {
+	base  http.RoundTripper
+	mu    sync.Mutex
+	dates map[string]int
+}
+
+func (c *graphQLDateCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	var gql struct {
+		Variables struct {
+			Date string `json:"date"`
+		} `json:"variables"`
+	}
+	if json.Unmarshal(body, &gql) == nil && gql.Variables.Date != "" {
+		c.mu.Lock()
+		c.dates[gql.Variables.Date]++
+		c.mu.Unlock()
+	}
+	return c.base.RoundTrip(req)
+}
+
+func TestUnit_Cinemagic_DuplicateDatesFetchedOnce(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "cinemagic")
+	require.NoError(t, os.CopyFS(dir, os.DirFS(filepath.Join(*goldenDir, "cinemagic"))), "copy golden files")
+	// Duplicated and out of order, as a misbehaving API might return them.
+	dates := `{"data":{"datesWithShowing":{"value":"[\"2026-02-22\",\"2026-02-21\",\"2026-02-22\",\"2026-02-21\"]"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dates.json"), []byte(dates), 0o644))
+
+	server := mountGoldenTestServerFrom(t, root, "cinemagic")
+	counter := &graphQLDateCounter{base: server.Client().Transport, dates: make(map[string]int)}
+	s := Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithClient(&http.Client{Transport: counter}))
+
+	ch, err := s.ScrapeShowtimes(context.Background(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+	var items []internal.ShowtimeListItem
+	for item := range ch {
+		items = append(items, item)
+	}
+
+	require.NotEmpty(t, items)
+	require.Equal(t, map[string]int{"2026-02-21": 1, "2026-02-22": 1}, counter.dates, "each date fetched once")
+}
+
+func TestUnit_Cinemagic_ReportsProgressPerDate(t *testing.T) {
+	server := MountGoldenTestServer(t, "cinemagic")
+	s := Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithClient(server.Client()))
+
+	var reports []internal.Progress
+	ch, err := s.ScrapeShowtimes(context.Background(), internal.ListShowtimesRequest{
+		After:    time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Progress: func(p internal.Progress) { reports = append(reports, p) },
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+	for range ch {
+	}
+
+	// The golden set has showings for 2026-02-21 through 2026-03-01.
+	require.Len(t, reports, 9, "one report per date")
+	for i, p := range reports {
+		assert.Equal(t, proto.PdxSite_Cinemagic, p.Site)
+		assert.Equal(t, "dates", p.Unit)
+		assert.Equal(t, i+1, p.Done)
+		assert.Equal(t, len(reports), p.Total)
+	}
+}
+
 func TestIntegration_Cinemagic_Showtimes(t *testing.T) {
 	scrp := Cinemagic()
 	showtimes, err := scrp.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
@@ -52,3 +194,56 @@ func TestIntegration_Cinemagic_Showtimes(t *testing.T) {
 		t.Logf("showtime: %+v", showtime)
 	}
 }
+
+func TestUnit_Cinemagic_GoldenShowtimes(t *testing.T) {
+	requireGoldenShowtimes(t, "cinemagic", func(baseURL string, client *http.Client) internal.Scraper {
+		return Cinemagic(CinemagicWithBaseURL(baseURL), CinemagicWithClient(client))
+	})
+}
+
+func TestUnit_Cinemagic_SkipsShowingsWithoutMovieName(t *testing.T) {
+	s := Cinemagic().(*cinemagicScraper)
+	body := `{"data":{"showingsForDate":{"data":[
+		{"id":"named","time":"2026-02-21T19:00:00Z","published":true,"movie":{"name":"Heat","duration":170}},
+		{"id":"nameless","time":"2026-02-21T21:00:00Z","published":true,"movie":{"name":"  ","duration":90}}
+	]}}}`
+
+	hits := make(chan internal.ShowtimeListItem, 2)
+	s.sendShowtimes(hits, map[string][]byte{"2026-02-21": []byte(body)}, internal.ListShowtimesRequest{})
+	close(hits)
+
+	var summaries []string
+	for item := range hits {
+		summaries = append(summaries, item.Showtime.Summary)
+	}
+	require.Equal(t, []string{"Heat"}, summaries, "the nameless showing is skipped")
+}
+
+func TestUnit_ParseCinemagicTime(t *testing.T) {
+	want := time.Date(2026, 2, 20, 19, 30, 0, 0, portlandTZ)
+	for _, tc := range []struct {
+		name string
+		in   string
+	}{
+		{name: "utc Z", in: "2026-02-21T03:30:00Z"},
+		{name: "utc offset", in: "2026-02-21T03:30:00+00:00"},
+		{name: "pacific offset", in: "2026-02-20T19:30:00-08:00"},
+		{name: "fractional seconds", in: "2026-02-21T03:30:00.000Z"},
+		{name: "nanoseconds with offset", in: "2026-02-20T19:30:00.000000000-08:00"},
+		{name: "naive", in: "2026-02-20T19:30:00"},
+		{name: "naive fractional", in: "2026-02-20T19:30:00.000"},
+		{name: "naive no seconds", in: "2026-02-20T19:30"},
+		{name: "naive space", in: "2026-02-20 19:30:00"},
+		{name: "padded", in: " 2026-02-21T03:30:00Z "},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseCinemagicTime(tc.in)
+			require.NoError(t, err)
+			require.True(t, want.Equal(got), "got %s, want %s", got, want)
+			require.Equal(t, "2026-02-20 19:30", got.In(portlandTZ).Format("2006-01-02 15:04"))
+		})
+	}
+
+	_, err := parseCinemagicTime("Friday at 7:30")
+	require.Error(t, err)
+}
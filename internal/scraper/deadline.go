@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/drewfead/pdx-watcher/internal"
+)
+
+// UntilDeadline wraps inner so a request with a Deadline stops it there: items scraped by then are
+// still delivered, the rest of the scrape is abandoned, and the site is logged as incomplete. Wrap
+// each site before interleaving so one slow site doesn't hold back the others' results. Requests
+// without a Deadline pass straight through.
+func UntilDeadline(inner internal.Scraper) internal.Scraper {
+	return &deadlineScraper{inner: inner}
+}
+
+type deadlineScraper struct {
+	inner internal.Scraper
+}
+
+func (d *deadlineScraper) Descriptor() string {
+	return d.inner.Descriptor()
+}
+
+func (d *deadlineScraper) Capabilities() internal.Capabilities {
+	return d.inner.Capabilities()
+}
+
+func (d *deadlineScraper) ScrapeShowtimes(ctx context.Context, req internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	if req.Deadline.IsZero() {
+		return d.inner.ScrapeShowtimes(ctx, req)
+	}
+	scrapeCtx, cancel := context.WithDeadline(ctx, req.Deadline)
+	ch, err := d.inner.ScrapeShowtimes(scrapeCtx, req)
+	if err != nil {
+		cancel()
+		if ctx.Err() == nil && scrapeCtx.Err() != nil {
+			slog.Warn("scrape incomplete: deadline reached", "descriptor", d.inner.Descriptor(), "sent", 0, "error", err)
+			return replay(nil), nil
+		}
+		return nil, err
+	}
+	out := make(chan internal.ShowtimeListItem)
+	go func() {
+		defer close(out)
+		defer cancel()
+		sent := 0
+		defer func() {
+			// A scraper may also notice the deadline itself and close its stream early.
+			if ctx.Err() == nil && scrapeCtx.Err() != nil {
+				slog.Warn("scrape incomplete: deadline reached", "descriptor", d.inner.Descriptor(), "sent", sent)
+			}
+		}()
+		for {
+			var item internal.ShowtimeListItem
+			var ok bool
+			select {
+			case item, ok = <-ch:
+			case <-scrapeCtx.Done():
+				// Past the deadline, still take anything the scraper already has ready; stop at the
+				// first item it would have to wait for.
+				select {
+				case item, ok = <-ch:
+				default:
+					return
+				}
+			}
+			if !ok {
+				return
+			}
+			// Deliver on the caller's context: items scraped in time aren't lost to the deadline.
+			select {
+			case out <- item:
+				sent++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,87 @@
+package scraper
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/stretchr/testify/require"
+)
+
+// stallingScraper sends its items, then holds the stream open until the context ends.
+type stallingScraper struct {
+	mockScraper
+}
+
+func (s *stallingScraper) ScrapeShowtimes(ctx context.Context, _ internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	ch := make(chan internal.ShowtimeListItem)
+	go func() {
+		defer close(ch)
+		for _, it := range s.items {
+			select {
+			case ch <- it:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return ch, nil
+}
+
+func TestUnit_UntilDeadline_CutsOffSlowSite(t *testing.T) {
+	base := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	fast := &mockScraper{descriptor: "fast", items: []internal.ShowtimeListItem{
+		{Showtime: internal.SourceShowtime{ID: "f1", StartTime: base}},
+		{Showtime: internal.SourceShowtime{ID: "f2", StartTime: base.Add(2 * time.Hour)}},
+	}}
+	slow := &stallingScraper{mockScraper{descriptor: "slow", items: []internal.ShowtimeListItem{
+		{Showtime: internal.SourceShowtime{ID: "s1", StartTime: base.Add(time.Hour)}},
+	}}}
+	sc := Interleaved(UntilDeadline(fast), UntilDeadline(slow))
+
+	start := time.Now()
+	ch, err := sc.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{Deadline: start.Add(100 * time.Millisecond)})
+	require.NoError(t, err)
+	var ids []string
+	for item := range ch {
+		ids = append(ids, item.Showtime.ID)
+	}
+	require.Less(t, time.Since(start), 5*time.Second, "the slow site should be cut off at the deadline")
+	require.Equal(t, []string{"f1", "s1", "f2"}, ids, "the slow site keeps what it sent before the deadline")
+}
+
+// bufferedScraper hands over all its items at once, then holds the stream open until the context ends.
+type bufferedScraper struct {
+	mockScraper
+}
+
+func (s *bufferedScraper) ScrapeShowtimes(ctx context.Context, _ internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	ch := make(chan internal.ShowtimeListItem, len(s.items))
+	for _, it := range s.items {
+		ch <- it
+	}
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
+
+func TestUnit_UntilDeadline_DeliversBufferedItems(t *testing.T) {
+	var items []internal.ShowtimeListItem
+	for i := range 50 {
+		items = append(items, internal.ShowtimeListItem{Showtime: internal.SourceShowtime{ID: strconv.Itoa(i)}})
+	}
+	sc := UntilDeadline(&bufferedScraper{mockScraper{descriptor: "buffered", items: items}})
+	// The deadline has already passed, so Done is ready alongside every buffered item.
+	ch, err := sc.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{Deadline: time.Now()})
+	require.NoError(t, err)
+	n := 0
+	for range ch {
+		n++
+	}
+	require.Equal(t, len(items), n, "items scraped before the deadline are never dropped")
+}
@@ -0,0 +1,153 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
+)
+
+// CachedWithDiskStore also keeps cached results as JSON files under dir, so they outlive the process
+// (e.g. across CLI invocations). A memory miss checks the disk before scraping, and each scrape is
+// written back. Files are replaced atomically; corrupt, expired, or unreadable files count as misses.
+func CachedWithDiskStore(dir string) CacheOption {
+	return func(c *cachingScraper) {
+		if dir != "" {
+			c.disk = &diskStore{dir: dir}
+		}
+	}
+}
+
+// diskStore persists cache entries as one JSON file per key.
+type diskStore struct {
+	dir string
+}
+
+// diskEntry is the on-disk form of a cacheEntry. A zero Expires never expires.
+type diskEntry struct {
+	Key     string                      `json:"key"`
+	Expires time.Time                   `json:"expires,omitzero"`
+	Items   []internal.ShowtimeListItem `json:"items"`
+	Skips   []diskSkipReport            `json:"skips,omitempty"`
+}
+
+type diskSkipReport struct {
+	Site  proto.PdxSite       `json:"site"`
+	Skips internal.SkipCounts `json:"skips"`
+}
+
+// path returns the file for key. Files are prefixed with the sanitized descriptor (which never
+// contains '-') so removeAll can find one scraper's entries in a shared directory.
+func (d *diskStore) path(descriptor, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, diskPrefix(descriptor)+hex.EncodeToString(sum[:16])+".json")
+}
+
+func diskPrefix(descriptor string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, descriptor)
+	return safe + "-"
+}
+
+// load returns the entry stored for key and whether one was found. Expiry is left to the caller,
+// which may serve stale entries off-schedule.
+func (d *diskStore) load(descriptor, key string) (cacheEntry, bool) {
+	path := d.path(descriptor, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Debug("scrape cache: read failed", "path", path, "error", err)
+		}
+		return cacheEntry{}, false
+	}
+	var stored diskEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Key != key {
+		// Corrupt, or a hash collision: treat as a miss and let the next store replace it.
+		slog.Debug("scrape cache: ignoring unreadable entry", "path", path, "error", err)
+		return cacheEntry{}, false
+	}
+	entry := cacheEntry{items: stored.Items, expires: stored.Expires}
+	for _, s := range stored.Skips {
+		entry.skips = append(entry.skips, skipReport{site: s.Site, skips: s.Skips})
+	}
+	return entry, true
+}
+
+// store writes entry for key via a temp file and rename, so readers never see a partial file.
+func (d *diskStore) store(descriptor, key string, entry cacheEntry) error {
+	stored := diskEntry{Key: key, Expires: entry.expires, Items: entry.items}
+	for _, s := range entry.skips {
+		stored.Skips = append(stored.Skips, diskSkipReport{Site: s.site, Skips: s.skips})
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("encode scrape cache entry: %w", err)
+	}
+	if err := os.MkdirAll(d.dir, 0o750); err != nil {
+		return fmt.Errorf("create scrape cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create scrape cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write scrape cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write scrape cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), d.path(descriptor, key)); err != nil {
+		return fmt.Errorf("replace scrape cache file: %w", err)
+	}
+	return nil
+}
+
+// prune deletes descriptor's entries that expired before cutoff, so keys that are never asked for
+// again (e.g. --upcoming's after=now) don't pile up. Unreadable files are left for store to replace.
+func (d *diskStore) prune(descriptor string, cutoff time.Time) {
+	paths, _ := filepath.Glob(filepath.Join(d.dir, diskPrefix(descriptor)+"*.json"))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var stored struct {
+			Expires time.Time `json:"expires"`
+		}
+		if json.Unmarshal(data, &stored) != nil || stored.Expires.IsZero() || !stored.Expires.Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			slog.Debug("scrape cache: prune failed", "path", path, "error", err)
+		}
+	}
+}
+
+// remove deletes the entry for key, if any.
+func (d *diskStore) remove(descriptor, key string) {
+	_ = os.Remove(d.path(descriptor, key))
+}
+
+// removeAll deletes every entry stored for descriptor.
+func (d *diskStore) removeAll(descriptor string) {
+	paths, _ := filepath.Glob(filepath.Join(d.dir, diskPrefix(descriptor)+"*.json"))
+	for _, path := range paths {
+		_ = os.Remove(path)
+	}
+}
@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +31,9 @@ type hollywoodTheatreScraper struct {
 	uuidNamespace   uuid.UUID
 	httpClient      *http.Client      // non-nil = test mode (skip rod)
 	headlessBrowser browser.Interface // nil = use browser.Headless()
+	fallbackClient  *http.Client      // used over HTTP when the browser can't launch; nil = no fallback
+	formatTerms     []string          // stripped from titles into the subhed; defaultFormatTerms plus any added
+	titleSuffixes   []string          // " in X" and " (X)" for each format term
 }
 
 // HollywoodTheatreOption applies configuration to a Hollywood Theatre scraper.
@@ -63,14 +67,41 @@ func WithBrowser(b browser.Interface) HollywoodTheatreOption {
 	}
 }
 
+// HollywoodWithFallbackClient sets the HTTP client used when the browser fails to launch or is
+// disabled (default DefaultHTTPClient). The site's endpoints are plain JSON, so HTTP works too.
+func HollywoodWithFallbackClient(client *http.Client) HollywoodTheatreOption {
+	return func(s *hollywoodTheatreScraper) {
+		if client != nil {
+			s.fallbackClient = client
+		}
+	}
+}
+
+// HollywoodWithFormatTerms adds format terms (e.g. "Dolby Vision") to strip from titles alongside
+// the defaults, as " in X", " (X)", or a trailing "(X)"; matching is case-insensitive.
+func HollywoodWithFormatTerms(terms ...string) HollywoodTheatreOption {
+	return func(s *hollywoodTheatreScraper) {
+		for _, t := range terms {
+			if t = strings.TrimSpace(t); t != "" {
+				s.formatTerms = append(s.formatTerms, t)
+			}
+		}
+	}
+}
+
 func HollywoodTheatre(opts ...HollywoodTheatreOption) internal.Scraper {
 	s := &hollywoodTheatreScraper{
-		baseURL:    defaultBaseURL,
-		descriptor: defaultDescriptor,
+		baseURL:        defaultBaseURL,
+		descriptor:     defaultDescriptor,
+		formatTerms:    slices.Clone(defaultFormatTerms),
+		fallbackClient: DefaultHTTPClient(),
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
+	for _, t := range s.formatTerms {
+		s.titleSuffixes = append(s.titleSuffixes, " in "+t, " ("+t+")")
+	}
 	s.uuidNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte(s.baseURL))
 	if s.headlessBrowser == nil && s.httpClient == nil {
 		s.headlessBrowser = browser.Headless()
@@ -82,16 +113,32 @@ const (
 	defaultBaseURL = "https://www.hollywoodtheatre.org"
 )
 
-var defaultDescriptor = proto.PdxSite_HollywoodTheatre.Descriptor().Syntax().GoString()
+var defaultDescriptor = SiteDescriptor(proto.PdxSite_HollywoodTheatre)
 
 var errHTTPRequestFailed = errors.New("http request failed")
 
 const portlandLocale = "en_US"
 const portlandTimezoneCode = "America/Los_Angeles"
 const hollywoodTheatreLocation = "Hollywood Theatre, 4122 NE Sandy Blvd, Portland, Oregon, 97212"
+const hollywoodTheatreVenueName = "Hollywood Theatre"
 
 var portlandTZ *time.Location
 
+// clockLayout is almost time.Kitchen, but with lowercase "am/pm"; parseClock normalizes input to it.
+const clockLayout = "3:04pm"
+
+// parseClock parses a listing's time of day in loc, tolerating case, spaces, and dots around
+// am/pm: "7:30pm", "7:30 PM", and "7:30 p.m." all parse.
+func parseClock(s string, loc *time.Location) (time.Time, error) {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, ".", "")
+	s = strings.Join(strings.Fields(s), "")
+	return time.ParseInLocation(clockLayout, s, loc)
+}
+
+// nowFunc returns the current time for date-relative ranges; tests override it to pin "today".
+var nowFunc = time.Now
+
 func init() {
 	var err error
 	portlandTZ, err = time.LoadLocation(portlandTimezoneCode)
@@ -104,10 +151,19 @@ func (s *hollywoodTheatreScraper) Descriptor() string {
 	return s.descriptor
 }
 
+// Capabilities reports director and runtime, which come from the calendar-events feed; the listing has no synopsis.
+func (s *hollywoodTheatreScraper) Capabilities() internal.Capabilities {
+	return internal.CapabilityDirector | internal.CapabilityRuntime | internal.CapabilityAdmission | internal.CapabilityAccessibility
+}
+
 func (s *hollywoodTheatreScraper) ScrapeShowtimes(
 	ctx context.Context,
 	listReq internal.ListShowtimesRequest,
 ) (<-chan internal.ShowtimeListItem, error) {
+	resume, err := decodeHollywoodAnchor(listReq.Anchor)
+	if err != nil {
+		return nil, err
+	}
 	allJSON, err := s.fetchAllData(ctx, listReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
@@ -143,7 +199,8 @@ func (s *hollywoodTheatreScraper) ScrapeShowtimes(
 	hits := make(chan internal.ShowtimeListItem)
 	go func() {
 		defer close(hits)
-		s.sendShowtimes(hits, allShows, listReq, calendarByID)
+		defer recoverScrape(s.Descriptor(), listReq)
+		s.sendShowtimes(hits, allShows, listReq, calendarByID, resume)
 	}()
 
 	return hits, nil
@@ -207,20 +264,25 @@ func (s *hollywoodTheatreScraper) MountGolden(ctx context.Context, goldenDir str
 // fetchAllData returns show-list (today, coming-soon) and calendar-events JSON for the listReq date range.
 func (s *hollywoodTheatreScraper) fetchAllData(ctx context.Context, listReq internal.ListShowtimesRequest) (map[string][]byte, error) {
 	if s.httpClient != nil {
-		return s.fetchAllViaHTTP(ctx, listReq)
+		return s.fetchAllViaHTTP(ctx, s.httpClient, listReq)
 	}
-	return s.fetchAllViaHeadlessBrowser(ctx, listReq)
+	results, err := s.fetchAllViaHeadlessBrowser(ctx, listReq)
+	if errors.Is(err, browser.ErrUnavailable) && s.fallbackClient != nil {
+		slog.Warn("hollywoodtheatre: browser unavailable, falling back to HTTP", "error", err)
+		return s.fetchAllViaHTTP(ctx, s.fallbackClient, listReq)
+	}
+	return results, err
 }
 
-// fetchAllViaHTTP fetches show-list and calendar-events (for the given listReq range).
-func (s *hollywoodTheatreScraper) fetchAllViaHTTP(ctx context.Context, listReq internal.ListShowtimesRequest) (map[string][]byte, error) {
+// fetchAllViaHTTP fetches show-list and calendar-events (for the given listReq range) with client.
+func (s *hollywoodTheatreScraper) fetchAllViaHTTP(ctx context.Context, client *http.Client, listReq internal.ListShowtimesRequest) (map[string][]byte, error) {
 	results := make(map[string][]byte, 3)
 	for _, view := range showListViews {
 		req, err := http.NewRequestWithContext(ctx, "GET", s.showListURL(view, portlandLocale), nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request for %s: %w", view, err)
 		}
-		resp, err := s.httpClient.Do(req)
+		resp, err := client.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get %s: %w", view, err)
 		}
@@ -239,7 +301,7 @@ func (s *hollywoodTheatreScraper) fetchAllViaHTTP(ctx context.Context, listReq i
 	if err != nil {
 		return nil, fmt.Errorf("failed to create calendar-events request: %w", err)
 	}
-	calResp, err := s.httpClient.Do(calReq)
+	calResp, err := client.Do(calReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get calendar-events: %w", err)
 	}
@@ -286,46 +348,49 @@ func (s *hollywoodTheatreScraper) sendShowtimes(
 	shows []showEntry,
 	listReq internal.ListShowtimesRequest,
 	calendarByID map[int]calendarEventDetails,
+	resume hollywoodAnchor,
 ) {
 	dateLayout := time.DateOnly
-	timeLayout := "3:04pm" // almost time.Kitchen, but with lowercase "am/pm"
 
 	var items []internal.ShowtimeListItem
-	var skippedAfter, skippedBefore, skippedParse int
+	var skips internal.SkipCounts
 	for _, show := range shows {
 		if show.HideEvents {
 			continue
 		}
 		showDate, err := time.ParseInLocation(dateLayout, show.QueryDate, portlandTZ)
 		if err != nil {
-			skippedParse++
+			skips.Parse++
 			continue
 		}
 		screeningLinks := []internal.Link{}
-		if show.Permalink != "" {
-			screeningLinks = append(screeningLinks, internal.Link{Href: show.Permalink, Display: "Event"})
+		if permalink := resolveURL(s.baseURL, show.Permalink); permalink != "" {
+			screeningLinks = append(screeningLinks, internal.Link{Href: permalink, Display: "Event", Rel: internal.LinkRelInfo})
 		}
-		if show.SeriesURL != "" && show.Series != "" {
-			screeningLinks = append(screeningLinks, internal.Link{Href: show.SeriesURL, Display: show.Series})
+		if seriesURL := resolveURL(s.baseURL, show.SeriesURL); seriesURL != "" && show.Series != "" {
+			screeningLinks = append(screeningLinks, internal.Link{Href: seriesURL, Display: show.Series, Rel: internal.LinkRelSeries})
 		}
 		normalized, subhed := s.extractTitleHintWithSubhed(show.Title)
 		if normalized == "" {
 			normalized = show.Title
 		}
 		screening := internal.ScreeningInfo{
-			Title:  show.Title,
-			Subhed: subhed,
-			Series: show.Series,
-			Links:  screeningLinks,
+			Title:         show.Title,
+			Subhed:        subhed,
+			Series:        show.Series,
+			Links:         screeningLinks,
+			Accessibility: accessibilityFeatures(show.Title, show.Series),
 		}
 
 		for _, ev := range show.Events {
 			var start time.Time
 			var directorHint string
 			var runtimeHint time.Duration
+			var runtimeSource internal.RuntimeSource
 			if cal, ok := calendarByID[ev.ID]; ok {
 				directorHint = cal.DirectorHint
 				runtimeHint = cal.RuntimeHint
+				runtimeSource = cal.RuntimeSource
 				if show.view == "coming-soon" && !cal.Start.IsZero() {
 					start = cal.Start
 				}
@@ -334,13 +399,13 @@ func (s *hollywoodTheatreScraper) sendShowtimes(
 				// Coming-soon bundles all future events under one query_date
 				// with wrong start_times. Without calendar data we can't
 				// determine the correct date or time, so skip.
-				skippedParse++
+				skips.Parse++
 				continue
 			}
 			if start.IsZero() {
-				startTime, err := time.ParseInLocation(timeLayout, ev.StartTime, portlandTZ)
+				startTime, err := parseClock(ev.StartTime, portlandTZ)
 				if err != nil {
-					skippedParse++
+					skips.Parse++
 					continue
 				}
 				start = time.Date(
@@ -349,37 +414,91 @@ func (s *hollywoodTheatreScraper) sendShowtimes(
 				)
 			}
 			if !listReq.After.IsZero() && !start.After(listReq.After) {
-				skippedAfter++
+				skips.After++
 				continue
 			}
 			if !listReq.Before.IsZero() && !start.Before(listReq.Before) {
-				skippedBefore++
+				skips.Before++
 				continue
 			}
 
+			// The listing has no synopsis; the title stands in for one when present.
+			var description *string
+			if show.Title != "" {
+				description = ptr(show.Title)
+			}
+			showtime := internal.SourceShowtime{
+				ID:           uuid.NewSHA1(s.uuidNamespace, []byte(strconv.Itoa(ev.ID))).String(),
+				Summary:      show.Title,
+				Description:  description,
+				StartTime:    start,
+				Location:     hollywoodTheatreLocation,
+				VenueName:    hollywoodTheatreVenueName,
+				Screening:    screening,
+				TitleHint:    normalized,
+				DirectorHint: directorHint,
+				Admission:    admission(show.Title, show.Series),
+			}
+			showtime.SetRuntimeHint(runtimeHint, runtimeSource)
 			items = append(items, internal.ShowtimeListItem{
-				Showtime: internal.SourceShowtime{
-					ID:           uuid.NewSHA1(s.uuidNamespace, []byte(strconv.Itoa(ev.ID))).String(),
-					Summary:      show.Title,
-					Description:  show.Title,
-					StartTime:    start,
-					Location:     hollywoodTheatreLocation,
-					Screening:    screening,
-					TitleHint:    normalized,
-					DirectorHint: directorHint,
-					RuntimeHint:  runtimeHint,
-				},
-				Site: proto.PdxSite_HollywoodTheatre,
+				Showtime: showtime,
+				Site:     proto.PdxSite_HollywoodTheatre,
 			})
 		}
 	}
 
-	slices.SortFunc(items, func(a, b internal.ShowtimeListItem) int {
-		return a.Showtime.StartTime.Compare(b.Showtime.StartTime)
-	})
+	slices.SortFunc(items, compareItems)
+	if !resume.isZero() {
+		// Resume where the previous page stopped: the anchored showtime is the first one sent.
+		items = slices.DeleteFunc(items, func(item internal.ShowtimeListItem) bool {
+			return resume.after(item)
+		})
+	}
+	if listReq.Limit > 0 && len(items) > listReq.Limit {
+		items[listReq.Limit-1].NextAnchor = encodeHollywoodAnchor(items[listReq.Limit])
+		items = items[:listReq.Limit]
+	}
 	for _, item := range items {
 		hits <- item
 	}
+	slog.Debug("hollywoodtheatre: emitted showtimes", "sent", len(items), "skipped", skips.Total())
+	listReq.ReportSkips(proto.PdxSite_HollywoodTheatre, skips)
+}
+
+// hollywoodAnchor is a decoded Hollywood Theatre page token: the start time and ID of the first
+// showtime of the next page. Tokens are base64 JSON so they survive --anchor unchanged.
+type hollywoodAnchor struct {
+	Start time.Time `json:"start"`
+	ID    string    `json:"id"`
+}
+
+func (a hollywoodAnchor) isZero() bool { return a.Start.IsZero() && a.ID == "" }
+
+// after reports whether the anchored showtime sorts after item, i.e. item belongs to an earlier page.
+func (a hollywoodAnchor) after(item internal.ShowtimeListItem) bool {
+	return compareItems(item, internal.ShowtimeListItem{Showtime: internal.SourceShowtime{StartTime: a.Start, ID: a.ID}}) < 0
+}
+
+// encodeHollywoodAnchor returns the token that resumes a listing at item.
+func encodeHollywoodAnchor(item internal.ShowtimeListItem) string {
+	data, _ := json.Marshal(hollywoodAnchor{Start: item.Showtime.StartTime, ID: item.Showtime.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeHollywoodAnchor parses a token from encodeHollywoodAnchor. An empty token is the zero anchor.
+func decodeHollywoodAnchor(token string) (hollywoodAnchor, error) {
+	var anchor hollywoodAnchor
+	if token == "" {
+		return anchor, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &anchor)
+	}
+	if err != nil {
+		return hollywoodAnchor{}, fmt.Errorf("invalid anchor %q: %w", token, err)
+	}
+	return anchor, nil
 }
 
 var showListViews = []string{"today", "coming-soon"}
@@ -387,23 +506,28 @@ var showListViews = []string{"today", "coming-soon"}
 // goldenCalendarRange returns start and end dates (YYYY-MM-DD) for calendar-events when pulling golden data.
 // Uses Portland timezone; range is today through one year from today.
 func goldenCalendarRange() (start, end time.Time) {
-	now := time.Now().In(portlandTZ)
+	now := nowFunc().In(portlandTZ)
 	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, portlandTZ)
 	endDate := startDate.AddDate(1, 0, 0)
 	return startDate, endDate
 }
 
-// calendarRangeFromListReq returns start_date and end_date (YYYY-MM-DD) for calendar-events from listReq.
-// If listReq has zero times, uses default: today through one year from today in Portland TZ.
+// calendarRangeFromListReq returns start_date and end_date (YYYY-MM-DD) for calendar-events from listReq,
+// bounding the query to exactly the requested range so a narrow --before doesn't pull a year of events.
+// Each bound is set independently: a zero After means today and a zero Before means one year after the
+// start, both in Portland TZ.
 func calendarRangeFromListReq(listReq internal.ListShowtimesRequest) (start, end time.Time) {
 	loc := portlandTZ
-	now := time.Now().In(loc)
-	if !listReq.After.IsZero() && !listReq.Before.IsZero() {
-		return listReq.After.In(loc), listReq.Before.In(loc)
+	if listReq.After.IsZero() {
+		now := nowFunc().In(loc)
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	} else {
+		start = listReq.After.In(loc)
 	}
-	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-	endDate := startDate.AddDate(1, 0, 0)
-	return startDate, endDate
+	if listReq.Before.IsZero() {
+		return start, start.AddDate(1, 0, 0)
+	}
+	return start, listReq.Before.In(loc)
 }
 
 func (s *hollywoodTheatreScraper) showListURL(view string, locale string) string {
@@ -428,8 +552,8 @@ func (s *hollywoodTheatreScraper) calendarEventsURL(startDate, endDate string, l
 }
 
 // parseCalendarEventsByID builds event_id -> calendarEventDetails from calendar-events JSON.
-// RuntimeHint is inferred from start/end times when both are present (most reliable);
-// otherwise it is parsed from the "runtime" string (e.g. "81 mins") when present.
+// RuntimeHint is inferred from start/end times when both are present (most reliable, RuntimeSourceCalendar);
+// otherwise it is parsed from the "runtime" string (e.g. "81 mins", RuntimeSourceListing) when present.
 func parseCalendarEventsByID(body []byte) (map[int]calendarEventDetails, error) {
 	var resp calendarEventsResponse
 	if err := json.Unmarshal(body, &resp); err != nil {
@@ -440,13 +564,16 @@ func parseCalendarEventsByID(body []byte) (map[int]calendarEventDetails, error)
 		for _, ev := range e.Events {
 			var start time.Time
 			var d time.Duration
+			var src internal.RuntimeSource
 			if ev.Start != "" {
 				s, err := time.Parse(time.RFC3339, ev.Start)
 				if err == nil {
 					if ev.End != "" {
 						end, err2 := time.Parse(time.RFC3339, ev.End)
-						if err2 == nil && end.After(s) {
-							d = end.Sub(s)
+						if err2 == nil {
+							if d = internal.PlausibleRuntime(end.Sub(s)); d > 0 {
+								src = internal.RuntimeSourceCalendar
+							}
 						}
 					}
 					// The calendar API uses +00:00 but stores Portland local
@@ -459,13 +586,16 @@ func parseCalendarEventsByID(body []byte) (map[int]calendarEventDetails, error)
 			if d == 0 && ev.Runtime != "" {
 				var mins int
 				if _, err := fmt.Sscanf(ev.Runtime, "%d mins", &mins); err == nil {
-					d = time.Duration(mins) * time.Minute
+					if d = internal.PlausibleRuntime(time.Duration(mins) * time.Minute); d > 0 {
+						src = internal.RuntimeSourceListing
+					}
 				}
 			}
 			out[ev.EventID] = calendarEventDetails{
-				Start:        start,
-				DirectorHint: strings.TrimSpace(ev.Director),
-				RuntimeHint:  d,
+				Start:         start,
+				DirectorHint:  strings.TrimSpace(ev.Director),
+				RuntimeHint:   d,
+				RuntimeSource: src,
 			}
 		}
 	}
@@ -515,9 +645,10 @@ type calendarEventsResponse struct {
 
 // calendarEventDetails is attached to each showtime when we have calendar-events data.
 type calendarEventDetails struct {
-	Start        time.Time
-	DirectorHint string
-	RuntimeHint  time.Duration
+	Start         time.Time
+	DirectorHint  string
+	RuntimeHint   time.Duration
+	RuntimeSource internal.RuntimeSource
 }
 
 // extractTitleHint returns a search-friendly title by stripping format and
@@ -527,30 +658,23 @@ func (h *hollywoodTheatreScraper) extractTitleHint(raw string) string {
 	return normalized
 }
 
-// formatTerms are keywords we strip from titles and collect as subhed (display-only).
-var formatTerms = []string{"70mm", "35mm", "16mm", "8mm", "Digital"}
-
-// titleSuffixes are " in X" and " (X)" for each format term, built at init.
-var titleSuffixes []string
-
-func init() {
-	for _, t := range formatTerms {
-		titleSuffixes = append(titleSuffixes, " in "+t, " ("+t+")")
-	}
-}
+// defaultFormatTerms are keywords we strip from titles and collect as subhed (display-only).
+// "70mm IMAX" precedes "70mm" and "IMAX" so the combined format is stripped whole.
+var defaultFormatTerms = []string{"70mm IMAX", "70mm", "35mm", "16mm", "8mm", "Digital", "DCP", "IMAX"}
 
-var trailingParenRE = regexp.MustCompile(`\s*\(([^)]+)\)\s*$`)
+// trailingParenRE matches the innermost trailing "(...)", so an unbalanced "((2024)" still yields "2024".
+var trailingParenRE = regexp.MustCompile(`\s*\(([^()]+)\)\s*$`)
 
 // stripTrailingParen removes a single trailing "(...)" from s if the content is a format term or 4-digit year.
 // Returns (trimmed s, content to add to subhed, true) or (s, "", false).
-func stripTrailingParen(s string) (string, string, bool) {
+func (h *hollywoodTheatreScraper) stripTrailingParen(s string) (string, string, bool) {
 	loc := trailingParenRE.FindStringSubmatchIndex(s)
 	if loc == nil {
 		return s, "", false
 	}
 	inner := strings.TrimSpace(s[loc[2]:loc[3]])
 	innerUpper := strings.ToUpper(inner)
-	for _, t := range formatTerms {
+	for _, t := range h.formatTerms {
 		if innerUpper == strings.ToUpper(t) {
 			return strings.TrimSpace(s[:loc[0]]), inner, true
 		}
@@ -580,18 +704,18 @@ func (h *hollywoodTheatreScraper) extractTitleHintWithSubhed(raw string) (titleH
 	var parts []string
 
 	// Strip " with ..." so we can then strip format suffixes from the end.
-	if i := strings.Index(strings.ToUpper(s), " WITH "); i > 0 {
+	if i := indexFold(s, " with "); i > 0 {
 		parts = append(parts, strings.TrimSpace(s[i:]))
 		s = strings.TrimSpace(s[:i])
 	}
 
+	// Every pass that doesn't break shortens s, so this terminates.
 	for {
 		unchanged := true
 
 		// Strip one format suffix (" in 35mm", " (Digital)", etc.)
-		upper := strings.ToUpper(s)
-		for _, suf := range titleSuffixes {
-			if strings.HasSuffix(upper, strings.ToUpper(suf)) {
+		for _, suf := range h.titleSuffixes {
+			if hasSuffixFold(s, suf) {
 				stripped := strings.TrimSpace(s[len(s)-len(suf):])
 				if len(stripped) >= 2 && stripped[0] == '(' && stripped[len(stripped)-1] == ')' {
 					stripped = strings.TrimSpace(stripped[1 : len(stripped)-1])
@@ -604,7 +728,7 @@ func (h *hollywoodTheatreScraper) extractTitleHintWithSubhed(raw string) (titleH
 		}
 		if unchanged {
 			// Strip one trailing (...) if it's a format term or year.
-			if trimmed, content, ok := stripTrailingParen(s); ok {
+			if trimmed, content, ok := h.stripTrailingParen(s); ok {
 				parts = append(parts, content)
 				s = trimmed
 				unchanged = false
@@ -614,6 +738,26 @@ func (h *hollywoodTheatreScraper) extractTitleHintWithSubhed(raw string) (titleH
 			break
 		}
 	}
+	// Stripping "(35mm)" from "TITLE ((35mm)" leaves an unclosed "(" that would spoil the search.
+	for strings.HasSuffix(s, "(") {
+		s = strings.TrimSpace(strings.TrimSuffix(s, "("))
+	}
 
 	return strings.TrimSpace(s), strings.Join(parts, " - ")
 }
+
+// hasSuffixFold reports whether s ends with suffix, ignoring case. It compares s's own bytes rather
+// than an upper-cased copy, whose length can differ for non-ASCII text, so s[:len(s)-len(suffix)] is safe.
+func hasSuffixFold(s, suffix string) bool {
+	return len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix)
+}
+
+// indexFold returns the byte offset in s of the first case-insensitive match of substr, or -1.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
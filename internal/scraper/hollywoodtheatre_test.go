@@ -2,11 +2,20 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/browser"
+	"github.com/go-rod/rod"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,6 +49,60 @@ func TestUnit_HollywoodTheatre_ScrapeShowtimes(t *testing.T) {
 	}
 }
 
+func TestUnit_HollywoodTheatre_PagesWithNextAnchor(t *testing.T) {
+	server := MountGoldenTestServer(t, "hollywoodtheatre")
+	s := HollywoodTheatre(WithBaseURL(server.URL), WithClient(server.Client()))
+	req := internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	collect := func(req internal.ListShowtimesRequest) []internal.ShowtimeListItem {
+		t.Helper()
+		ch, err := s.ScrapeShowtimes(t.Context(), req)
+		require.NoError(t, err, "ScrapeShowtimes")
+		var items []internal.ShowtimeListItem
+		for item := range ch {
+			items = append(items, item)
+		}
+		return items
+	}
+
+	all := collect(req)
+	require.Greater(t, len(all), 5, "golden data should span several pages")
+	for _, item := range all {
+		require.Empty(t, item.NextAnchor, "no anchor without a limit")
+	}
+
+	var paged []internal.ShowtimeListItem
+	req.Limit = 2
+	for range len(all) {
+		page := collect(req)
+		require.NotEmpty(t, page)
+		require.LessOrEqual(t, len(page), req.Limit)
+		for _, item := range page[:len(page)-1] {
+			require.Empty(t, item.NextAnchor, "only the last item of a page carries the anchor")
+		}
+		last := page[len(page)-1]
+		last.NextAnchor, req.Anchor = "", last.NextAnchor
+		paged = append(append(paged, page[:len(page)-1]...), last)
+		if req.Anchor == "" {
+			break
+		}
+	}
+	require.Equal(t, all, paged, "paging through every anchor yields the full listing once, in order")
+
+	_, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{Anchor: "not a token"})
+	require.ErrorContains(t, err, "invalid anchor")
+}
+
+func TestUnit_HollywoodTheatre_LinkRels(t *testing.T) {
+	server := MountGoldenTestServer(t, "hollywoodtheatre")
+	rels := goldenLinkRels(t, HollywoodTheatre(WithBaseURL(server.URL), WithClient(server.Client())))
+	require.Positive(t, rels[internal.LinkRelInfo], "event pages")
+	require.Positive(t, rels[internal.LinkRelSeries], "series pages")
+	require.Subset(t, []string{internal.LinkRelInfo, internal.LinkRelSeries}, keys(rels))
+}
+
 func TestNormalizeTitleHint(t *testing.T) {
 	h := HollywoodTheatre().(*hollywoodTheatreScraper)
 	tests := []struct {
@@ -59,6 +122,8 @@ func TestNormalizeTitleHint(t *testing.T) {
 		{"combined format then with", "SOME MOVIE in 70mm with Open Captions", "SOME MOVIE"},
 		{"year in parens", "SOME MOVIE (2024)", "SOME MOVIE"},
 		{"series style parens kept", "SOME MOVIE (Part One)", "SOME MOVIE (Part One)"},
+		{"unbalanced parens", "SOME MOVIE ((2024)", "SOME MOVIE"},
+		{"unclosed paren kept", "SOME MOVIE (Part One", "SOME MOVIE (Part One"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -85,6 +150,10 @@ func TestNormalizeTitleHintWithSuffix(t *testing.T) {
 		{"(Digital)", "MARTY SUPREME (Digital)", "MARTY SUPREME", "Digital"},
 		{"with Open Captions", "THE TESTAMENT OF ANN LEE with Open Captions", "THE TESTAMENT OF ANN LEE", "with Open Captions"},
 		{"combined", "SOME MOVIE in 70mm with Open Captions", "SOME MOVIE", "with Open Captions - in 70mm"},
+		{"in DCP", "SOME MOVIE in DCP", "SOME MOVIE", "in DCP"},
+		{"(IMAX)", "SOME MOVIE (IMAX)", "SOME MOVIE", "IMAX"},
+		{"in 70MM IMAX", "SOME MOVIE in 70MM IMAX", "SOME MOVIE", "in 70MM IMAX"},
+		{"(70mm IMAX)", "SOME MOVIE (70mm IMAX)", "SOME MOVIE", "70mm IMAX"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -97,6 +166,32 @@ func TestNormalizeTitleHintWithSuffix(t *testing.T) {
 	}
 }
 
+func FuzzUnit_HollywoodTheatre_ExtractTitleHintWithSubhed(f *testing.F) {
+	for _, seed := range []string{
+		"SOME MOVIE in 70mm with Open Captions",
+		"SOME MOVIE (70mm IMAX)",
+		"SOME MOVIE (2024) (Digital)",
+		"SOME MOVIE ((35mm)",
+		"SOME MOVIE (35mm))",
+		"SOME MOVIE (Part One",
+		"((((((((((",
+		"ɐɐɐɐɐɐɐɐɐɐɐɐɐɐɐɐ with guests",
+		"x ın 35mm",
+	} {
+		f.Add(seed)
+	}
+	h := HollywoodTheatre(WithClient(http.DefaultClient), HollywoodWithFormatTerms("Dolby Vision")).(*hollywoodTheatreScraper)
+	f.Fuzz(func(t *testing.T, raw string) {
+		title, subhed := h.extractTitleHintWithSubhed(raw)
+		require.True(t, strings.HasPrefix(strings.TrimSpace(raw), title), "title %q should be a prefix of %q", title, raw)
+		require.False(t, strings.HasSuffix(title, "("), "title %q of %q ends in an unclosed paren", title, raw)
+		if utf8.ValidString(raw) {
+			require.True(t, utf8.ValidString(title), "title %q of %q is not valid UTF-8", title, raw)
+			require.True(t, utf8.ValidString(subhed), "subhed %q of %q is not valid UTF-8", subhed, raw)
+		}
+	})
+}
+
 func TestIntegration_HollywoodTheatre_Showtimes(t *testing.T) {
 	scrp := HollywoodTheatre()
 	showtimes, err := scrp.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
@@ -109,3 +204,165 @@ func TestIntegration_HollywoodTheatre_Showtimes(t *testing.T) {
 		t.Logf("showtime: %+v", showtime)
 	}
 }
+
+func TestUnit_ParseCalendarEventsByID_RuntimePrecedence(t *testing.T) {
+	body := []byte(`{"events":[{"events":[
+		{"event_id":1,"runtime":"81 mins","start":"2026-02-20T19:00:00+00:00","end":"2026-02-20T20:45:00+00:00"},
+		{"event_id":2,"runtime":"81 mins","start":"2026-02-20T19:00:00+00:00"},
+		{"event_id":3,"runtime":"","start":"2026-02-20T19:00:00+00:00"}
+	]}]}`)
+	byID, err := parseCalendarEventsByID(body)
+	require.NoError(t, err)
+
+	assert.Equal(t, 105*time.Minute, byID[1].RuntimeHint, "calendar delta wins over runtime string")
+	assert.Equal(t, internal.RuntimeSourceCalendar, byID[1].RuntimeSource)
+	assert.Equal(t, 81*time.Minute, byID[2].RuntimeHint, "runtime string used without an end time")
+	assert.Equal(t, internal.RuntimeSourceListing, byID[2].RuntimeSource)
+	assert.Zero(t, byID[3].RuntimeHint)
+	assert.Equal(t, internal.RuntimeSourceUnknown, byID[3].RuntimeSource)
+}
+
+func TestUnit_HollywoodTheatre_GoldenShowtimes(t *testing.T) {
+	requireGoldenShowtimes(t, "hollywoodtheatre", func(baseURL string, client *http.Client) internal.Scraper {
+		return HollywoodTheatre(WithBaseURL(baseURL), WithClient(client))
+	})
+}
+
+func TestUnit_HollywoodTheatre_CalendarEventsUseRequestedRange(t *testing.T) {
+	handler, err := goldenScrapers["hollywoodtheatre"].MountGolden(t.Context(), filepath.Join(*goldenDir, "hollywoodtheatre"))
+	require.NoError(t, err, "MountGolden")
+	var calendarQueries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/gecko-theme/v1/calendar-events" {
+			calendarQueries = append(calendarQueries, r.URL.Query())
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	s := HollywoodTheatre(WithBaseURL(server.URL), WithClient(server.Client()))
+
+	ch, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 21, 8, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 2, 24, 8, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+	for range ch {
+	}
+	require.Len(t, calendarQueries, 1)
+	assert.Equal(t, "2026-02-21", calendarQueries[0].Get("start_date"))
+	assert.Equal(t, "2026-02-24", calendarQueries[0].Get("end_date"))
+}
+
+func TestUnit_CalendarRangeFromListReq(t *testing.T) {
+	after := time.Date(2026, 2, 21, 8, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 24, 8, 0, 0, 0, time.UTC)
+
+	start, end := calendarRangeFromListReq(internal.ListShowtimesRequest{After: after, Before: before})
+	assert.True(t, start.Equal(after), "start")
+	assert.True(t, end.Equal(before), "end")
+
+	// A lone --before still bounds the query instead of falling back to a year.
+	start, end = calendarRangeFromListReq(internal.ListShowtimesRequest{Before: before})
+	assert.Equal(t, portlandTZ, start.Location())
+	assert.True(t, end.Equal(before), "end")
+
+	start, end = calendarRangeFromListReq(internal.ListShowtimesRequest{After: after})
+	assert.True(t, start.Equal(after), "start")
+	assert.Equal(t, start.AddDate(1, 0, 0), end)
+}
+
+// pinNow makes nowFunc return now for the rest of the test.
+func pinNow(t *testing.T, now time.Time) {
+	t.Helper()
+	prev := nowFunc
+	nowFunc = func() time.Time { return now }
+	t.Cleanup(func() { nowFunc = prev })
+}
+
+func TestUnit_CalendarRanges_PinnedNow(t *testing.T) {
+	// 06:30 UTC on Feb 21 is still Feb 20 in Portland.
+	pinNow(t, time.Date(2026, 2, 21, 6, 30, 0, 0, time.UTC))
+	today := time.Date(2026, 2, 20, 0, 0, 0, 0, portlandTZ)
+
+	start, end := goldenCalendarRange()
+	assert.Equal(t, today, start)
+	assert.Equal(t, today.AddDate(1, 0, 0), end)
+
+	start, end = calendarRangeFromListReq(internal.ListShowtimesRequest{})
+	assert.Equal(t, today, start)
+	assert.Equal(t, today.AddDate(1, 0, 0), end)
+
+	before := time.Date(2026, 2, 24, 8, 0, 0, 0, time.UTC)
+	start, end = calendarRangeFromListReq(internal.ListShowtimesRequest{Before: before})
+	assert.Equal(t, today, start)
+	assert.True(t, end.Equal(before), "end")
+}
+
+func TestUnit_HollywoodWithFormatTerms(t *testing.T) {
+	h := HollywoodTheatre(WithClient(http.DefaultClient), HollywoodWithFormatTerms("Dolby Vision")).(*hollywoodTheatreScraper)
+	title, subhed := h.extractTitleHintWithSubhed("SOME MOVIE in Dolby Vision")
+	assert.Equal(t, "SOME MOVIE", title)
+	assert.Equal(t, "in Dolby Vision", subhed)
+
+	title, subhed = h.extractTitleHintWithSubhed("SOME MOVIE (dcp)")
+	assert.Equal(t, "SOME MOVIE", title, "defaults still apply")
+	assert.Equal(t, "dcp", subhed)
+}
+
+func TestUnit_ParseClock(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantH   int
+		wantM   int
+		wantErr bool
+	}{
+		{"7:30pm", 19, 30, false},
+		{"7:30 PM", 19, 30, false},
+		{"7:30 p.m.", 19, 30, false},
+		{"11:05 A.M.", 11, 5, false},
+		{" 12:00am ", 0, 0, false},
+		{"19:30", 0, 0, true},
+		{"", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseClock(tt.in, portlandTZ)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantH, got.Hour())
+			assert.Equal(t, tt.wantM, got.Minute())
+		})
+	}
+}
+
+// unlaunchableBrowser fails like a headless browser whose chrome could not be started.
+type unlaunchableBrowser struct{}
+
+func (unlaunchableBrowser) WithPage(context.Context, string, func(*rod.Page) error) error {
+	return fmt.Errorf("%w: launch browser: %w", browser.ErrUnavailable, errors.New("chrome not found"))
+}
+
+func (unlaunchableBrowser) FetchJSON(context.Context, string, any) func(*rod.Page) error {
+	return func(*rod.Page) error { return errors.New("unreachable") }
+}
+
+func (unlaunchableBrowser) Close() error { return nil }
+
+func TestUnit_HollywoodTheatre_BrowserLaunchFailureFallsBackToHTTP(t *testing.T) {
+	server := MountGoldenTestServer(t, "hollywoodtheatre")
+	s := HollywoodTheatre(WithBaseURL(server.URL), WithBrowser(unlaunchableBrowser{}), HollywoodWithFallbackClient(server.Client()))
+
+	ch, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	var n int
+	for range ch {
+		n++
+	}
+	require.Positive(t, n, "expected golden showtimes over HTTP")
+}
@@ -0,0 +1,63 @@
+package scraper
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout         = 30 * time.Second
+	defaultMaxIdleConns        = 32
+	defaultMaxIdleConnsPerHost = 8
+)
+
+var defaultHTTPClient = sync.OnceValue(func() *http.Client {
+	return &http.Client{
+		Timeout: defaultHTTPTimeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          defaultMaxIdleConns,
+			MaxIdleConnsPerHost:   defaultMaxIdleConnsPerHost,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 15 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+	}
+})
+
+// DefaultHTTPClient returns the shared client scrapers use when no client is injected.
+// Its transport keeps idle connections per host so fan-out requests (e.g. one per date)
+// reuse them, and the client timeout bounds a hung upstream.
+func DefaultHTTPClient() *http.Client {
+	return defaultHTTPClient()
+}
+
+// resolveURL resolves a link or image URL from a theater feed against the site's base URL, as a
+// browser would: absolute URLs are kept, protocol-relative ones (//host/path) take base's scheme,
+// and relative paths are joined to base. An empty ref stays empty, and a ref or base that doesn't
+// parse leaves ref unchanged.
+func resolveURL(base, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	b, err := url.Parse(base)
+	if err != nil || !b.IsAbs() {
+		return ref
+	}
+	return b.ResolveReference(r).String()
+}
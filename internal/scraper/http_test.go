@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_DefaultHTTPClient(t *testing.T) {
+	client := DefaultHTTPClient()
+	require.Same(t, client, DefaultHTTPClient(), "client should be shared")
+	require.NotZero(t, client.Timeout, "client should time out")
+
+	s, ok := Cinema21().(*cinema21Scraper)
+	require.True(t, ok)
+	require.Same(t, client, s.httpClient, "Cinema21 should use the default client when none is injected")
+}
+
+func TestUnit_ResolveURL(t *testing.T) {
+	const base = "https://www.hollywoodtheatre.org/wp-json/gecko-theme/v1/"
+	tests := []struct {
+		name string
+		base string
+		ref  string
+		want string
+	}{
+		{name: "absolute", base: base, ref: "https://cdn.example.com/poster.jpg", want: "https://cdn.example.com/poster.jpg"},
+		{name: "protocol-relative", base: base, ref: "//cdn.example.com/poster.jpg", want: "https://cdn.example.com/poster.jpg"},
+		{name: "root-relative", base: base, ref: "/events/heat/", want: "https://www.hollywoodtheatre.org/events/heat/"},
+		{name: "path-relative", base: base, ref: "show-list?view=today", want: "https://www.hollywoodtheatre.org/wp-json/gecko-theme/v1/show-list?view=today"},
+		{name: "dot-segments", base: base, ref: "../../../uploads/heat.jpg", want: "https://www.hollywoodtheatre.org/uploads/heat.jpg"},
+		{name: "surrounding space", base: base, ref: " /events/heat/ ", want: "https://www.hollywoodtheatre.org/events/heat/"},
+		{name: "empty", base: base, ref: "", want: ""},
+		{name: "no base", base: "", ref: "/events/heat/", want: "/events/heat/"},
+		{name: "unparseable ref", base: base, ref: "http://[::1", want: "http://[::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, resolveURL(tt.base, tt.ref))
+		})
+	}
+}
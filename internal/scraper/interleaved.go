@@ -1,9 +1,11 @@
 package scraper
 
 import (
+	"cmp"
 	"container/heap"
 	"context"
 	"log/slog"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -43,12 +45,32 @@ type interleavedScraper struct {
 	scrapers []internal.Scraper
 }
 
+// Descriptor length-prefixes each child descriptor ("interleaved:1:A,1:B,") so child descriptors
+// containing commas or colons can't make two different child sets produce the same cache key.
 func (s *interleavedScraper) Descriptor() string {
-	parts := make([]string, 0, len(s.scrapers))
+	var b strings.Builder
+	b.WriteString("interleaved:")
 	for _, sc := range s.scrapers {
-		parts = append(parts, sc.Descriptor())
+		d := sc.Descriptor()
+		b.WriteString(strconv.Itoa(len(d)))
+		b.WriteByte(':')
+		b.WriteString(d)
+		b.WriteByte(',')
 	}
-	return "interleaved:" + strings.Join(parts, ",")
+	return b.String()
+}
+
+// Capabilities returns only the capabilities every interleaved scraper shares, since a field
+// missing from any one site can't be relied on across the merged stream.
+func (s *interleavedScraper) Capabilities() internal.Capabilities {
+	if len(s.scrapers) == 0 {
+		return 0
+	}
+	caps := s.scrapers[0].Capabilities()
+	for _, sc := range s.scrapers[1:] {
+		caps &= sc.Capabilities()
+	}
+	return caps
 }
 
 func (s *interleavedScraper) ScrapeShowtimes(
@@ -64,10 +86,11 @@ func (s *interleavedScraper) ScrapeShowtimes(
 }
 
 // mergedSlot is sent from each scraper goroutine to the merge loop.
-// Item is nil when that scraper's stream has closed.
+// done is set (and item empty) when that scraper's stream has closed.
 type mergedSlot struct {
-	item  *internal.ShowtimeListItem
+	item  internal.ShowtimeListItem
 	index int // scraper index
+	done  bool
 }
 
 func (s *interleavedScraper) run(
@@ -80,24 +103,35 @@ func (s *interleavedScraper) run(
 	var wg sync.WaitGroup
 	wg.Add(n)
 	for i, sc := range s.scrapers {
-		sc := sc
-		i := i
 		go func() {
 			defer wg.Done()
+			done := func() {
+				select {
+				case mergeChan <- mergedSlot{index: i, done: true}:
+				case <-ctx.Done():
+				}
+			}
+			// A panicking scraper ends its own stream; the other sites' results keep flowing.
+			defer func() {
+				if r := recover(); r != nil {
+					logScrapePanic(sc.Descriptor(), r)
+					done()
+				}
+			}()
 			ch, err := sc.ScrapeShowtimes(ctx, req)
 			if err != nil {
 				slog.Warn("interleaved: scraper failed", "descriptor", sc.Descriptor(), "error", err)
-				mergeChan <- mergedSlot{index: i} // signal stream exhausted
+				done()
 				return
 			}
 			for it := range ch {
 				select {
-				case mergeChan <- mergedSlot{item: &it, index: i}:
+				case mergeChan <- mergedSlot{item: it, index: i}:
 				case <-ctx.Done():
 					return
 				}
 			}
-			mergeChan <- mergedSlot{index: i} // signal stream exhausted
+			done()
 		}()
 	}
 	go func() {
@@ -105,11 +139,12 @@ func (s *interleavedScraper) run(
 		close(mergeChan)
 	}()
 
-	// K-way merge: one slot per scraper (current head), heap ordered by StartTime.
+	// K-way merge: one head per scraper in buffer, heap ordered by StartTime.
 	// Each stream may send multiple items before we pop; we only keep the current head in buffer,
 	// and queue the rest in pending[i] until we pop that stream.
-	buffer := make([]*internal.ShowtimeListItem, n)
-	pending := make([][]*internal.ShowtimeListItem, n)
+	buffer := make([]internal.ShowtimeListItem, n)
+	full := make([]bool, n) // buffer[i] holds an unsent head
+	pending := make([]itemQueue, n)
 	closed := make([]bool, n)
 	h := &mergeHeap{buffer: buffer}
 	heap.Init(h)
@@ -120,32 +155,35 @@ func (s *interleavedScraper) run(
 	sent := 0
 	canPop := func() bool {
 		for i := range n {
-			if !closed[i] && buffer[i] == nil && len(pending[i]) == 0 {
+			if !closed[i] && !full[i] && pending[i].len() == 0 {
 				return false
 			}
 		}
 		return true
 	}
-	refill := func(j int) {
-		if len(pending[j]) > 0 {
-			buffer[j] = pending[j][0]
-			pending[j] = pending[j][1:]
+	// pop removes the earliest head and refills its slot from that stream's pending queue.
+	pop := func() internal.ShowtimeListItem {
+		j := heap.Pop(h).(int)
+		item := buffer[j]
+		buffer[j], full[j] = internal.ShowtimeListItem{}, false
+		if next, ok := pending[j].pop(); ok {
+			buffer[j], full[j] = next, true
 			heap.Push(h, j)
 		}
+		return item
+	}
+	send := func(item internal.ShowtimeListItem) bool {
+		select {
+		case out <- item:
+			sent++
+			return true
+		case <-ctx.Done():
+			return false
+		}
 	}
 	for {
 		if canPop() && h.Len() > 0 && sent < limit {
-			j := heap.Pop(h).(int)
-			item := buffer[j]
-			buffer[j] = nil
-			refill(j)
-			if item == nil {
-				continue
-			}
-			select {
-			case out <- *item:
-				sent++
-			case <-ctx.Done():
+			if !send(pop()) {
 				return
 			}
 			continue
@@ -153,38 +191,63 @@ func (s *interleavedScraper) run(
 		slot, ok := <-mergeChan
 		if !ok {
 			for h.Len() > 0 && sent < limit {
-				j := heap.Pop(h).(int)
-				item := buffer[j]
-				buffer[j] = nil
-				refill(j)
-				if item != nil {
-					select {
-					case out <- *item:
-						sent++
-					case <-ctx.Done():
-						return
-					}
+				if !send(pop()) {
+					return
 				}
 			}
 			return
 		}
-		if slot.item == nil {
+		if slot.done {
 			closed[slot.index] = true
 			continue
 		}
-		if buffer[slot.index] == nil {
-			buffer[slot.index] = slot.item
+		if !full[slot.index] {
+			buffer[slot.index], full[slot.index] = slot.item, true
 			heap.Push(h, slot.index)
 		} else {
-			pending[slot.index] = append(pending[slot.index], slot.item)
+			pending[slot.index].push(slot.item)
 		}
 	}
 }
 
-// mergeHeap is a min-heap of scraper indices ordered by buffer[i].Showtime.StartTime.
+// itemQueue is a FIFO ring buffer of items waiting behind a stream's head. Unlike re-slicing an
+// append-built slice, popped slots are reused and cleared, so a long stream doesn't keep growing
+// or pinning its backing array.
+type itemQueue struct {
+	items []internal.ShowtimeListItem
+	head  int
+	n     int
+}
+
+func (q *itemQueue) len() int { return q.n }
+
+func (q *itemQueue) push(item internal.ShowtimeListItem) {
+	if q.n == len(q.items) {
+		grown := make([]internal.ShowtimeListItem, max(4, 2*len(q.items)))
+		for i := range q.n {
+			grown[i] = q.items[(q.head+i)%len(q.items)]
+		}
+		q.items, q.head = grown, 0
+	}
+	q.items[(q.head+q.n)%len(q.items)] = item
+	q.n++
+}
+
+func (q *itemQueue) pop() (internal.ShowtimeListItem, bool) {
+	if q.n == 0 {
+		return internal.ShowtimeListItem{}, false
+	}
+	item := q.items[q.head]
+	q.items[q.head] = internal.ShowtimeListItem{}
+	q.head = (q.head + 1) % len(q.items)
+	q.n--
+	return item, true
+}
+
+// mergeHeap is a min-heap of scraper indices ordered by buffer[i] (see compareItems).
 type mergeHeap struct {
 	indices []int
-	buffer  []*internal.ShowtimeListItem
+	buffer  []internal.ShowtimeListItem
 }
 
 func (h *mergeHeap) Len() int {
@@ -192,7 +255,13 @@ func (h *mergeHeap) Len() int {
 }
 
 func (h *mergeHeap) Less(i, j int) bool {
-	return h.buffer[h.indices[i]].Showtime.StartTime.Before(h.buffer[h.indices[j]].Showtime.StartTime)
+	return compareItems(h.buffer[h.indices[i]], h.buffer[h.indices[j]]) < 0
+}
+
+// compareItems orders items by start time, then by ID so showtimes at the same minute come out in
+// the same order on every run.
+func compareItems(a, b internal.ShowtimeListItem) int {
+	return cmp.Or(a.Showtime.StartTime.Compare(b.Showtime.StartTime), cmp.Compare(a.Showtime.ID, b.Showtime.ID))
 }
 
 func (h *mergeHeap) Swap(i, j int) {
@@ -2,20 +2,29 @@ package scraper
 
 import (
 	"context"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/browser"
+	"github.com/go-rod/rod"
 	"github.com/stretchr/testify/require"
 )
 
 // mockScraper returns a fixed list of items and implements internal.Scraper.
 type mockScraper struct {
-	descriptor string
-	items      []internal.ShowtimeListItem
+	descriptor   string
+	items        []internal.ShowtimeListItem
+	capabilities internal.Capabilities
 }
 
 func (m *mockScraper) Descriptor() string { return m.descriptor }
+
+func (m *mockScraper) Capabilities() internal.Capabilities { return m.capabilities }
+
 func (m *mockScraper) ScrapeShowtimes(
 	ctx context.Context,
 	_ internal.ListShowtimesRequest,
@@ -56,7 +65,7 @@ func TestUnit_Interleaved_MergesByStartTime(t *testing.T) {
 	}
 
 	merged := Interleaved(a, b)
-	require.Equal(t, "interleaved:A,B", merged.Descriptor())
+	require.Equal(t, "interleaved:1:A,1:B,", merged.Descriptor())
 
 	ch, err := merged.ScrapeShowtimes(context.Background(), internal.ListShowtimesRequest{
 		After:  t1.Add(-time.Hour),
@@ -110,3 +119,194 @@ func TestUnit_Interleaved_RespectsLimit(t *testing.T) {
 	require.Len(t, got, 1)
 	require.Equal(t, "1", got[0].Showtime.ID)
 }
+
+func TestUnit_Interleaved_CapabilitiesIntersect(t *testing.T) {
+	a := &mockScraper{descriptor: "a", capabilities: internal.CapabilityDirector | internal.CapabilityRuntime}
+	b := &mockScraper{descriptor: "b", capabilities: internal.CapabilityRuntime}
+	caps := Interleaved(a, b).Capabilities()
+	require.True(t, caps.Has(internal.CapabilityRuntime))
+	require.False(t, caps.Has(internal.CapabilityDirector))
+	require.Zero(t, Interleaved().Capabilities(), "no scrapers, no capabilities")
+}
+
+func TestUnit_Interleaved_DescriptorsDoNotCollide(t *testing.T) {
+	descriptor := func(children ...string) string {
+		scrapers := make([]internal.Scraper, len(children))
+		for i, d := range children {
+			scrapers[i] = &mockScraper{descriptor: d}
+		}
+		return Interleaved(scrapers...).Descriptor()
+	}
+
+	sets := [][]string{
+		{"A,B", "C"},
+		{"A", "B,C"},
+		{"A", "B", "C"},
+		{"1:A", "B"},
+		{"A", "1:B"},
+		{"A,1:B", "C"},
+		{"A", "B,1:C"},
+		{"", "A,B"},
+		{"A,B", ""},
+	}
+	seen := make(map[string][]string, len(sets))
+	for _, set := range sets {
+		d := descriptor(set...)
+		prev, dup := seen[d]
+		require.False(t, dup, "%q and %q both produce descriptor %q", prev, set, d)
+		seen[d] = set
+	}
+}
+
+// interleavedSources returns n mock scrapers of perSource items each, with start times interleaved across sources.
+func interleavedSources(n, perSource int) []internal.Scraper {
+	base := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	scrapers := make([]internal.Scraper, n)
+	for i := range n {
+		items := make([]internal.ShowtimeListItem, perSource)
+		for k := range items {
+			items[k] = internal.ShowtimeListItem{Showtime: internal.SourceShowtime{
+				ID:        strconv.Itoa(i) + "-" + strconv.Itoa(k),
+				StartTime: base.Add(time.Duration(k*n+i) * time.Minute),
+			}}
+		}
+		scrapers[i] = &mockScraper{descriptor: strconv.Itoa(i), items: items}
+	}
+	return scrapers
+}
+
+// rendezvousBrowser holds each WithPage until want callers are inside at once, then fails them as
+// an unlaunched browser would. A caller that gives up waiting records that pages were serialized.
+type rendezvousBrowser struct {
+	want       int
+	mu         sync.Mutex
+	inside     int
+	all        chan struct{}
+	serialized bool
+}
+
+func (b *rendezvousBrowser) WithPage(ctx context.Context, _ string, _ func(*rod.Page) error) error {
+	b.mu.Lock()
+	if b.inside++; b.inside == b.want {
+		close(b.all)
+	}
+	b.mu.Unlock()
+	select {
+	case <-b.all:
+	case <-time.After(5 * time.Second):
+		b.mu.Lock()
+		b.serialized = true
+		b.mu.Unlock()
+	case <-ctx.Done():
+	}
+	return browser.ErrDisabled
+}
+
+func (b *rendezvousBrowser) FetchJSON(context.Context, string, any) func(*rod.Page) error {
+	return func(*rod.Page) error { return nil }
+}
+
+func (b *rendezvousBrowser) Close() error { return nil }
+
+func TestUnit_Interleaved_SharedBrowserScrapesConcurrently(t *testing.T) {
+	server := MountGoldenTestServer(t, "hollywoodtheatre")
+	shared := &rendezvousBrowser{want: 2, all: make(chan struct{})}
+	sc := Interleaved(
+		HollywoodTheatre(WithBaseURL(server.URL), WithBrowser(shared), HollywoodWithFallbackClient(server.Client())),
+		Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithBrowser(shared)),
+	)
+
+	ch, err := sc.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	var n int
+	for range ch {
+		n++
+	}
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	require.False(t, shared.serialized, "scrapers should hold pages at the same time")
+	require.Positive(t, n, "Hollywood should fall back to HTTP once the browser fails")
+}
+
+// panickingScraper sends its items from a goroutine guarded like the real scrapers', then panics;
+// with early set it panics before returning a stream at all.
+type panickingScraper struct {
+	mockScraper
+	early bool
+}
+
+func (p *panickingScraper) ScrapeShowtimes(ctx context.Context, req internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	if p.early {
+		panic("boom before streaming")
+	}
+	ch := make(chan internal.ShowtimeListItem)
+	go func() {
+		defer close(ch)
+		defer recoverScrape(p.Descriptor(), req)
+		for _, it := range p.items {
+			select {
+			case ch <- it:
+			case <-ctx.Done():
+				return
+			}
+		}
+		panic("boom mid-stream")
+	}()
+	return ch, nil
+}
+
+func TestUnit_Interleaved_PanickingScraperKeepsOthers(t *testing.T) {
+	base := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	healthy := &mockScraper{descriptor: "healthy", items: []internal.ShowtimeListItem{
+		{Showtime: internal.SourceShowtime{ID: "a", StartTime: base}},
+		{Showtime: internal.SourceShowtime{ID: "c", StartTime: base.Add(2 * time.Hour)}},
+	}}
+	midStream := &panickingScraper{mockScraper: mockScraper{descriptor: "mid", items: []internal.ShowtimeListItem{
+		{Showtime: internal.SourceShowtime{ID: "b", StartTime: base.Add(time.Hour)}},
+	}}}
+	early := &panickingScraper{mockScraper: mockScraper{descriptor: "early"}, early: true}
+
+	ch, err := Interleaved(healthy, midStream, early).ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{})
+	require.NoError(t, err)
+	var ids []string
+	for item := range ch {
+		ids = append(ids, item.Showtime.ID)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, ids)
+}
+
+func TestUnit_Interleaved_ManySourcesInOrder(t *testing.T) {
+	const n, perSource = 7, 500
+	ch, err := Interleaved(interleavedSources(n, perSource)...).ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{})
+	require.NoError(t, err)
+	var got []internal.ShowtimeListItem
+	for it := range ch {
+		got = append(got, it)
+	}
+	require.Len(t, got, n*perSource)
+	require.True(t, slices.IsSortedFunc(got, func(a, b internal.ShowtimeListItem) int {
+		return a.Showtime.StartTime.Compare(b.Showtime.StartTime)
+	}), "merged stream is ordered by start time")
+}
+
+func BenchmarkInterleaved(b *testing.B) {
+	const n, perSource = 8, 2000
+	scrapers := interleavedSources(n, perSource)
+	b.ReportAllocs()
+	for b.Loop() {
+		ch, err := Interleaved(scrapers...).ScrapeShowtimes(b.Context(), internal.ListShowtimesRequest{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		count := 0
+		for range ch {
+			count++
+		}
+		if count != n*perSource {
+			b.Fatalf("got %d items, want %d", count, n*perSource)
+		}
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/drewfead/pdx-watcher/proto"
 )
 
-type noneScraper struct{}
+type noneScraper struct {
+	internal.NoCapabilities
+}
 
 func (s *noneScraper) Descriptor() string {
-	return proto.PdxSite_None.Descriptor().Syntax().GoString()
+	return SiteDescriptor(proto.PdxSite_None)
 }
 
 func (s *noneScraper) ScrapeShowtimes(
@@ -1,10 +1,18 @@
 package scraper
 
 import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"maps"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
 	"github.com/stretchr/testify/require"
@@ -16,7 +24,13 @@ var goldenScrapers = map[string]internal.GoldenScraper{
 	"cinema21":         Cinema21().(internal.GoldenScraper),
 }
 
-const goldenDir = "golden"
+// goldenDir is the root holding one golden fixture directory per scraper. Override it to pull
+// or test against an alternate fixture set: go test ./internal/scraper -golden-dir=/path/to/golden
+var goldenDir = flag.String("golden-dir", "golden", "root directory of golden fixtures")
+
+// updateExpected rewrites the <golden-dir>/<scraper>.expected.json files from the current parsers
+// instead of comparing against them: go test ./internal/scraper -run GoldenShowtimes -update-expected
+var updateExpected = flag.Bool("update-expected", false, "rewrite expected scraper output from the golden fixtures")
 
 func TestPrep_PullAllGolden(t *testing.T) {
 	if os.Getenv("PREP") != "1" {
@@ -26,7 +40,7 @@ func TestPrep_PullAllGolden(t *testing.T) {
 	for name, s := range goldenScrapers {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			dir := filepath.Join(goldenDir, name)
+			dir := filepath.Join(*goldenDir, name)
 			require.NoError(t, os.RemoveAll(dir), "clean golden dir")
 			require.NoError(t, os.MkdirAll(dir, 0o750), "create golden dir")
 			err := s.PullGolden(t.Context(), dir)
@@ -38,7 +52,13 @@ func TestPrep_PullAllGolden(t *testing.T) {
 
 func MountGoldenTestServer(t *testing.T, scraperName string) *httptest.Server {
 	t.Helper()
-	dir := filepath.Join(goldenDir, scraperName)
+	return mountGoldenTestServerFrom(t, *goldenDir, scraperName)
+}
+
+// mountGoldenTestServerFrom serves scraperName's golden files from root/<scraperName>.
+func mountGoldenTestServerFrom(t *testing.T, root, scraperName string) *httptest.Server {
+	t.Helper()
+	dir := filepath.Join(root, scraperName)
 	s := goldenScrapers[scraperName]
 	handler, err := s.MountGolden(t.Context(), dir)
 	require.NoError(t, err, "MountGolden")
@@ -46,3 +66,114 @@ func MountGoldenTestServer(t *testing.T, scraperName string) *httptest.Server {
 	t.Cleanup(server.Close)
 	return server
 }
+
+func TestUnit_MountGolden_CustomDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.CopyFS(filepath.Join(root, "cinema21"), os.DirFS(filepath.Join(*goldenDir, "cinema21"))), "copy golden files")
+
+	server := mountGoldenTestServerFrom(t, root, "cinema21")
+	s := Cinema21(Cinema21WithBaseURL(server.URL), Cinema21WithClient(server.Client()))
+	ch, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+
+	var items []internal.ShowtimeListItem
+	for item := range ch {
+		items = append(items, item)
+	}
+	require.NotEmpty(t, items, "expected showtimes served from the copied golden dir")
+}
+
+// goldenLinkRels scrapes s over the golden date range and counts screening links by rel.
+// Every link must carry a rel.
+func goldenLinkRels(t *testing.T, s internal.Scraper) map[string]int {
+	t.Helper()
+	ch, err := s.ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+	rels := make(map[string]int)
+	for item := range ch {
+		for _, link := range item.Showtime.Screening.Links {
+			require.NotEmpty(t, link.Rel, "link %q (%s) has no rel", link.Display, link.Href)
+			rels[link.Rel]++
+		}
+	}
+	return rels
+}
+
+// keys returns m's keys, sorted.
+func keys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// goldenBaseURL stands in for the test server's random address so ids and links derived from the
+// base URL are stable across runs.
+const goldenBaseURL = "https://golden.test"
+
+// redirectTransport sends every request to target, whatever host it was addressed to.
+type redirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	req.Host = rt.target.Host
+	return rt.base.RoundTrip(req)
+}
+
+// requireGoldenShowtimes scrapes scraperName's golden fixtures over the golden date range and compares
+// the parsed showtimes with <golden-dir>/<scraperName>.expected.json. newScraper is given a fixed base
+// URL and a client that serves it from the fixtures. Times are normalized to UTC and showtimes are
+// ordered by start time then id, so only parsing changes (not zone or fetch order) fail the test.
+func requireGoldenShowtimes(t *testing.T, scraperName string, newScraper func(baseURL string, client *http.Client) internal.Scraper) {
+	t.Helper()
+	server := MountGoldenTestServer(t, scraperName)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err, "parse server URL")
+	client := &http.Client{Transport: redirectTransport{target: target, base: server.Client().Transport}}
+
+	ch, err := newScraper(goldenBaseURL, client).ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+		After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err, "ScrapeShowtimes")
+	var got []internal.SourceShowtime
+	for item := range ch {
+		got = append(got, normalizeShowtime(item.Showtime))
+	}
+	slices.SortFunc(got, func(a, b internal.SourceShowtime) int {
+		return cmp.Or(a.StartTime.Compare(b.StartTime), cmp.Compare(a.ID, b.ID))
+	})
+	require.NotEmpty(t, got, "golden fixtures produced no showtimes")
+
+	path := filepath.Join(*goldenDir, scraperName+".expected.json")
+	if *updateExpected {
+		data, err := json.MarshalIndent(got, "", "  ")
+		require.NoError(t, err, "Marshal")
+		require.NoError(t, os.WriteFile(path, append(data, '\n'), 0o600), "write expected output")
+		t.Logf("wrote %d showtimes to %s", len(got), path)
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "read expected output (regenerate with -update-expected)")
+	var want []internal.SourceShowtime
+	require.NoError(t, json.Unmarshal(data, &want), "Unmarshal expected output")
+	for i := range want {
+		want[i] = normalizeShowtime(want[i])
+	}
+	require.Equal(t, want, got, "parsed showtimes differ from %s (regenerate with -update-expected if intended)", path)
+}
+
+func normalizeShowtime(s internal.SourceShowtime) internal.SourceShowtime {
+	s.StartTime = s.StartTime.UTC().Round(0)
+	s.EndTime = s.EndTime.UTC().Round(0)
+	return s
+}
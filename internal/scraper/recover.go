@@ -0,0 +1,22 @@
+package scraper
+
+import (
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/drewfead/pdx-watcher/internal"
+)
+
+// recoverScrape, deferred in a scraper's goroutine, turns a panic into an error log so only that
+// site's stream ends; the process and the other sites keep going. The request is told its results
+// are incomplete so they aren't cached.
+func recoverScrape(descriptor string, req internal.ListShowtimesRequest) {
+	if r := recover(); r != nil {
+		logScrapePanic(descriptor, r)
+		req.ReportIncomplete()
+	}
+}
+
+func logScrapePanic(descriptor string, r any) {
+	slog.Error("scraper panicked; ending its stream", "descriptor", descriptor, "panic", r, "stack", string(debug.Stack()))
+}
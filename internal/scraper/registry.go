@@ -3,6 +3,8 @@ package scraper
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/drewfead/pdx-watcher/internal"
 	"github.com/drewfead/pdx-watcher/proto"
@@ -13,6 +15,10 @@ type Registry interface {
 	// AllSites returns the list of PdxSite values that have a scraper registered (excluding None).
 	// Used when --from is omitted to build an interleaved scraper for all theaters.
 	AllSites() []proto.PdxSite
+	// InvalidateAll drops the cached results of every registered scraper that caches (see internal.Invalidator).
+	InvalidateAll()
+	// SiteGroup returns the sites in the named group (case-insensitive), if one is registered.
+	SiteGroup(name string) ([]proto.PdxSite, bool)
 }
 
 type ScraperMiddleware func(internal.Scraper) internal.Scraper
@@ -26,9 +32,24 @@ func NewRegistry(opts ...RegistryOption) Registry {
 	for _, opt := range opts {
 		opt(r)
 	}
+	for descriptor, scraper := range r.scrapers {
+		for _, m := range r.defaultMiddleware {
+			scraper = m(scraper)
+		}
+		r.scrapers[descriptor] = scraper
+	}
 	return r
 }
 
+// WithDefaultMiddleware wraps every registered scraper, regardless of option order, in m. Default
+// middleware sits outside any middleware passed with the scraper itself; as there, later middleware
+// wraps earlier.
+func WithDefaultMiddleware(m ...ScraperMiddleware) RegistryOption {
+	return func(r *registry) {
+		r.defaultMiddleware = append(r.defaultMiddleware, m...)
+	}
+}
+
 func WithScraper(descriptor string, scraper internal.Scraper, middleware ...ScraperMiddleware) RegistryOption {
 	return func(r *registry) {
 		for _, m := range middleware {
@@ -38,9 +59,25 @@ func WithScraper(descriptor string, scraper internal.Scraper, middleware ...Scra
 	}
 }
 
+// WithSiteGroup names a set of sites that --from can select together, e.g. "repertory".
+func WithSiteGroup(name string, sites ...proto.PdxSite) RegistryOption {
+	return func(r *registry) {
+		if r.groups == nil {
+			r.groups = make(map[string][]proto.PdxSite)
+		}
+		r.groups[strings.ToLower(name)] = slices.Clone(sites)
+	}
+}
+
+// SiteDescriptor returns the descriptor for a site's scraper: its enum name (e.g. "HollywoodTheatre").
+// Registry keys and cache keys use it, so it must be distinct per site.
+func SiteDescriptor(site proto.PdxSite) string {
+	return site.String()
+}
+
 func WithScraperForSite(site proto.PdxSite, scraper internal.Scraper, middleware ...ScraperMiddleware) RegistryOption {
 	return func(r *registry) {
-		WithScraper(site.String(), scraper, middleware...)(r)
+		WithScraper(SiteDescriptor(site), scraper, middleware...)(r)
 		if site != proto.PdxSite_None {
 			r.allSites = append(r.allSites, site)
 		}
@@ -48,8 +85,10 @@ func WithScraperForSite(site proto.PdxSite, scraper internal.Scraper, middleware
 }
 
 type registry struct {
-	scrapers map[string]internal.Scraper
-	allSites []proto.PdxSite
+	scrapers          map[string]internal.Scraper
+	allSites          []proto.PdxSite
+	defaultMiddleware []ScraperMiddleware
+	groups            map[string][]proto.PdxSite
 }
 
 func (r *registry) AllSites() []proto.PdxSite {
@@ -61,6 +100,19 @@ func (r *registry) AllSites() []proto.PdxSite {
 	return out
 }
 
+func (r *registry) InvalidateAll() {
+	for _, s := range r.scrapers {
+		if inv, ok := s.(internal.Invalidator); ok {
+			inv.InvalidateAll()
+		}
+	}
+}
+
+func (r *registry) SiteGroup(name string) ([]proto.PdxSite, bool) {
+	sites, ok := r.groups[strings.ToLower(name)]
+	return slices.Clone(sites), ok
+}
+
 var ErrScraperNotFound = errors.New("scraper not found")
 
 func (r *registry) GetScraper(descriptor string) (internal.Scraper, error) {
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_SiteDescriptors_Distinct(t *testing.T) {
+	scrapers := map[proto.PdxSite]internal.Scraper{
+		proto.PdxSite_None:             None(),
+		proto.PdxSite_HollywoodTheatre: HollywoodTheatre(WithClient(http.DefaultClient)),
+		proto.PdxSite_Cinemagic:        Cinemagic(CinemagicWithClient(http.DefaultClient)),
+		proto.PdxSite_Cinema21:         Cinema21(Cinema21WithClient(http.DefaultClient)),
+	}
+	require.Len(t, scrapers, len(proto.PdxSite_name), "every site should be covered")
+	seen := make(map[string]proto.PdxSite)
+	for site, sc := range scrapers {
+		d := sc.Descriptor()
+		require.Equal(t, SiteDescriptor(site), d, "%s descriptor", site)
+		prev, dup := seen[d]
+		require.False(t, dup, "%s and %s share descriptor %q", prev, site, d)
+		seen[d] = site
+	}
+}
+
+func TestUnit_Registry_GetScraperBySiteDescriptor(t *testing.T) {
+	var opts []RegistryOption
+	for value := range proto.PdxSite_name {
+		site := proto.PdxSite(value)
+		opts = append(opts, WithScraperForSite(site, &mockScraper{descriptor: SiteDescriptor(site)}))
+	}
+	registry := NewRegistry(opts...)
+	for value := range proto.PdxSite_name {
+		site := proto.PdxSite(value)
+		sc, err := registry.GetScraper(SiteDescriptor(site))
+		require.NoError(t, err, "%s", site)
+		require.Equal(t, SiteDescriptor(site), sc.Descriptor())
+	}
+	require.Len(t, registry.AllSites(), len(proto.PdxSite_name)-1, "None is registered but not listed")
+}
+
+// tagMiddleware appends tag to the wrapped scraper's descriptor so tests can see wrapping order.
+func tagMiddleware(tag string) ScraperMiddleware {
+	return func(inner internal.Scraper) internal.Scraper {
+		return &mockScraper{descriptor: inner.Descriptor() + "+" + tag}
+	}
+}
+
+func TestUnit_Registry_WithDefaultMiddleware(t *testing.T) {
+	registry := NewRegistry(
+		WithScraperForSite(proto.PdxSite_HollywoodTheatre, &mockScraper{descriptor: "H"}, tagMiddleware("site")),
+		WithDefaultMiddleware(tagMiddleware("a"), tagMiddleware("b")),
+		WithScraperForSite(proto.PdxSite_Cinema21, &mockScraper{descriptor: "C"}),
+	)
+	for site, want := range map[proto.PdxSite]string{
+		proto.PdxSite_HollywoodTheatre: "H+site+a+b",
+		proto.PdxSite_Cinema21:         "C+a+b",
+	} {
+		sc, err := registry.GetScraper(SiteDescriptor(site))
+		require.NoError(t, err)
+		require.Equal(t, want, sc.Descriptor(), "%s", site)
+	}
+}
@@ -0,0 +1,49 @@
+// Package scrapertest provides a fake scraper and log capture for tests.
+package scrapertest
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
+)
+
+// Static is a Scraper that emits a fixed list of showtimes regardless of the request.
+type Static struct {
+	internal.NoCapabilities
+	Showtimes []internal.SourceShowtime
+	Site      proto.PdxSite // set on every item
+	Anchor    string        // set on the last item, as a paging scraper would
+	Err       error         // returned instead of showtimes when set
+}
+
+func (Static) Descriptor() string { return "static" }
+
+func (s Static) ScrapeShowtimes(context.Context, internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	ch := make(chan internal.ShowtimeListItem, len(s.Showtimes))
+	for i, st := range s.Showtimes {
+		item := internal.ShowtimeListItem{Showtime: st, Site: s.Site}
+		if i == len(s.Showtimes)-1 {
+			item.NextAnchor = s.Anchor
+		}
+		ch <- item
+	}
+	close(ch)
+	return ch, nil
+}
+
+// CaptureLogs redirects the default slog logger into a buffer, at debug level, for the duration of the test.
+func CaptureLogs(t testing.TB) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
@@ -0,0 +1,35 @@
+package scraper
+
+import (
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/geo"
+	"github.com/drewfead/pdx-watcher/proto"
+)
+
+// venues describes the theater behind each site.
+var venues = map[proto.PdxSite]internal.VenueInfo{
+	proto.PdxSite_HollywoodTheatre: {
+		Name:     hollywoodTheatreVenueName,
+		Address:  hollywoodTheatreLocation,
+		Timezone: portlandTimezoneCode,
+		Location: geo.Point{Lat: 45.5355, Lon: -122.6205},
+	},
+	proto.PdxSite_Cinemagic: {
+		Name:     cinemagicVenueName,
+		Address:  cinemagicLocation,
+		Timezone: portlandTimezoneCode,
+		Location: geo.Point{Lat: 45.5121, Lon: -122.6445},
+	},
+	proto.PdxSite_Cinema21: {
+		Name:     cinema21VenueName,
+		Address:  cinema21Location,
+		Timezone: portlandTimezoneCode,
+		Location: geo.Point{Lat: 45.5265, Lon: -122.6945},
+	},
+}
+
+// Venue returns the venue info for site; ok is false for sites without a known venue.
+func Venue(site proto.PdxSite) (internal.VenueInfo, bool) {
+	v, ok := venues[site]
+	return v, ok
+}
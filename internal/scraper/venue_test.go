@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Scrapers_SetVenueName(t *testing.T) {
+	tests := []struct {
+		site   proto.PdxSite
+		golden string
+		build  func(server *httptest.Server) internal.Scraper
+		want   string
+	}{
+		{
+			site:   proto.PdxSite_HollywoodTheatre,
+			golden: "hollywoodtheatre",
+			build: func(server *httptest.Server) internal.Scraper {
+				return HollywoodTheatre(WithBaseURL(server.URL), WithClient(server.Client()))
+			},
+			want: "Hollywood Theatre",
+		},
+		{
+			site:   proto.PdxSite_Cinemagic,
+			golden: "cinemagic",
+			build: func(server *httptest.Server) internal.Scraper {
+				return Cinemagic(CinemagicWithBaseURL(server.URL), CinemagicWithClient(server.Client()))
+			},
+			want: "Cinemagic",
+		},
+		{
+			site:   proto.PdxSite_Cinema21,
+			golden: "cinema21",
+			build: func(server *httptest.Server) internal.Scraper {
+				return Cinema21(Cinema21WithBaseURL(server.URL), Cinema21WithClient(server.Client()))
+			},
+			want: "Cinema 21",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.site.String(), func(t *testing.T) {
+			server := MountGoldenTestServer(t, tt.golden)
+			ch, err := tt.build(server).ScrapeShowtimes(t.Context(), internal.ListShowtimesRequest{
+				After:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				Before: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			})
+			require.NoError(t, err, "ScrapeShowtimes")
+			n := 0
+			for item := range ch {
+				n++
+				assert.Equal(t, tt.want, item.Showtime.VenueName, "%s: VenueName", item.Showtime.ID)
+				assert.NotEqual(t, item.Showtime.Location, item.Showtime.VenueName, "VenueName should be shorter than Location")
+			}
+			require.Positive(t, n, "expected showtimes from golden data")
+
+			venue, ok := Venue(tt.site)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, venue.Name, "Venue name should match the showtimes'")
+		})
+	}
+}
@@ -1,12 +1,22 @@
 package services
 
 import (
+	"cmp"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/drewfead/pdx-watcher/internal"
 	"github.com/drewfead/pdx-watcher/internal/enrichment"
+	"github.com/drewfead/pdx-watcher/internal/geo"
+	"github.com/drewfead/pdx-watcher/internal/htmltext"
 	"github.com/drewfead/pdx-watcher/internal/scraper"
 	"github.com/drewfead/pdx-watcher/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -28,10 +38,21 @@ func ShowtimesService(registry scraper.Registry, enrichmentProviders ...internal
 
 const defaultLimit = 100
 
+// staleResultThreshold is how far in the past the newest showtime in a result can be before
+// ListShowtimes warns that a theater's feed may have gone stale.
+const staleResultThreshold = 24 * time.Hour
+
+// defaultMaxRange is the widest after..before span listed without --max-range. The default range
+// (yesterday through a year from today) always fits.
+const defaultMaxRange = 367 * 24 * time.Hour
+
+// nowFunc returns the current time for default ranges, --deadline, and staleness checks; tests override it.
+var nowFunc = time.Now
+
 // defaultTimeRange returns the default after (start of yesterday) and before (one year from today)
 // when --after and --before are not set.
 func defaultTimeRange() (after, before time.Time) {
-	now := time.Now()
+	now := nowFunc()
 	loc := now.Location()
 	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
 	after = startOfToday.AddDate(0, 0, -1) // start of yesterday
@@ -39,6 +60,43 @@ func defaultTimeRange() (after, before time.Time) {
 	return after, before
 }
 
+// expandGroups returns req.From followed by the sites of each group in req.FromGroup, without repeats.
+func (s *showtimesService) expandGroups(req *proto.ListShowtimesRequest) ([]proto.PdxSite, error) {
+	if len(req.FromGroup) == 0 {
+		return req.From, nil
+	}
+	from := slices.Clone(req.From)
+	for _, name := range req.FromGroup {
+		sites, ok := s.registry.SiteGroup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown site or group %q (valid sites: hollywood-theatre, cinemagic, cinema21, none, all)", name)
+		}
+		for _, site := range sites {
+			if !slices.Contains(from, site) {
+				from = append(from, site)
+			}
+		}
+	}
+	if len(from) == 0 {
+		// An empty group must not fall through to listing every site.
+		return nil, fmt.Errorf("site groups %v have no sites", req.FromGroup)
+	}
+	return from, nil
+}
+
+// siteScraper looks up site's scraper, wrapped so --deadline cuts it off on its own. None always
+// resolves, to an empty scraper when the registry has none, so --from none lists nothing instead of failing.
+func (s *showtimesService) siteScraper(site proto.PdxSite) (internal.Scraper, error) {
+	sc, err := s.registry.GetScraper(scraper.SiteDescriptor(site))
+	if err != nil {
+		if site != proto.PdxSite_None {
+			return nil, err
+		}
+		sc = scraper.None()
+	}
+	return scraper.UntilDeadline(sc), nil
+}
+
 // protoTime returns the time if ts is set and non-zero; otherwise returns the zero time.
 // Treats both Go zero (0001-01-01) and Unix epoch (1970-01-01) as unset, since the CLI
 // sends epoch when --after/--before are omitted. Callers can use .IsZero() on the result.
@@ -54,39 +112,42 @@ func protoTime(ts *timestamppb.Timestamp) time.Time {
 }
 
 func (s *showtimesService) ListShowtimes(req *proto.ListShowtimesRequest, stream proto.ShowtimeService_ListShowtimesServer) error {
+	from, err := s.expandGroups(req)
+	if err != nil {
+		return err
+	}
 	var sc internal.Scraper
 	switch {
-	case len(req.From) == 0:
+	case len(from) == 0:
 		sites := s.registry.AllSites()
 		if len(sites) == 0 {
 			return fmt.Errorf("no theaters registered")
 		}
 		scrapers := make([]internal.Scraper, 0, len(sites))
 		for _, site := range sites {
-			scraper, err := s.registry.GetScraper(site.String())
+			siteScraper, err := s.siteScraper(site)
 			if err != nil {
 				continue
 			}
-			scrapers = append(scrapers, scraper)
+			scrapers = append(scrapers, siteScraper)
 		}
 		if len(scrapers) == 0 {
 			return fmt.Errorf("no scrapers available")
 		}
 		sc = scraper.Interleaved(scrapers...)
-	case len(req.From) == 1:
-		var err error
-		sc, err = s.registry.GetScraper(req.From[0].String())
+	case len(from) == 1:
+		sc, err = s.siteScraper(from[0])
 		if err != nil {
 			return fmt.Errorf("unsupported site: %w", err)
 		}
 	default:
-		scrapers := make([]internal.Scraper, 0, len(req.From))
-		for _, site := range req.From {
-			scraper, err := s.registry.GetScraper(site.String())
+		scrapers := make([]internal.Scraper, 0, len(from))
+		for _, site := range from {
+			siteScraper, err := s.siteScraper(site)
 			if err != nil {
 				return fmt.Errorf("unsupported site %s: %w", site.String(), err)
 			}
-			scrapers = append(scrapers, scraper)
+			scrapers = append(scrapers, siteScraper)
 		}
 		sc = scraper.Interleaved(scrapers...)
 	}
@@ -99,58 +160,569 @@ func (s *showtimesService) ListShowtimes(req *proto.ListShowtimesRequest, stream
 	if req.Anchor != nil {
 		anchor = *req.Anchor
 	}
+	// Anchors page one site's listing; interleaved sites can't resume from another site's anchor.
+	pageable := len(from) == 1
+	if anchor != "" && !pageable {
+		return fmt.Errorf("--anchor pages a single site; pass exactly one --from")
+	}
 
 	after, before := defaultTimeRange()
+	defaultAfter, defaultBefore := true, true
 	if t := protoTime(req.After); !t.IsZero() {
-		after = t
+		after, defaultAfter = t, false
 	}
 	if t := protoTime(req.Before); !t.IsZero() {
-		before = t
+		before, defaultBefore = t, false
 	}
-	showtimes, err := sc.ScrapeShowtimes(stream.Context(), internal.ListShowtimesRequest{
-		After:  after,
-		Before: before,
-		Limit:  limit,
-		Anchor: anchor,
-	})
+	maxRange, err := parseMaxRange(req.GetMaxRange())
+	if err != nil {
+		return err
+	}
+	if before.Sub(after) > maxRange {
+		clamped := after.Add(maxRange)
+		slog.Warn("list-showtimes: time range exceeds --max-range; listing a shorter range",
+			"requested_before", before.Format(time.RFC3339), "before", clamped.Format(time.RFC3339),
+			"max_range", maxRange.String())
+		before = clamped
+	}
+	// Say which range is being listed; without --after/--before it spans a year, which surprises people.
+	slog.Info("list-showtimes: time range",
+		"after", after.Format(time.RFC3339), "before", before.Format(time.RFC3339),
+		"default_after", defaultAfter, "default_before", defaultBefore)
+	listReq := internal.ListShowtimesRequest{
+		After:   after,
+		Before:  before,
+		Limit:   limit,
+		Anchor:  anchor,
+		Refresh: req.GetRefresh(),
+	}
+	if req.GetProgress() {
+		listReq.Progress = printProgress(progressOutput)
+	}
+	if d := req.GetDeadline(); d != "" {
+		budget, err := time.ParseDuration(d)
+		if err != nil || budget <= 0 {
+			return fmt.Errorf("invalid deadline %q: want a positive duration like 10s", d)
+		}
+		listReq.Deadline = nowFunc().Add(budget)
+	}
+	var skips *skipSummary
+	if req.GetExplainSkips() {
+		skips = &skipSummary{}
+		listReq.Skips = skips.add
+	}
+	perDay := int(req.GetLimitPerDay())
+	loc := time.Local
+	if perDay > 0 {
+		if tz := req.GetOutputTimezone(); tz != "" {
+			var err error
+			loc, err = time.LoadLocation(tz)
+			if err != nil {
+				return fmt.Errorf("invalid output timezone %q: %w", tz, err)
+			}
+		}
+		// A global limit would truncate wide ranges to their first days; only apply one if asked.
+		if req.Limit == nil {
+			listReq.Limit = 0
+		}
+	}
+	// The filters below drop listings after the scraper has applied the limit, leaving a short
+	// listing. A paging site continues past them via its next anchor; otherwise the scraper lists
+	// everything and the limit counts what passes.
+	filtered := len(req.GetExcludeTitle()) > 0 || len(req.GetExcludeId()) > 0 || req.GetNear() != "" ||
+		req.GetBookable() || len(req.GetAccessibility()) > 0 || req.GetFree()
+	var filteredLimit int
+	if filtered && !pageable && listReq.Limit > 0 {
+		filteredLimit, listReq.Limit = listReq.Limit, 0
+	}
+	near, err := newNearby(req)
+	if err != nil {
+		return err
+	}
+	groups, err := newGroupedResponses(req.GetGroupBy())
+	if err != nil {
+		return err
+	}
+	for _, feature := range req.GetAccessibility() {
+		if !slices.Contains(internal.AccessibilityFeatures, feature) {
+			return fmt.Errorf("unknown accessibility feature %q (valid: %s)", feature, strings.Join(internal.AccessibilityFeatures, ", "))
+		}
+	}
+	warnUnsupportedFilters(req, sc)
+	showtimes, err := sc.ScrapeShowtimes(stream.Context(), listReq)
 	if err != nil {
 		return fmt.Errorf("failed to scrape showtimes: %w", err)
 	}
 
 	var sent int
+	var latest time.Time
+	perDayCounts := make(map[string]int)
+	var cacheStats enrichment.CacheStats
+	style, err := parseSummaryStyle(req.GetSummaryStyle())
+	if err != nil {
+		return err
+	}
+	summary := summaryOptions{style: style, noSubhed: req.GetNoSubhed(), preferListedTitle: req.GetPreferListedTitle()}
+	sendNow := func(resp *proto.ListShowtimesResponse) error {
+		if err := stream.Send(resp); err != nil {
+			slog.Error("list-showtimes: stream.Send failed", "error", err, "sent_so_far", sent)
+			return err
+		}
+		sent++
+		return nil
+	}
+	// The scraper puts the next page's anchor on its last item, which the filters below may drop, so
+	// the latest anchor is kept aside and the newest response held back until flush can attach it
+	// to whatever is actually sent last.
+	var nextAnchor string
+	var held *proto.ListShowtimesResponse
+	// Repeats are flagged in the order responses go out, which --group-by may change, so each
+	// response's film key waits here until send.
+	seen := make(filmsSeen)
+	films := make(map[*proto.ListShowtimesResponse]string)
+	send := func(resp *proto.ListShowtimesResponse) error {
+		resp.Showtime.Repeat = seen.repeat(films[resp])
+		delete(films, resp)
+		if held != nil {
+			if err := sendNow(held); err != nil {
+				return err
+			}
+		}
+		held = resp
+		return nil
+	}
+	// flush sends what --group-by buffered and the held-back response, carrying the next anchor.
+	flush := func() error {
+		for _, resp := range groups.sorted() {
+			if err := send(resp); err != nil {
+				return err
+			}
+		}
+		if held == nil {
+			if nextAnchor != "" {
+				slog.Info("list-showtimes: nothing on this page passed the filters; more results remain", "next_anchor", nextAnchor)
+			}
+			return nil
+		}
+		if nextAnchor != "" {
+			held.NextAnchor = &nextAnchor
+		}
+		last := held
+		held = nil
+		return sendNow(last)
+	}
+	exclude := newExclusions(req.GetExcludeTitle(), req.GetExcludeId())
+	stripHTML := req.StripHtml == nil || req.GetStripHtml()
+	var matched int // responses that passed the filters, sent or buffered
 	for showtime := range showtimes {
+		if err := stream.Context().Err(); err != nil {
+			// Interrupted (e.g. Ctrl-C): keep what was already sent and end the stream cleanly.
+			slog.Warn("list-showtimes: interrupted; returning partial results", "sent", sent+groups.len(), "error", err)
+			go func() {
+				for range showtimes {
+				}
+			}()
+			return flush()
+		}
+		if showtime.NextAnchor != "" && pageable {
+			nextAnchor = showtime.NextAnchor
+		}
+		if exclude.matches(showtime.Showtime) {
+			continue
+		}
+		if near != nil && !near.includes(showtime.Site) {
+			continue
+		}
+		if req.GetBookable() && !bookable(showtime.Showtime) {
+			continue
+		}
+		if !hasAccessibility(showtime.Showtime, req.GetAccessibility()) {
+			continue
+		}
+		if req.GetFree() && !showtime.Showtime.Free() {
+			continue
+		}
+		if showtime.Showtime.StartTime.After(latest) {
+			latest = showtime.Showtime.StartTime
+		}
+		if perDay > 0 {
+			day := showtime.Showtime.StartTime.In(loc).Format(time.DateOnly)
+			if perDayCounts[day] >= perDay {
+				continue
+			}
+			perDayCounts[day]++
+		}
 		enriched := enrichment.Enrich(stream.Context(), showtime.Showtime, s.enrichment...)
-		resp := &proto.ListShowtimesResponse{
-			Showtime: toProtoShowtime(enriched),
+		cacheStats.Add(enriched.Audits)
+		if stripHTML && enriched.Source.Description != nil {
+			enriched.Source.Description = ptr(htmltext.Plain(*enriched.Source.Description))
 		}
-		if showtime.NextAnchor != "" {
-			resp.NextAnchor = &showtime.NextAnchor
+		resp := &proto.ListShowtimesResponse{
+			Showtime: toProtoShowtime(enriched, summary),
 		}
+		films[resp] = filmKey(enriched)
 		if showtime.Site != proto.PdxSite_None {
 			siteVal := showtime.Site
 			resp.Site = &siteVal
 		}
-		if err := stream.Send(resp); err != nil {
-			slog.Error("list-showtimes: stream.Send failed", "error", err, "sent_so_far", sent)
+		if req.GetExplain() {
+			resp.MatchCandidates = toProtoMatchCandidates(enriched.Audits)
+		}
+		if groups != nil {
+			groups.add(showtime.Showtime, resp)
+		} else if err := send(resp); err != nil {
 			return err
 		}
-		sent++
+		if matched++; filteredLimit > 0 && matched >= filteredLimit {
+			go func() {
+				for range showtimes {
+				}
+			}()
+			break
+		}
+	}
+	if err := flush(); err != nil {
+		return err
 	}
 	slog.Debug("list-showtimes", "from", req.From, "sent", sent)
+	if req.GetTrace() {
+		_, _ = fmt.Fprintln(progressOutput, cacheStats)
+	}
+	if skips != nil {
+		_, _ = fmt.Fprint(progressOutput, skips)
+	}
+	if isStale(latest, before, nowFunc()) {
+		slog.Warn("list-showtimes: newest showtime is in the past; the theater feed may be stale",
+			"descriptor", sc.Descriptor(), "latest", latest, "threshold", staleResultThreshold)
+	}
 	return nil
 }
 
+// warnUnsupportedFilters warns about filters on a field sc doesn't report for every selected site;
+// listings without the field never pass, so the filter quietly drops them.
+func warnUnsupportedFilters(req *proto.ListShowtimesRequest, sc internal.Scraper) {
+	caps := sc.Capabilities()
+	filters := []struct {
+		flag  string
+		set   bool
+		field internal.Capabilities
+	}{
+		{"--free", req.GetFree(), internal.CapabilityAdmission},
+		{"--bookable", req.GetBookable(), internal.CapabilityTickets},
+		{"--accessibility", len(req.GetAccessibility()) > 0, internal.CapabilityAccessibility},
+	}
+	for _, f := range filters {
+		if f.set && !caps.Has(f.field) {
+			slog.Warn("list-showtimes: a selected site doesn't report what this filter checks; its listings won't match",
+				"filter", f.flag, "descriptor", sc.Descriptor())
+		}
+	}
+}
+
+// bookable reports whether showtime has a link to buy tickets.
+func bookable(showtime internal.SourceShowtime) bool {
+	return slices.ContainsFunc(showtime.Screening.Links, func(link internal.Link) bool {
+		return link.Rel == internal.LinkRelTickets && link.Href != ""
+	})
+}
+
+// hasAccessibility reports whether showtime offers any of features; an empty list matches everything.
+func hasAccessibility(showtime internal.SourceShowtime, features []string) bool {
+	if len(features) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(features, func(feature string) bool {
+		return slices.Contains(showtime.Screening.Accessibility, feature)
+	})
+}
+
+// noSeriesGroup heads showtimes outside any series under --group-by series.
+const noSeriesGroup = "No series"
+
+// groupedResponses buffers responses for --group-by so each group can be sent together.
+type groupedResponses struct {
+	key   func(internal.SourceShowtime) string
+	items []groupedResponse
+}
+
+type groupedResponse struct {
+	group string
+	start time.Time
+	id    string
+	resp  *proto.ListShowtimesResponse
+}
+
+// newGroupedResponses validates a --group-by value; empty means no grouping and returns nil.
+func newGroupedResponses(groupBy string) (*groupedResponses, error) {
+	switch strings.ToLower(strings.TrimSpace(groupBy)) {
+	case "":
+		return nil, nil
+	case "series":
+		return &groupedResponses{key: func(showtime internal.SourceShowtime) string {
+			return cmp.Or(strings.TrimSpace(showtime.Screening.Series), noSeriesGroup)
+		}}, nil
+	}
+	return nil, fmt.Errorf("invalid group by %q (want series)", groupBy)
+}
+
+func (g *groupedResponses) add(showtime internal.SourceShowtime, resp *proto.ListShowtimesResponse) {
+	g.items = append(g.items, groupedResponse{group: g.key(showtime), start: showtime.StartTime, id: showtime.ID, resp: resp})
+}
+
+func (g *groupedResponses) len() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.items)
+}
+
+// sorted returns the buffered responses by group name (noSeriesGroup last), then start time and id,
+// with Group and GroupStart set. A page's next anchor moves to the last response, where readers expect it.
+func (g *groupedResponses) sorted() []*proto.ListShowtimesResponse {
+	if g.len() == 0 {
+		return nil
+	}
+	slices.SortStableFunc(g.items, func(a, b groupedResponse) int {
+		last := func(group string) int {
+			if group == noSeriesGroup {
+				return 1
+			}
+			return 0
+		}
+		return cmp.Or(
+			cmp.Compare(last(a.group), last(b.group)),
+			cmp.Compare(strings.ToLower(a.group), strings.ToLower(b.group)),
+			cmp.Compare(a.group, b.group),
+			a.start.Compare(b.start),
+			cmp.Compare(a.id, b.id),
+		)
+	})
+	out := make([]*proto.ListShowtimesResponse, len(g.items))
+	for i, item := range g.items {
+		item.resp.Group = &item.group
+		item.resp.GroupStart = i == 0 || g.items[i-1].group != item.group
+		out[i] = item.resp
+	}
+	g.items = nil
+	return out
+}
+
+// exclusions hides showtimes by id or by title (case-insensitive).
+type exclusions struct {
+	titles map[string]struct{}
+	ids    map[string]struct{}
+}
+
+func newExclusions(titles, ids []string) exclusions {
+	e := exclusions{
+		titles: make(map[string]struct{}, len(titles)),
+		ids:    make(map[string]struct{}, len(ids)),
+	}
+	for _, t := range titles {
+		e.titles[strings.ToLower(strings.TrimSpace(t))] = struct{}{}
+	}
+	for _, id := range ids {
+		e.ids[id] = struct{}{}
+	}
+	return e
+}
+
+// matches reports whether showtime's id, summary, or screening title is excluded.
+func (e exclusions) matches(showtime internal.SourceShowtime) bool {
+	if _, ok := e.ids[showtime.ID]; ok {
+		return true
+	}
+	for _, title := range []string{showtime.Summary, showtime.Screening.Title} {
+		if _, ok := e.titles[strings.ToLower(strings.TrimSpace(title))]; ok && title != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// filmsSeen tracks the films already listed so later sessions of the same film can be flagged as repeats.
+type filmsSeen map[string]struct{}
+
+// repeat records the film with key (see filmKey) and reports whether it was already seen.
+func (f filmsSeen) repeat(key string) bool {
+	if key == "" {
+		return false
+	}
+	if _, ok := f[key]; ok {
+		return true
+	}
+	f[key] = struct{}{}
+	return false
+}
+
+// filmKey identifies showtime's film: by TMDB id when matched, else by normalized title.
+func filmKey(showtime internal.EnrichedShowtime) string {
+	if id := showtime.Movie.TMDBID; id != 0 {
+		return fmt.Sprintf("tmdb:%d", id)
+	}
+	title := cmp.Or(showtime.Source.TitleHint, showtime.Source.Screening.Title, showtime.Source.Summary)
+	title = strings.Join(strings.Fields(strings.ToLower(title)), " ")
+	if title == "" {
+		return ""
+	}
+	return "title:" + title
+}
+
+// nearby keeps showtimes at venues within radiusKm of center.
+type nearby struct {
+	center   geo.Point
+	radiusKm float64
+}
+
+// newNearby returns the request's --near filter, or nil when none was requested.
+func newNearby(req *proto.ListShowtimesRequest) (*nearby, error) {
+	if req.Near == nil {
+		return nil, nil
+	}
+	center, err := geo.ParsePoint(req.GetNear())
+	if err != nil {
+		return nil, fmt.Errorf("invalid near: %w", err)
+	}
+	if req.GetRadiusKm() <= 0 {
+		return nil, fmt.Errorf("near requires a positive radius")
+	}
+	return &nearby{center: center, radiusKm: req.GetRadiusKm()}, nil
+}
+
+// includes reports whether site's venue is within the radius. Venues without a known location are excluded.
+func (n *nearby) includes(site proto.PdxSite) bool {
+	venue, ok := scraper.Venue(site)
+	if !ok || venue.Location.IsZero() {
+		return false
+	}
+	return geo.DistanceKm(n.center, venue.Location) <= n.radiusKm
+}
+
+// parseMaxRange parses --max-range: a Go duration (2160h) or a whole number of days (90d).
+// Empty means defaultMaxRange.
+func parseMaxRange(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultMaxRange, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max range %q: want a duration like 90d or 2160h", value)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid max range %q: want a duration like 90d or 2160h", value)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid max range %q: want a positive duration", value)
+	}
+	return d, nil
+}
+
+// isStale reports whether a result whose newest showtime starts at latest looks like a dead feed:
+// the request reached into the future, but nothing returned starts later than staleResultThreshold ago.
+// Requests for a purely historical range (before <= now) are never considered stale.
+func isStale(latest, before, now time.Time) bool {
+	if latest.IsZero() || !before.After(now) {
+		return false
+	}
+	return now.Sub(latest) > staleResultThreshold
+}
+
+// progressOutput is where --progress lines and the --trace summary are written.
+var progressOutput io.Writer = os.Stderr
+
+// printProgress returns a ProgressFunc that writes lines like "Cinemagic: fetched 12/52 dates" to w.
+func printProgress(w io.Writer) internal.ProgressFunc {
+	return func(p internal.Progress) {
+		_, _ = fmt.Fprintf(w, "%s: fetched %d/%d %s\n", p.Site, p.Done, p.Total, p.Unit)
+	}
+}
+
+// skipSummary collects the per-site skip counts scrapers report for --explain-skips.
+type skipSummary struct {
+	mu    sync.Mutex
+	sites map[proto.PdxSite]internal.SkipCounts
+}
+
+func (s *skipSummary) add(site proto.PdxSite, skips internal.SkipCounts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sites == nil {
+		s.sites = make(map[proto.PdxSite]internal.SkipCounts)
+	}
+	s.sites[site] = s.sites[site].Add(skips)
+}
+
+// String renders one line per site, e.g. "Cinema21: skipped 12 (10 before --after, 2 after --before, 0 unparseable)".
+func (s *skipSummary) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var b strings.Builder
+	for _, site := range slices.Sorted(maps.Keys(s.sites)) {
+		c := s.sites[site]
+		fmt.Fprintf(&b, "%s: skipped %d (%d before --after, %d after --before, %d unparseable)\n", site, c.Total(), c.After, c.Before, c.Parse)
+	}
+	if b.Len() == 0 {
+		return "no sites reported skips\n"
+	}
+	return b.String()
+}
+
+// toProtoMatchCandidates collects the match candidates every enrichment provider recorded in its audit annotations.
+func toProtoMatchCandidates(audits []internal.EnrichmentAudit) []*proto.MatchCandidate {
+	var out []*proto.MatchCandidate
+	for _, audit := range audits {
+		candidates, ok := audit.Annotations[internal.AnnotationMatchCandidates].([]internal.MatchCandidate)
+		if !ok {
+			continue
+		}
+		for _, c := range candidates {
+			var runtimeDiff *int32
+			if c.RuntimeDiff >= 0 {
+				runtimeDiff = ptr(int32(c.RuntimeDiff))
+			}
+			out = append(out, &proto.MatchCandidate{
+				Title:              c.Title,
+				TmdbId:             c.TMDBID,
+				DirectorMatch:      c.DirectorMatch,
+				RuntimeDiffMinutes: runtimeDiff,
+				Chosen:             c.Chosen,
+			})
+		}
+	}
+	return out
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// toProtoLinks maps links, keeping only the first of any with the same href (scrapers can list a
+// page twice, e.g. when a film's permalink is also its series page).
 func toProtoLinks(links []internal.Link) []*proto.Link {
-	out := make([]*proto.Link, len(links))
-	for i, link := range links {
+	out := make([]*proto.Link, 0, len(links))
+	seen := make(map[string]bool, len(links))
+	for _, link := range links {
+		if seen[link.Href] {
+			continue
+		}
+		seen[link.Href] = true
 		var display *string
 		if link.Display != "" {
 			display = &link.Display
 		}
-		out[i] = &proto.Link{
+		var rel *string
+		if link.Rel != "" {
+			rel = &link.Rel
+		}
+		out = append(out, &proto.Link{
 			Href:    link.Href,
 			Display: display,
-		}
+			Rel:     rel,
+		})
 	}
 	return out
 }
@@ -173,11 +745,12 @@ func toProtoScreeningInfo(screening internal.ScreeningInfo) *proto.ScreeningInfo
 		host = &screening.Host
 	}
 	return &proto.ScreeningInfo{
-		Title:  title,
-		Subhed: subhed,
-		Series: series,
-		Host:   host,
-		Links:  toProtoLinks(screening.Links),
+		Title:         title,
+		Subhed:        subhed,
+		Series:        series,
+		Host:          host,
+		Accessibility: screening.Accessibility,
+		Links:         toProtoLinks(screening.Links),
 	}
 }
 
@@ -194,41 +767,92 @@ func toProtoMovieInfo(movie internal.MovieInfo) *proto.MovieInfo {
 	if movie.Overview != "" {
 		overview = &movie.Overview
 	}
+	var collection *string
+	if movie.Collection != "" {
+		collection = &movie.Collection
+	}
 	return &proto.MovieInfo{
-		Title:    title,
-		Tagline:  tagline,
-		Overview: overview,
-		Links:    toProtoLinks(movie.Links),
+		Title:      title,
+		Tagline:    tagline,
+		Overview:   overview,
+		Collection: collection,
+		Links:      toProtoLinks(movie.Links),
 	}
 }
 
-func toProtoShowtime(showtime internal.EnrichedShowtime) *proto.Showtime {
-	var description *string
-	if showtime.Source.Description != "" {
-		description = &showtime.Source.Description
+// summaryStyle is how toProtoShowtime composes a summary around the showtime's title.
+type summaryStyle string
+
+const (
+	summaryStyleTitle       summaryStyle = "title"        // the title alone
+	summaryStyleTitleSubhed summaryStyle = "title-subhed" // "Heat - in 35mm"; the subhed is only appended to TMDB titles, since listed titles usually carry it already
+	summaryStyleSeriesTitle summaryStyle = "series-title" // "70mm: Heat", or the title alone outside a series
+)
+
+// parseSummaryStyle validates a --summary-style value; empty means the default, title-subhed.
+func parseSummaryStyle(s string) (summaryStyle, error) {
+	switch style := summaryStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case "":
+		return summaryStyleTitleSubhed, nil
+	case summaryStyleTitle, summaryStyleTitleSubhed, summaryStyleSeriesTitle:
+		return style, nil
 	}
+	return "", fmt.Errorf("invalid summary style %q (want %s, %s, or %s)", s, summaryStyleTitle, summaryStyleTitleSubhed, summaryStyleSeriesTitle)
+}
+
+// summaryOptions controls how toProtoShowtime builds a showtime's summary.
+type summaryOptions struct {
+	style             summaryStyle // zero value behaves as title-subhed
+	noSubhed          bool         // don't append the screening subhed to a matched title
+	preferListedTitle bool         // keep the venue's advertised title even when TMDB matched
+}
+
+// toProtoShowtime maps an enriched showtime to its proto form. Description passes through as-is so an
+// unset field means the source had no description and a set-but-empty one means it is known empty.
+func toProtoShowtime(showtime internal.EnrichedShowtime, opts summaryOptions) *proto.Showtime {
 	startTime := timestamppb.New(showtime.Source.StartTime)
 	endTime := timestamppb.New(showtime.Source.EndTime)
 	var location *string
 	if showtime.Source.Location != "" {
 		location = &showtime.Source.Location
 	}
+	var venueName *string
+	if showtime.Source.VenueName != "" {
+		venueName = &showtime.Source.VenueName
+	}
 	summary := showtime.Source.Summary
-	if showtime.Movie.Title != "" {
+	matched := showtime.Movie.Title != "" && !opts.preferListedTitle
+	if matched {
 		summary = showtime.Movie.Title
-		if showtime.Source.Screening.Subhed != "" {
+	}
+	switch opts.style {
+	case summaryStyleTitle:
+	case summaryStyleSeriesTitle:
+		if series := showtime.Source.Screening.Series; series != "" {
+			summary = series + ": " + summary
+		}
+	default:
+		if matched && showtime.Source.Screening.Subhed != "" && !opts.noSubhed {
 			summary += " - " + showtime.Source.Screening.Subhed
 		}
 	}
 
+	var admission *string
+	if showtime.Source.Admission != "" {
+		admission = &showtime.Source.Admission
+	}
+
 	return &proto.Showtime{
 		Id:          showtime.Source.ID,
 		Summary:     summary,
-		Description: description,
+		Description: showtime.Source.Description,
 		StartTime:   startTime,
 		EndTime:     endTime,
 		Location:    location,
+		VenueName:   venueName,
+		SoldOut:     showtime.SoldOut,
 		Screening:   toProtoScreeningInfo(showtime.Source.Screening),
 		Movie:       toProtoMovieInfo(showtime.Movie),
+		Admission:   admission,
 	}
 }
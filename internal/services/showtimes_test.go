@@ -0,0 +1,803 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewfead/pdx-watcher/internal"
+	"github.com/drewfead/pdx-watcher/internal/geo"
+	"github.com/drewfead/pdx-watcher/internal/scraper"
+	"github.com/drewfead/pdx-watcher/internal/scraper/scrapertest"
+	"github.com/drewfead/pdx-watcher/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordingStream collects responses sent by ListShowtimes.
+type recordingStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*proto.ListShowtimesResponse
+	onSend    func() // called after each response is recorded
+}
+
+func (s *recordingStream) Context() context.Context { return s.ctx }
+
+func (s *recordingStream) Send(resp *proto.ListShowtimesResponse) error {
+	s.responses = append(s.responses, resp)
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return nil
+}
+
+// goldenServer serves the named scraper's golden files (internal/scraper/golden/<name>).
+func goldenServer(t *testing.T, gs internal.GoldenScraper, name string) *httptest.Server {
+	t.Helper()
+	handler, err := gs.MountGolden(t.Context(), filepath.Join("..", "scraper", "golden", name))
+	require.NoError(t, err, "MountGolden")
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func cinema21Golden(t *testing.T) internal.Scraper {
+	t.Helper()
+	gs, _ := scraper.Cinema21().(internal.GoldenScraper)
+	server := goldenServer(t, gs, "cinema21")
+	return scraper.Cinema21(scraper.Cinema21WithBaseURL(server.URL), scraper.Cinema21WithClient(server.Client()))
+}
+
+func cinemagicGolden(t *testing.T) internal.Scraper {
+	t.Helper()
+	gs, _ := scraper.Cinemagic().(internal.GoldenScraper)
+	server := goldenServer(t, gs, "cinemagic")
+	return scraper.Cinemagic(scraper.CinemagicWithBaseURL(server.URL), scraper.CinemagicWithClient(server.Client()))
+}
+
+func TestUnit_ListShowtimes_WarnsOnStaleResults(t *testing.T) {
+	logs := scrapertest.CaptureLogs(t)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, cinema21Golden(t)))
+	svc := ShowtimesService(registry)
+
+	// Golden data is from early 2026; asking for everything up to a year from now leaves only past showtimes.
+	stream := &recordingStream{ctx: t.Context()}
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+		From:   []proto.PdxSite{proto.PdxSite_Cinema21},
+		After:  timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+		Before: timestamppb.New(time.Now().AddDate(1, 0, 0)),
+	}, stream)
+	require.NoError(t, err)
+	require.NotEmpty(t, stream.responses)
+	require.Contains(t, logs.String(), "feed may be stale")
+}
+
+func TestUnit_IsStale(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		latest time.Time
+		before time.Time
+		want   bool
+	}{
+		{"no results", time.Time{}, now.AddDate(1, 0, 0), false},
+		{"future results", now.Add(time.Hour), now.AddDate(1, 0, 0), false},
+		{"within threshold", now.Add(-time.Hour), now.AddDate(1, 0, 0), false},
+		{"past threshold", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0), true},
+		{"historical range", now.AddDate(0, -1, 0), now.AddDate(0, 0, -7), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isStale(tt.latest, tt.before, now))
+		})
+	}
+}
+
+// candidateProvider records a fixed set of match candidates on every showtime.
+type candidateProvider struct {
+	candidates []internal.MatchCandidate
+}
+
+func (p candidateProvider) Enrich(_ context.Context, showtime internal.EnrichedShowtime) (internal.EnrichedShowtime, error) {
+	showtime.Audits = append(showtime.Audits, internal.EnrichmentAudit{
+		Result:      internal.EnrichmentResultSuccess,
+		At:          time.Now(),
+		Annotations: map[string]any{internal.AnnotationMatchCandidates: p.candidates},
+	})
+	return showtime, nil
+}
+
+func TestUnit_ListShowtimes_Explain(t *testing.T) {
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, cinema21Golden(t)))
+	provider := candidateProvider{candidates: []internal.MatchCandidate{
+		{Title: "Chosen", TMDBID: 1, DirectorMatch: true, RuntimeDiff: 2, Chosen: true},
+		{Title: "Unscored", TMDBID: 2, RuntimeDiff: -1},
+	}}
+	svc := ShowtimesService(registry, provider)
+
+	list := func(explain bool) []*proto.ListShowtimesResponse {
+		stream := &recordingStream{ctx: t.Context()}
+		err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+			From:    []proto.PdxSite{proto.PdxSite_Cinema21},
+			After:   timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+			Before:  timestamppb.New(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+			Explain: &explain,
+		}, stream)
+		require.NoError(t, err)
+		require.NotEmpty(t, stream.responses)
+		return stream.responses
+	}
+
+	for _, resp := range list(false) {
+		require.Empty(t, resp.GetMatchCandidates())
+	}
+	for _, resp := range list(true) {
+		candidates := resp.GetMatchCandidates()
+		require.Len(t, candidates, 2)
+		require.True(t, candidates[0].GetChosen())
+		require.True(t, candidates[0].GetDirectorMatch())
+		require.Equal(t, int32(2), candidates[0].GetRuntimeDiffMinutes())
+		require.Nil(t, candidates[1].RuntimeDiffMinutes)
+	}
+}
+
+func TestUnit_ListShowtimes_DescriptionNullVsEmpty(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+		{ID: "absent", Summary: "Absent", StartTime: start},
+		{ID: "cleared", Summary: "Cleared", StartTime: start.Add(time.Minute), Description: ptr("")},
+	}}))
+	svc := ShowtimesService(registry)
+
+	stream := &recordingStream{ctx: t.Context()}
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{From: []proto.PdxSite{proto.PdxSite_Cinema21}}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.responses, 2)
+	require.Nil(t, stream.responses[0].GetShowtime().Description, "absent description should stay nil")
+	require.NotNil(t, stream.responses[1].GetShowtime().Description, "cleared description should be set")
+	require.Empty(t, stream.responses[1].GetShowtime().GetDescription())
+}
+
+func TestUnit_ListShowtimes_LimitPerDay(t *testing.T) {
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinemagic, cinemagicGolden(t)))
+	svc := ShowtimesService(registry)
+
+	stream := &recordingStream{ctx: t.Context()}
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+		From:           []proto.PdxSite{proto.PdxSite_Cinemagic},
+		After:          timestamppb.New(time.Date(2026, 2, 21, 0, 0, 0, 0, time.UTC)),
+		Before:         timestamppb.New(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)),
+		OutputTimezone: ptr("America/Los_Angeles"),
+		LimitPerDay:    ptr(int32(2)),
+	}, stream)
+	require.NoError(t, err)
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	perDay := make(map[string]int)
+	for _, resp := range stream.responses {
+		perDay[resp.GetShowtime().GetStartTime().AsTime().In(loc).Format(time.DateOnly)]++
+	}
+	require.Greater(t, len(perDay), 1, "results should span multiple days")
+	for day, n := range perDay {
+		require.LessOrEqual(t, n, 2, "day %s", day)
+	}
+}
+
+func TestUnit_ListShowtimes_ExcludeTitle(t *testing.T) {
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, cinema21Golden(t)))
+	svc := ShowtimesService(registry)
+	list := func(excludeTitles ...string) []*proto.ListShowtimesResponse {
+		stream := &recordingStream{ctx: t.Context()}
+		err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+			From:         []proto.PdxSite{proto.PdxSite_Cinema21},
+			After:        timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+			Before:       timestamppb.New(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)),
+			ExcludeTitle: excludeTitles,
+		}, stream)
+		require.NoError(t, err)
+		return stream.responses
+	}
+
+	all := list()
+	require.NotEmpty(t, all)
+	hidden := all[0].GetShowtime().GetSummary()
+
+	filtered := list(strings.ToUpper(hidden))
+	require.NotEmpty(t, filtered)
+	require.Less(t, len(filtered), len(all))
+	for _, resp := range filtered {
+		require.NotEqual(t, hidden, resp.GetShowtime().GetSummary())
+	}
+}
+
+func TestUnit_ListShowtimes_Near(t *testing.T) {
+	registry := scraper.NewRegistry(
+		scraper.WithScraperForSite(proto.PdxSite_Cinema21, cinema21Golden(t)),
+		scraper.WithScraperForSite(proto.PdxSite_Cinemagic, cinemagicGolden(t)),
+	)
+	svc := ShowtimesService(registry)
+	cinema21, ok := scraper.Venue(proto.PdxSite_Cinema21)
+	require.True(t, ok)
+	cinemagic, ok := scraper.Venue(proto.PdxSite_Cinemagic)
+	require.True(t, ok)
+	// Cinemagic is across the river, ~4km from Cinema 21.
+	require.Greater(t, geo.DistanceKm(cinema21.Location, cinemagic.Location), 2.0)
+
+	stream := &recordingStream{ctx: t.Context()}
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+		After:    timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+		Before:   timestamppb.New(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Limit:    ptr(int32(1000)),
+		Near:     ptr(fmt.Sprintf("%f,%f", cinema21.Location.Lat, cinema21.Location.Lon)),
+		RadiusKm: ptr(2.0),
+	}, stream)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, stream.responses, "the near venue should be kept")
+	for _, resp := range stream.responses {
+		require.Equal(t, proto.PdxSite_Cinema21, resp.GetSite(), "the far venue should be excluded")
+	}
+}
+
+func TestUnit_ListShowtimes_NearRequiresRadius(t *testing.T) {
+	svc := ShowtimesService(scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{})))
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{Near: ptr("45.5,-122.6")}, &recordingStream{ctx: t.Context()})
+	require.ErrorContains(t, err, "radius")
+}
+
+func TestUnit_ListShowtimes_InterruptKeepsSentItems(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+		{ID: "1", Summary: "Heat", StartTime: start},
+		{ID: "2", Summary: "Thief", StartTime: start.Add(time.Hour)},
+		{ID: "3", Summary: "Collateral", StartTime: start.Add(2 * time.Hour)},
+	}}))
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	// Interrupt as soon as the first item has been written.
+	stream := &recordingStream{ctx: ctx, onSend: cancel}
+
+	err := ShowtimesService(registry).ListShowtimes(&proto.ListShowtimesRequest{
+		From:   []proto.PdxSite{proto.PdxSite_Cinema21},
+		After:  timestamppb.New(start.Add(-time.Hour)),
+		Before: timestamppb.New(start.Add(24 * time.Hour)),
+	}, stream)
+
+	require.NoError(t, err, "an interrupted stream should close cleanly")
+	// The response held back for the next anchor is still delivered; nothing after the interrupt is.
+	require.Len(t, stream.responses, 2)
+	require.Equal(t, "Heat", stream.responses[0].GetShowtime().GetSummary())
+	require.Equal(t, "Thief", stream.responses[1].GetShowtime().GetSummary())
+}
+
+func TestUnit_ListShowtimes_NextAnchorSurvivesFilters(t *testing.T) {
+	start := time.Date(2026, 2, 20, 19, 0, 0, 0, time.UTC)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+		{ID: "1", Summary: "Heat", StartTime: start},
+		{ID: "2", Summary: "Thief", StartTime: start.Add(time.Hour)},
+		{ID: "3", Summary: "Collateral", StartTime: start.Add(2 * time.Hour)},
+	}, Anchor: "page-2"}))
+	stream := &recordingStream{ctx: t.Context()}
+
+	err := ShowtimesService(registry).ListShowtimes(&proto.ListShowtimesRequest{
+		From:      []proto.PdxSite{proto.PdxSite_Cinema21},
+		After:     timestamppb.New(start.Add(-time.Hour)),
+		Before:    timestamppb.New(start.Add(24 * time.Hour)),
+		ExcludeId: []string{"3"},
+	}, stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.responses, 2)
+	require.Nil(t, stream.responses[0].NextAnchor)
+	require.Equal(t, "page-2", stream.responses[1].GetNextAnchor(), "the anchor moves to the last response sent")
+}
+
+func TestUnit_ListShowtimes_LimitCountsFilteredResults(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	registry := scraper.NewRegistry(
+		scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+			{ID: "heat", Summary: "Heat", StartTime: start},
+			{ID: "thief", Summary: "Thief", StartTime: start.Add(2 * time.Hour), Admission: internal.AdmissionFree},
+			{ID: "collateral", Summary: "Collateral", StartTime: start.Add(4 * time.Hour), Admission: internal.AdmissionFree},
+		}}),
+		scraper.WithScraperForSite(proto.PdxSite_Cinemagic, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+			{ID: "ali", Summary: "Ali", StartTime: start.Add(time.Hour)},
+			{ID: "blackhat", Summary: "Blackhat", StartTime: start.Add(3 * time.Hour), Admission: internal.AdmissionFree},
+		}}),
+	)
+	stream := &recordingStream{ctx: t.Context()}
+
+	err := ShowtimesService(registry).ListShowtimes(&proto.ListShowtimesRequest{
+		From:  []proto.PdxSite{proto.PdxSite_Cinema21, proto.PdxSite_Cinemagic},
+		Free:  ptr(true),
+		Limit: ptr(int32(2)),
+	}, stream)
+
+	require.NoError(t, err)
+	var ids []string
+	for _, resp := range stream.responses {
+		ids = append(ids, resp.GetShowtime().GetId())
+	}
+	require.Equal(t, []string{"thief", "blackhat"}, ids, "the limit applies to what passes --free, not to what was scraped")
+}
+
+func TestUnit_ListShowtimes_AnchorRequiresOneSite(t *testing.T) {
+	registry := scraper.NewRegistry(
+		scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{}),
+		scraper.WithScraperForSite(proto.PdxSite_Cinemagic, scrapertest.Static{}),
+	)
+	err := ShowtimesService(registry).ListShowtimes(&proto.ListShowtimesRequest{
+		From:   []proto.PdxSite{proto.PdxSite_Cinema21, proto.PdxSite_Cinemagic},
+		Anchor: ptr("page-2"),
+	}, &recordingStream{ctx: t.Context()})
+	require.ErrorContains(t, err, "--anchor")
+}
+
+func TestUnit_ToProtoShowtime_NoSubhed(t *testing.T) {
+	showtime := internal.EnrichedShowtime{
+		Source: internal.SourceShowtime{
+			Summary:   "HEAT (35MM)",
+			Screening: internal.ScreeningInfo{Title: "HEAT", Subhed: "in 35mm"},
+		},
+		Movie: internal.MovieInfo{Title: "Heat"},
+	}
+
+	require.Equal(t, "Heat - in 35mm", toProtoShowtime(showtime, summaryOptions{}).GetSummary())
+
+	got := toProtoShowtime(showtime, summaryOptions{noSubhed: true})
+	require.Equal(t, "Heat", got.GetSummary())
+	require.Equal(t, "in 35mm", got.GetScreening().GetSubhed(), "subhed stays in the structured screening field")
+}
+
+func TestUnit_ToProtoShowtime_DedupesLinks(t *testing.T) {
+	showtime := internal.EnrichedShowtime{
+		Source: internal.SourceShowtime{
+			Summary: "Heat",
+			Screening: internal.ScreeningInfo{Links: []internal.Link{
+				{Href: "https://hollywoodtheatre.org/series/mann", Display: "Permalink", Rel: internal.LinkRelInfo},
+				{Href: "https://hollywoodtheatre.org/tickets/1", Display: "Tickets", Rel: internal.LinkRelTickets},
+				{Href: "https://hollywoodtheatre.org/series/mann", Display: "Series", Rel: internal.LinkRelSeries},
+			}},
+		},
+	}
+
+	links := toProtoShowtime(showtime, summaryOptions{}).GetScreening().GetLinks()
+	require.Len(t, links, 2, "the duplicate href is dropped")
+	require.Equal(t, "https://hollywoodtheatre.org/series/mann", links[0].GetHref())
+	require.Equal(t, "Permalink", links[0].GetDisplay(), "the first occurrence survives")
+	require.Equal(t, "https://hollywoodtheatre.org/tickets/1", links[1].GetHref())
+}
+
+func TestUnit_ToProtoShowtime_PreferListedTitle(t *testing.T) {
+	showtime := internal.EnrichedShowtime{
+		Source: internal.SourceShowtime{
+			Summary:   "Heat: Director's Definitive Edition",
+			Screening: internal.ScreeningInfo{Title: "Heat: Director's Definitive Edition"},
+		},
+		Movie: internal.MovieInfo{
+			Title: "Heat",
+			Links: []internal.Link{{Href: "https://www.themoviedb.org/movie/949", Display: "TMDB"}},
+		},
+	}
+
+	require.Equal(t, "Heat", toProtoShowtime(showtime, summaryOptions{}).GetSummary())
+
+	got := toProtoShowtime(showtime, summaryOptions{preferListedTitle: true})
+	require.Equal(t, "Heat: Director's Definitive Edition", got.GetSummary())
+	require.Equal(t, "Heat", got.GetMovie().GetTitle(), "TMDB movie info is still attached")
+	require.NotEmpty(t, got.GetMovie().GetLinks())
+}
+
+func TestUnit_ListShowtimes_Repeat(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+		{ID: "heat-1", Summary: "Heat", TitleHint: "Heat", StartTime: start},
+		{ID: "thief", Summary: "Thief", TitleHint: "Thief", StartTime: start.Add(time.Hour)},
+		{ID: "heat-2", Summary: "HEAT ", TitleHint: "HEAT ", StartTime: start.Add(2 * time.Hour)},
+		{ID: "heat-3", Summary: "Heat", TitleHint: "heat", StartTime: start.Add(3 * time.Hour), Screening: internal.ScreeningInfo{Series: "Mann Fest"}},
+	}}))
+	svc := ShowtimesService(registry)
+	repeats := func(req *proto.ListShowtimesRequest) map[string]bool {
+		t.Helper()
+		stream := &recordingStream{ctx: t.Context()}
+		require.NoError(t, svc.ListShowtimes(req, stream))
+		repeats := make(map[string]bool)
+		for _, resp := range stream.responses {
+			repeats[resp.GetShowtime().GetId()] = resp.GetShowtime().GetRepeat()
+		}
+		return repeats
+	}
+
+	require.Equal(t, map[string]bool{"heat-1": false, "thief": false, "heat-2": true, "heat-3": true},
+		repeats(&proto.ListShowtimesRequest{}))
+	require.Equal(t, map[string]bool{"heat-3": false, "heat-1": true, "thief": false, "heat-2": true},
+		repeats(&proto.ListShowtimesRequest{GroupBy: ptr("series")}), "the first session listed is the one not flagged")
+}
+
+func TestUnit_FilmKey_PrefersTMDBID(t *testing.T) {
+	seen := make(filmsSeen)
+	require.False(t, seen.repeat(filmKey(internal.EnrichedShowtime{
+		Source: internal.SourceShowtime{TitleHint: "Heat (1995) - 35mm"},
+		Movie:  internal.MovieInfo{Title: "Heat", TMDBID: 949},
+	})))
+	require.True(t, seen.repeat(filmKey(internal.EnrichedShowtime{
+		Source: internal.SourceShowtime{TitleHint: "Heat"},
+		Movie:  internal.MovieInfo{Title: "Heat", TMDBID: 949},
+	})), "the same TMDB match is a repeat despite a different listed title")
+	require.False(t, seen.repeat(filmKey(internal.EnrichedShowtime{
+		Source: internal.SourceShowtime{TitleHint: "Heat"},
+		Movie:  internal.MovieInfo{Title: "Heat", TMDBID: 17074},
+	})), "a different film with the same title is not a repeat")
+}
+
+func TestUnit_ToProtoShowtime_SummaryStyle(t *testing.T) {
+	// A Hollywood Theatre 70mm series screening from the golden data: series "70mm", subhed "in 70mm".
+	data, err := os.ReadFile(filepath.Join("..", "scraper", "golden", "hollywoodtheatre.expected.json"))
+	require.NoError(t, err)
+	var golden []internal.SourceShowtime
+	require.NoError(t, json.Unmarshal(data, &golden))
+	i := slices.IndexFunc(golden, func(s internal.SourceShowtime) bool { return s.ID == "fd423ab1-1f93-5bf1-adff-1b25ed9f55f8" })
+	require.GreaterOrEqual(t, i, 0, "golden item")
+	source := golden[i]
+	require.Equal(t, "70mm", source.Screening.Series)
+	require.Equal(t, "in 70mm", source.Screening.Subhed)
+
+	matched := internal.EnrichedShowtime{Source: source, Movie: internal.MovieInfo{Title: "Malcolm X"}}
+	unmatched := internal.EnrichedShowtime{Source: source}
+	for _, tc := range []struct {
+		style     string
+		matched   string
+		unmatched string
+	}{
+		{style: "title", matched: "Malcolm X", unmatched: "MALCOLM X in 70mm"},
+		{style: "title-subhed", matched: "Malcolm X - in 70mm", unmatched: "MALCOLM X in 70mm"},
+		{style: "", matched: "Malcolm X - in 70mm", unmatched: "MALCOLM X in 70mm"},
+		{style: "series-title", matched: "70mm: Malcolm X", unmatched: "70mm: MALCOLM X in 70mm"},
+	} {
+		t.Run(cmp.Or(tc.style, "default"), func(t *testing.T) {
+			style, err := parseSummaryStyle(tc.style)
+			require.NoError(t, err)
+			opts := summaryOptions{style: style}
+			require.Equal(t, tc.matched, toProtoShowtime(matched, opts).GetSummary())
+			require.Equal(t, tc.unmatched, toProtoShowtime(unmatched, opts).GetSummary())
+		})
+	}
+
+	_, err = parseSummaryStyle("subhed-title")
+	require.Error(t, err)
+}
+
+func TestUnit_ListShowtimes_StripHTML(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	raw := "<p>Q&amp;A with the director&#39;s &quot;crew&quot;</p>"
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinemagic, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+		{ID: "heat", Summary: "Heat", StartTime: start, Description: ptr(raw)},
+		{ID: "thief", Summary: "Thief", StartTime: start.Add(time.Hour)},
+	}}))
+	svc := ShowtimesService(registry)
+
+	list := func(req *proto.ListShowtimesRequest) []*proto.ListShowtimesResponse {
+		t.Helper()
+		stream := &recordingStream{ctx: t.Context()}
+		require.NoError(t, svc.ListShowtimes(req, stream))
+		require.Len(t, stream.responses, 2)
+		return stream.responses
+	}
+
+	got := list(&proto.ListShowtimesRequest{})
+	require.Equal(t, `Q&A with the director's "crew"`, got[0].GetShowtime().GetDescription(), "on by default")
+	require.Nil(t, got[1].GetShowtime().Description, "a missing description stays missing")
+
+	got = list(&proto.ListShowtimesRequest{StripHtml: ptr(false)})
+	require.Equal(t, raw, got[0].GetShowtime().GetDescription())
+}
+
+func TestUnit_DefaultTimeRange_PinnedNow(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	prev := nowFunc
+	nowFunc = func() time.Time { return time.Date(2026, 3, 1, 15, 4, 5, 0, loc) }
+	t.Cleanup(func() { nowFunc = prev })
+
+	after, before := defaultTimeRange()
+	require.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, loc), after, "start of yesterday")
+	require.Equal(t, time.Date(2027, 3, 1, 0, 0, 0, 0, loc), before, "start of the day a year out")
+}
+
+func TestUnit_ListShowtimes_WarnsOnUnsupportedFilters(t *testing.T) {
+	logs := scrapertest.CaptureLogs(t)
+	svc := ShowtimesService(scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{})))
+
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+		From:          []proto.PdxSite{proto.PdxSite_Cinema21},
+		Free:          ptr(true),
+		Accessibility: []string{internal.AccessibilityOpenCaptions},
+	}, &recordingStream{ctx: t.Context()})
+	require.NoError(t, err)
+	require.Contains(t, logs.String(), "filter=--free")
+	require.Contains(t, logs.String(), "filter=--accessibility")
+	require.NotContains(t, logs.String(), "filter=--bookable", "only filters that were asked for")
+}
+
+func TestUnit_ListShowtimes_LogsDefaultTimeRange(t *testing.T) {
+	logs := scrapertest.CaptureLogs(t)
+	prev := nowFunc
+	nowFunc = func() time.Time { return time.Date(2026, 3, 1, 15, 4, 5, 0, time.UTC) }
+	t.Cleanup(func() { nowFunc = prev })
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{}))
+	svc := ShowtimesService(registry)
+
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{From: []proto.PdxSite{proto.PdxSite_Cinema21}}, &recordingStream{ctx: t.Context()})
+	require.NoError(t, err)
+	after, before := defaultTimeRange()
+	require.Contains(t, logs.String(), fmt.Sprintf("after=%s before=%s default_after=true default_before=true",
+		after.Format(time.RFC3339), before.Format(time.RFC3339)))
+}
+
+func TestUnit_ListShowtimes_FromGroup(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	registry := scraper.NewRegistry(
+		scraper.WithScraperForSite(proto.PdxSite_HollywoodTheatre, scrapertest.Static{Showtimes: []internal.SourceShowtime{{ID: "h", Summary: "Heat", StartTime: start}}}),
+		scraper.WithScraperForSite(proto.PdxSite_Cinemagic, scrapertest.Static{Showtimes: []internal.SourceShowtime{{ID: "m", Summary: "Manhunter", StartTime: start.Add(time.Minute)}}}),
+		scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{{ID: "t", Summary: "Thief", StartTime: start.Add(2 * time.Minute)}}}),
+		scraper.WithSiteGroup("Repertory", proto.PdxSite_HollywoodTheatre, proto.PdxSite_Cinema21),
+	)
+	svc := ShowtimesService(registry)
+
+	stream := &recordingStream{ctx: t.Context()}
+	require.NoError(t, svc.ListShowtimes(&proto.ListShowtimesRequest{FromGroup: []string{"repertory"}}, stream))
+	var summaries []string
+	for _, resp := range stream.responses {
+		summaries = append(summaries, resp.GetShowtime().GetSummary())
+	}
+	require.Equal(t, []string{"Heat", "Thief"}, summaries)
+
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{FromGroup: []string{"arthouse"}}, &recordingStream{ctx: t.Context()})
+	require.ErrorContains(t, err, `unknown site or group "arthouse"`)
+}
+
+func TestUnit_ListShowtimes_Bookable(t *testing.T) {
+	gs, _ := scraper.HollywoodTheatre(scraper.WithClient(http.DefaultClient)).(internal.GoldenScraper)
+	server := goldenServer(t, gs, "hollywoodtheatre")
+	hollywood := scraper.HollywoodTheatre(scraper.WithBaseURL(server.URL), scraper.WithClient(server.Client()))
+	registry := scraper.NewRegistry(
+		scraper.WithScraperForSite(proto.PdxSite_HollywoodTheatre, hollywood),
+		scraper.WithScraperForSite(proto.PdxSite_Cinema21, cinema21Golden(t)),
+	)
+	svc := ShowtimesService(registry)
+	list := func(bookableOnly bool) []*proto.ListShowtimesResponse {
+		stream := &recordingStream{ctx: t.Context()}
+		require.NoError(t, svc.ListShowtimes(&proto.ListShowtimesRequest{
+			From:     []proto.PdxSite{proto.PdxSite_HollywoodTheatre, proto.PdxSite_Cinema21},
+			After:    timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+			Before:   timestamppb.New(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+			Limit:    ptr(int32(0)),
+			Bookable: ptr(bookableOnly),
+		}, stream))
+		return stream.responses
+	}
+	hasTickets := func(resp *proto.ListShowtimesResponse) bool {
+		return slices.ContainsFunc(resp.GetShowtime().GetScreening().GetLinks(), func(link *proto.Link) bool {
+			return link.GetRel() == internal.LinkRelTickets
+		})
+	}
+
+	all := list(false)
+	require.True(t, slices.ContainsFunc(all, hasTickets), "golden set should have bookable showtimes")
+	require.True(t, slices.ContainsFunc(all, func(r *proto.ListShowtimesResponse) bool { return !hasTickets(r) }),
+		"golden set should have informational showtimes")
+	bookable := list(true)
+	require.NotEmpty(t, bookable)
+	require.Less(t, len(bookable), len(all))
+	for _, resp := range bookable {
+		require.True(t, hasTickets(resp), "%s has no tickets link", resp.GetShowtime().GetSummary())
+	}
+}
+
+func TestUnit_ListShowtimes_GroupBySeries(t *testing.T) {
+	gs, _ := scraper.HollywoodTheatre(scraper.WithClient(http.DefaultClient)).(internal.GoldenScraper)
+	server := goldenServer(t, gs, "hollywoodtheatre")
+	hollywood := scraper.HollywoodTheatre(scraper.WithBaseURL(server.URL), scraper.WithClient(server.Client()))
+	svc := ShowtimesService(scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_HollywoodTheatre, hollywood)))
+	stream := &recordingStream{ctx: t.Context()}
+	require.NoError(t, svc.ListShowtimes(&proto.ListShowtimesRequest{
+		From:    []proto.PdxSite{proto.PdxSite_HollywoodTheatre},
+		After:   timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+		Before:  timestamppb.New(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Limit:   ptr(int32(0)),
+		GroupBy: ptr("series"),
+	}, stream))
+	require.NotEmpty(t, stream.responses)
+
+	var order []string
+	for i, resp := range stream.responses {
+		group := resp.GetGroup()
+		require.NotEmpty(t, group, "every showtime is grouped")
+		require.Equal(t, cmp.Or(resp.GetShowtime().GetScreening().GetSeries(), "No series"), group)
+		if resp.GetGroupStart() {
+			require.NotContains(t, order, group, "group %q should be contiguous", group)
+			order = append(order, group)
+			continue
+		}
+		prev := stream.responses[i-1]
+		require.Equal(t, prev.GetGroup(), group, "only the first of a group starts it")
+		require.False(t, resp.GetShowtime().GetStartTime().AsTime().Before(prev.GetShowtime().GetStartTime().AsTime()),
+			"%q is out of time order within %q", resp.GetShowtime().GetSummary(), group)
+	}
+	require.GreaterOrEqual(t, len(order), 3, "golden set should span several series")
+	require.Equal(t, "No series", order[len(order)-1], "showtimes outside a series come last")
+	require.True(t, slices.IsSorted(order[:len(order)-1]), "series are listed by name: %v", order)
+
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{GroupBy: ptr("venue")}, &recordingStream{ctx: t.Context()})
+	require.ErrorContains(t, err, "invalid group by")
+}
+
+func TestUnit_ListShowtimes_ExplainSkips(t *testing.T) {
+	var out bytes.Buffer
+	prev := progressOutput
+	progressOutput = &out
+	t.Cleanup(func() { progressOutput = prev })
+
+	// Cached so the second run replays the first scrape's skips from the cache.
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, cinema21Golden(t), scraper.Cached(8, time.Hour)))
+	svc := ShowtimesService(registry)
+	// An hour-wide window in the middle of the night: every listing falls outside it.
+	req := &proto.ListShowtimesRequest{
+		From:         []proto.PdxSite{proto.PdxSite_Cinema21},
+		After:        timestamppb.New(time.Date(2026, 2, 24, 10, 0, 0, 0, time.UTC)),
+		Before:       timestamppb.New(time.Date(2026, 2, 24, 11, 0, 0, 0, time.UTC)),
+		ExplainSkips: ptr(true),
+	}
+
+	for run := range 2 {
+		out.Reset()
+		stream := &recordingStream{ctx: t.Context()}
+		require.NoError(t, svc.ListShowtimes(req, stream))
+		require.Empty(t, stream.responses, "run %d", run)
+
+		var total, after, before, parse int
+		_, err := fmt.Sscanf(out.String(), "Cinema21: skipped %d (%d before --after, %d after --before, %d unparseable)\n", &total, &after, &before, &parse)
+		require.NoError(t, err, "run %d summary: %q", run, out.String())
+		require.Positive(t, after, "listings before the window")
+		require.Positive(t, before, "listings after the window")
+		require.Zero(t, parse)
+		require.Equal(t, after+before, total)
+	}
+}
+
+// requestRecorder records the request each scrape was given and emits nothing.
+type requestRecorder struct {
+	internal.NoCapabilities
+	reqs []internal.ListShowtimesRequest
+}
+
+func (r *requestRecorder) Descriptor() string { return "recorder" }
+
+func (r *requestRecorder) ScrapeShowtimes(_ context.Context, req internal.ListShowtimesRequest) (<-chan internal.ShowtimeListItem, error) {
+	r.reqs = append(r.reqs, req)
+	ch := make(chan internal.ShowtimeListItem)
+	close(ch)
+	return ch, nil
+}
+
+func TestUnit_ListShowtimes_MaxRange(t *testing.T) {
+	after := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	list := func(t *testing.T, before time.Time, maxRange *string) (internal.ListShowtimesRequest, string, error) {
+		t.Helper()
+		logs := scrapertest.CaptureLogs(t)
+		recorder := &requestRecorder{}
+		svc := ShowtimesService(scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinemagic, recorder)))
+		err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+			From:     []proto.PdxSite{proto.PdxSite_Cinemagic},
+			After:    timestamppb.New(after),
+			Before:   timestamppb.New(before),
+			MaxRange: maxRange,
+		}, &recordingStream{ctx: t.Context()})
+		if err != nil {
+			return internal.ListShowtimesRequest{}, logs.String(), err
+		}
+		require.Len(t, recorder.reqs, 1)
+		return recorder.reqs[0], logs.String(), nil
+	}
+
+	t.Run("a range decades out is clamped to the default", func(t *testing.T) {
+		req, logs, err := list(t, after.AddDate(30, 0, 0), nil)
+		require.NoError(t, err)
+		require.Equal(t, after, req.After)
+		require.Equal(t, after.Add(defaultMaxRange), req.Before)
+		require.Contains(t, logs, "exceeds --max-range")
+	})
+
+	t.Run("--max-range in days", func(t *testing.T) {
+		req, logs, err := list(t, after.AddDate(1, 0, 0), ptr("90d"))
+		require.NoError(t, err)
+		require.Equal(t, after.AddDate(0, 0, 90), req.Before)
+		require.Contains(t, logs, "exceeds --max-range")
+	})
+
+	t.Run("ranges within the limit are untouched", func(t *testing.T) {
+		req, logs, err := list(t, after.AddDate(0, 1, 0), ptr("2160h"))
+		require.NoError(t, err)
+		require.Equal(t, after.AddDate(0, 1, 0), req.Before)
+		require.NotContains(t, logs, "exceeds --max-range")
+	})
+
+	t.Run("invalid --max-range", func(t *testing.T) {
+		for _, value := range []string{"ninety days", "0d", "-1h"} {
+			_, _, err := list(t, after.AddDate(0, 1, 0), ptr(value))
+			require.ErrorContains(t, err, "invalid max range", value)
+		}
+	})
+}
+
+func TestUnit_ListShowtimes_Accessibility(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	registry := scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinema21, scrapertest.Static{Showtimes: []internal.SourceShowtime{
+		{ID: "heat", Summary: "Heat", StartTime: start},
+		{ID: "heat-oc", Summary: "Heat", StartTime: start.Add(time.Hour), Screening: internal.ScreeningInfo{
+			Accessibility: []string{internal.AccessibilityOpenCaptions},
+		}},
+		{ID: "thief-ad", Summary: "Thief", StartTime: start.Add(2 * time.Hour), Screening: internal.ScreeningInfo{
+			Accessibility: []string{internal.AccessibilityAudioDescription, internal.AccessibilitySensoryFriendly},
+		}},
+	}}))
+	svc := ShowtimesService(registry)
+	list := func(t *testing.T, features ...string) ([]*proto.ListShowtimesResponse, error) {
+		t.Helper()
+		stream := &recordingStream{ctx: t.Context()}
+		err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+			From:          []proto.PdxSite{proto.PdxSite_Cinema21},
+			Accessibility: features,
+		}, stream)
+		return stream.responses, err
+	}
+
+	all, err := list(t)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Empty(t, all[0].GetShowtime().GetScreening().GetAccessibility())
+	require.Equal(t, []string{"open-captions"}, all[1].GetShowtime().GetScreening().GetAccessibility())
+	require.Equal(t, []string{"audio-description", "sensory-friendly"}, all[2].GetShowtime().GetScreening().GetAccessibility())
+
+	captioned, err := list(t, internal.AccessibilityOpenCaptions)
+	require.NoError(t, err)
+	require.Len(t, captioned, 1)
+	require.Equal(t, "heat-oc", captioned[0].GetShowtime().GetId())
+
+	either, err := list(t, internal.AccessibilityOpenCaptions, internal.AccessibilityAudioDescription)
+	require.NoError(t, err)
+	require.Len(t, either, 2, "any of the requested features matches")
+
+	_, err = list(t, "subtitles")
+	require.ErrorContains(t, err, `unknown accessibility feature "subtitles"`)
+}
+
+func TestUnit_ListShowtimes_DeadlineFromClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 15, 4, 5, 0, time.UTC)
+	prev := nowFunc
+	nowFunc = func() time.Time { return now }
+	t.Cleanup(func() { nowFunc = prev })
+	recorder := &requestRecorder{}
+	svc := ShowtimesService(scraper.NewRegistry(scraper.WithScraperForSite(proto.PdxSite_Cinemagic, recorder)))
+
+	err := svc.ListShowtimes(&proto.ListShowtimesRequest{
+		From:     []proto.PdxSite{proto.PdxSite_Cinemagic},
+		Deadline: ptr("10s"),
+	}, &recordingStream{ctx: t.Context()})
+	require.NoError(t, err)
+	require.Len(t, recorder.reqs, 1)
+	require.Equal(t, now.Add(10*time.Second), recorder.reqs[0].Deadline)
+}
@@ -86,8 +86,66 @@ type ListShowtimesRequest struct {
 	Anchor *string                `protobuf:"bytes,7,opt,name=anchor,proto3,oneof" json:"anchor,omitempty"`
 	// Display only: times are shown in this IANA timezone. Server ignores this.
 	OutputTimezone *string `protobuf:"bytes,8,opt,name=output_timezone,json=outputTimezone,proto3,oneof" json:"output_timezone,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Attach the TMDB candidates considered for each match to the response.
+	Explain *bool `protobuf:"varint,9,opt,name=explain,proto3,oneof" json:"explain,omitempty"`
+	// Skip cached results for this request but store the fresh ones, so the next query is fast and current.
+	Refresh *bool `protobuf:"varint,10,opt,name=refresh,proto3,oneof" json:"refresh,omitempty"`
+	// Print fan-out progress (e.g. "Cinemagic: fetched 12/52 dates") to stderr while scraping.
+	Progress *bool `protobuf:"varint,11,opt,name=progress,proto3,oneof" json:"progress,omitempty"`
+	// CLI convenience: the CLI resolves this into after/before for that day in the output timezone. Server ignores this.
+	Date *string `protobuf:"bytes,12,opt,name=date,proto3,oneof" json:"date,omitempty"`
+	// Keep at most this many showtimes per calendar day (in output_timezone, else server local time).
+	LimitPerDay *int32 `protobuf:"varint,13,opt,name=limit_per_day,json=limitPerDay,proto3,oneof" json:"limit_per_day,omitempty"`
+	// Drop showtimes whose title matches one of these (case-insensitive); pass multiple times.
+	ExcludeTitle []string `protobuf:"bytes,14,rep,name=exclude_title,json=excludeTitle,proto3" json:"exclude_title,omitempty"`
+	// Drop showtimes with one of these ids; pass multiple times.
+	ExcludeId []string `protobuf:"bytes,15,rep,name=exclude_id,json=excludeId,proto3" json:"exclude_id,omitempty"`
+	// CLI convenience: the CLI sets after to the time recorded in state_file, then records now. Server ignores this.
+	SinceLast *bool `protobuf:"varint,16,opt,name=since_last,json=sinceLast,proto3,oneof" json:"since_last,omitempty"`
+	// CLI convenience: where --since-last keeps its timestamp. Server ignores this.
+	StateFile *string `protobuf:"bytes,17,opt,name=state_file,json=stateFile,proto3,oneof" json:"state_file,omitempty"`
+	// Print a run-level enrichment cache summary (searches, details calls, hit rate) to stderr when done.
+	Trace *bool `protobuf:"varint,18,opt,name=trace,proto3,oneof" json:"trace,omitempty"`
+	// CLI convenience: the CLI sets after to now instead of the default of yesterday. Server ignores this.
+	Upcoming *bool `protobuf:"varint,19,opt,name=upcoming,proto3,oneof" json:"upcoming,omitempty"`
+	// Only list showtimes at venues within radius_km of this point, given as "LAT,LON".
+	Near *string `protobuf:"bytes,20,opt,name=near,proto3,oneof" json:"near,omitempty"`
+	// Radius in kilometers for near.
+	RadiusKm *float64 `protobuf:"fixed64,21,opt,name=radius_km,json=radiusKm,proto3,oneof" json:"radius_km,omitempty"`
+	// Keep summaries to the title alone; the subhed (e.g. "in 35mm") stays in screening.subhed.
+	NoSubhed *bool `protobuf:"varint,22,opt,name=no_subhed,json=noSubhed,proto3,oneof" json:"no_subhed,omitempty"`
+	// Keep the theater's advertised title as the summary; the TMDB match is still attached as movie.
+	PreferListedTitle *bool `protobuf:"varint,23,opt,name=prefer_listed_title,json=preferListedTitle,proto3,oneof" json:"prefer_listed_title,omitempty"`
+	// How summaries are composed: "title", "title-subhed" (default), or "series-title".
+	SummaryStyle *string `protobuf:"bytes,24,opt,name=summary_style,json=summaryStyle,proto3,oneof" json:"summary_style,omitempty"`
+	// Reduce descriptions to plain text (tags removed, entities decoded). Unset means true.
+	StripHtml *bool `protobuf:"varint,25,opt,name=strip_html,json=stripHtml,proto3,oneof" json:"strip_html,omitempty"`
+	// Print how many listings each site dropped and why (outside the range, unparseable) to stderr when done.
+	ExplainSkips *bool `protobuf:"varint,26,opt,name=explain_skips,json=explainSkips,proto3,oneof" json:"explain_skips,omitempty"`
+	// Site groups from the config's groups; the service expands them to their sites. --from takes group names too.
+	FromGroup []string `protobuf:"bytes,27,rep,name=from_group,json=fromGroup,proto3" json:"from_group,omitempty"`
+	// Wall-clock budget for the whole scrape (Go duration, e.g. 10s); sites still running are cut off.
+	Deadline *string `protobuf:"bytes,28,opt,name=deadline,proto3,oneof" json:"deadline,omitempty"`
+	// Keep only showtimes with a tickets link; some listings are informational only.
+	Bookable *bool `protobuf:"varint,29,opt,name=bookable,proto3,oneof" json:"bookable,omitempty"`
+	// Cluster showtimes under headers: "series" groups them by screening series ("No series" for the rest),
+	// each group sorted by time. The whole result is buffered before anything is sent.
+	GroupBy *string `protobuf:"bytes,30,opt,name=group_by,json=groupBy,proto3,oneof" json:"group_by,omitempty"`
+	// Longest span between after and before (Go duration or days, e.g. 90d); wider ranges are cut
+	// short with a warning so a far-off --before can't fan out into thousands of requests.
+	MaxRange *string `protobuf:"bytes,31,opt,name=max_range,json=maxRange,proto3,oneof" json:"max_range,omitempty"`
+	// CLI convenience: the CLI adds the sites (or groups) listed in this file to from. Server ignores this.
+	FromFile *string `protobuf:"bytes,32,opt,name=from_file,json=fromFile,proto3,oneof" json:"from_file,omitempty"`
+	// Keep only screenings with at least one of these access features (ScreeningInfo.accessibility values).
+	Accessibility []string `protobuf:"bytes,33,rep,name=accessibility,proto3" json:"accessibility,omitempty"`
+	// Keep only screenings listed as free admission (Showtime.admission "Free").
+	Free *bool `protobuf:"varint,34,opt,name=free,proto3,oneof" json:"free,omitempty"`
+	// CLI convenience: the CLI resolves these into after/before in the output timezone. Server ignores them.
+	Today         *bool `protobuf:"varint,35,opt,name=today,proto3,oneof" json:"today,omitempty"`
+	Tomorrow      *bool `protobuf:"varint,36,opt,name=tomorrow,proto3,oneof" json:"tomorrow,omitempty"`
+	ThisWeek      *bool `protobuf:"varint,37,opt,name=this_week,json=thisWeek,proto3,oneof" json:"this_week,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListShowtimesRequest) Reset() {
@@ -162,13 +220,219 @@ func (x *ListShowtimesRequest) GetOutputTimezone() string {
 	return ""
 }
 
+func (x *ListShowtimesRequest) GetExplain() bool {
+	if x != nil && x.Explain != nil {
+		return *x.Explain
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetRefresh() bool {
+	if x != nil && x.Refresh != nil {
+		return *x.Refresh
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetProgress() bool {
+	if x != nil && x.Progress != nil {
+		return *x.Progress
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetDate() string {
+	if x != nil && x.Date != nil {
+		return *x.Date
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetLimitPerDay() int32 {
+	if x != nil && x.LimitPerDay != nil {
+		return *x.LimitPerDay
+	}
+	return 0
+}
+
+func (x *ListShowtimesRequest) GetExcludeTitle() []string {
+	if x != nil {
+		return x.ExcludeTitle
+	}
+	return nil
+}
+
+func (x *ListShowtimesRequest) GetExcludeId() []string {
+	if x != nil {
+		return x.ExcludeId
+	}
+	return nil
+}
+
+func (x *ListShowtimesRequest) GetSinceLast() bool {
+	if x != nil && x.SinceLast != nil {
+		return *x.SinceLast
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetStateFile() string {
+	if x != nil && x.StateFile != nil {
+		return *x.StateFile
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetTrace() bool {
+	if x != nil && x.Trace != nil {
+		return *x.Trace
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetUpcoming() bool {
+	if x != nil && x.Upcoming != nil {
+		return *x.Upcoming
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetNear() string {
+	if x != nil && x.Near != nil {
+		return *x.Near
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetRadiusKm() float64 {
+	if x != nil && x.RadiusKm != nil {
+		return *x.RadiusKm
+	}
+	return 0
+}
+
+func (x *ListShowtimesRequest) GetNoSubhed() bool {
+	if x != nil && x.NoSubhed != nil {
+		return *x.NoSubhed
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetPreferListedTitle() bool {
+	if x != nil && x.PreferListedTitle != nil {
+		return *x.PreferListedTitle
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetSummaryStyle() string {
+	if x != nil && x.SummaryStyle != nil {
+		return *x.SummaryStyle
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetStripHtml() bool {
+	if x != nil && x.StripHtml != nil {
+		return *x.StripHtml
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetExplainSkips() bool {
+	if x != nil && x.ExplainSkips != nil {
+		return *x.ExplainSkips
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetFromGroup() []string {
+	if x != nil {
+		return x.FromGroup
+	}
+	return nil
+}
+
+func (x *ListShowtimesRequest) GetDeadline() string {
+	if x != nil && x.Deadline != nil {
+		return *x.Deadline
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetBookable() bool {
+	if x != nil && x.Bookable != nil {
+		return *x.Bookable
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetGroupBy() string {
+	if x != nil && x.GroupBy != nil {
+		return *x.GroupBy
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetMaxRange() string {
+	if x != nil && x.MaxRange != nil {
+		return *x.MaxRange
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetFromFile() string {
+	if x != nil && x.FromFile != nil {
+		return *x.FromFile
+	}
+	return ""
+}
+
+func (x *ListShowtimesRequest) GetAccessibility() []string {
+	if x != nil {
+		return x.Accessibility
+	}
+	return nil
+}
+
+func (x *ListShowtimesRequest) GetFree() bool {
+	if x != nil && x.Free != nil {
+		return *x.Free
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetToday() bool {
+	if x != nil && x.Today != nil {
+		return *x.Today
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetTomorrow() bool {
+	if x != nil && x.Tomorrow != nil {
+		return *x.Tomorrow
+	}
+	return false
+}
+
+func (x *ListShowtimesRequest) GetThisWeek() bool {
+	if x != nil && x.ThisWeek != nil {
+		return *x.ThisWeek
+	}
+	return false
+}
+
 type ListShowtimesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Showtime      *Showtime              `protobuf:"bytes,1,opt,name=showtime,proto3" json:"showtime,omitempty"`                             // the showtime (present for all messages except potentially the last)
-	NextAnchor    *string                `protobuf:"bytes,2,opt,name=next_anchor,json=nextAnchor,proto3,oneof" json:"next_anchor,omitempty"` // token for the next page (only set on the last message if more results exist)
-	Site          *PdxSite               `protobuf:"varint,3,opt,name=site,proto3,enum=showtimes.PdxSite,oneof" json:"site,omitempty"`       // source theater for correct per-row display when interleaved
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Showtime        *Showtime              `protobuf:"bytes,1,opt,name=showtime,proto3" json:"showtime,omitempty"`                                      // the showtime (present for all messages except potentially the last)
+	NextAnchor      *string                `protobuf:"bytes,2,opt,name=next_anchor,json=nextAnchor,proto3,oneof" json:"next_anchor,omitempty"`          // token for the next page (only set on the last message if more results exist)
+	Site            *PdxSite               `protobuf:"varint,3,opt,name=site,proto3,enum=showtimes.PdxSite,oneof" json:"site,omitempty"`                // source theater for correct per-row display when interleaved
+	MatchCandidates []*MatchCandidate      `protobuf:"bytes,4,rep,name=match_candidates,json=matchCandidates,proto3" json:"match_candidates,omitempty"` // TMDB candidates scored for this showtime (only with --explain)
+	Group           *string                `protobuf:"bytes,5,opt,name=group,proto3,oneof" json:"group,omitempty"`                                      // header this showtime is listed under (only with --group-by)
+	GroupStart      bool                   `protobuf:"varint,6,opt,name=group_start,json=groupStart,proto3" json:"group_start,omitempty"`               // first showtime of its group, where a header belongs
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListShowtimesResponse) Reset() {
@@ -222,6 +486,104 @@ func (x *ListShowtimesResponse) GetSite() PdxSite {
 	return PdxSite_None
 }
 
+func (x *ListShowtimesResponse) GetMatchCandidates() []*MatchCandidate {
+	if x != nil {
+		return x.MatchCandidates
+	}
+	return nil
+}
+
+func (x *ListShowtimesResponse) GetGroup() string {
+	if x != nil && x.Group != nil {
+		return *x.Group
+	}
+	return ""
+}
+
+func (x *ListShowtimesResponse) GetGroupStart() bool {
+	if x != nil {
+		return x.GroupStart
+	}
+	return false
+}
+
+// MatchCandidate is one TMDB search result considered when matching a showtime to a movie.
+type MatchCandidate struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Title              string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	TmdbId             int64                  `protobuf:"varint,2,opt,name=tmdb_id,json=tmdbId,proto3" json:"tmdb_id,omitempty"`
+	DirectorMatch      bool                   `protobuf:"varint,3,opt,name=director_match,json=directorMatch,proto3" json:"director_match,omitempty"`
+	RuntimeDiffMinutes *int32                 `protobuf:"varint,4,opt,name=runtime_diff_minutes,json=runtimeDiffMinutes,proto3,oneof" json:"runtime_diff_minutes,omitempty"` // unset when no runtime hint or details were available
+	Chosen             bool                   `protobuf:"varint,5,opt,name=chosen,proto3" json:"chosen,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MatchCandidate) Reset() {
+	*x = MatchCandidate{}
+	mi := &file_showtimes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchCandidate) ProtoMessage() {}
+
+func (x *MatchCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_showtimes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchCandidate.ProtoReflect.Descriptor instead.
+func (*MatchCandidate) Descriptor() ([]byte, []int) {
+	return file_showtimes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MatchCandidate) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MatchCandidate) GetTmdbId() int64 {
+	if x != nil {
+		return x.TmdbId
+	}
+	return 0
+}
+
+func (x *MatchCandidate) GetDirectorMatch() bool {
+	if x != nil {
+		return x.DirectorMatch
+	}
+	return false
+}
+
+func (x *MatchCandidate) GetRuntimeDiffMinutes() int32 {
+	if x != nil && x.RuntimeDiffMinutes != nil {
+		return *x.RuntimeDiffMinutes
+	}
+	return 0
+}
+
+func (x *MatchCandidate) GetChosen() bool {
+	if x != nil {
+		return x.Chosen
+	}
+	return false
+}
+
 type Showtime struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -230,15 +592,19 @@ type Showtime struct {
 	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
 	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3,oneof" json:"end_time,omitempty"`
 	Location      *string                `protobuf:"bytes,6,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	SoldOut       *bool                  `protobuf:"varint,7,opt,name=sold_out,json=soldOut,proto3,oneof" json:"sold_out,omitempty"`      // set only when ticket availability was checked (--check-tickets)
+	Repeat        bool                   `protobuf:"varint,8,opt,name=repeat,proto3" json:"repeat,omitempty"`                             // a later session of a film already listed earlier in this response
+	VenueName     *string                `protobuf:"bytes,9,opt,name=venue_name,json=venueName,proto3,oneof" json:"venue_name,omitempty"` // short theater name, e.g. "Cinema 21"; location is the full address
 	Screening     *ScreeningInfo         `protobuf:"bytes,10,opt,name=screening,proto3" json:"screening,omitempty"`
 	Movie         *MovieInfo             `protobuf:"bytes,11,opt,name=movie,proto3" json:"movie,omitempty"`
+	Admission     *string                `protobuf:"bytes,12,opt,name=admission,proto3,oneof" json:"admission,omitempty"` // price as listed by the venue, e.g. "$9", or "Free"; unset when unknown
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Showtime) Reset() {
 	*x = Showtime{}
-	mi := &file_showtimes_proto_msgTypes[2]
+	mi := &file_showtimes_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +616,7 @@ func (x *Showtime) String() string {
 func (*Showtime) ProtoMessage() {}
 
 func (x *Showtime) ProtoReflect() protoreflect.Message {
-	mi := &file_showtimes_proto_msgTypes[2]
+	mi := &file_showtimes_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +629,7 @@ func (x *Showtime) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Showtime.ProtoReflect.Descriptor instead.
 func (*Showtime) Descriptor() ([]byte, []int) {
-	return file_showtimes_proto_rawDescGZIP(), []int{2}
+	return file_showtimes_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Showtime) GetId() string {
@@ -308,6 +674,27 @@ func (x *Showtime) GetLocation() string {
 	return ""
 }
 
+func (x *Showtime) GetSoldOut() bool {
+	if x != nil && x.SoldOut != nil {
+		return *x.SoldOut
+	}
+	return false
+}
+
+func (x *Showtime) GetRepeat() bool {
+	if x != nil {
+		return x.Repeat
+	}
+	return false
+}
+
+func (x *Showtime) GetVenueName() string {
+	if x != nil && x.VenueName != nil {
+		return *x.VenueName
+	}
+	return ""
+}
+
 func (x *Showtime) GetScreening() *ScreeningInfo {
 	if x != nil {
 		return x.Screening
@@ -322,12 +709,20 @@ func (x *Showtime) GetMovie() *MovieInfo {
 	return nil
 }
 
+func (x *Showtime) GetAdmission() string {
+	if x != nil && x.Admission != nil {
+		return *x.Admission
+	}
+	return ""
+}
+
 type ScreeningInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         *string                `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
 	Series        *string                `protobuf:"bytes,2,opt,name=series,proto3,oneof" json:"series,omitempty"`
 	Host          *string                `protobuf:"bytes,3,opt,name=host,proto3,oneof" json:"host,omitempty"`
-	Subhed        *string                `protobuf:"bytes,4,opt,name=subhed,proto3,oneof" json:"subhed,omitempty"` // e.g. "in 35mm" from venue listing
+	Subhed        *string                `protobuf:"bytes,4,opt,name=subhed,proto3,oneof" json:"subhed,omitempty"`         // e.g. "in 35mm" from venue listing
+	Accessibility []string               `protobuf:"bytes,5,rep,name=accessibility,proto3" json:"accessibility,omitempty"` // access features: "open-captions", "audio-description", "sensory-friendly"
 	Links         []*Link                `protobuf:"bytes,10,rep,name=links,proto3" json:"links,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -335,7 +730,7 @@ type ScreeningInfo struct {
 
 func (x *ScreeningInfo) Reset() {
 	*x = ScreeningInfo{}
-	mi := &file_showtimes_proto_msgTypes[3]
+	mi := &file_showtimes_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +742,7 @@ func (x *ScreeningInfo) String() string {
 func (*ScreeningInfo) ProtoMessage() {}
 
 func (x *ScreeningInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_showtimes_proto_msgTypes[3]
+	mi := &file_showtimes_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +755,7 @@ func (x *ScreeningInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScreeningInfo.ProtoReflect.Descriptor instead.
 func (*ScreeningInfo) Descriptor() ([]byte, []int) {
-	return file_showtimes_proto_rawDescGZIP(), []int{3}
+	return file_showtimes_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ScreeningInfo) GetTitle() string {
@@ -391,6 +786,13 @@ func (x *ScreeningInfo) GetSubhed() string {
 	return ""
 }
 
+func (x *ScreeningInfo) GetAccessibility() []string {
+	if x != nil {
+		return x.Accessibility
+	}
+	return nil
+}
+
 func (x *ScreeningInfo) GetLinks() []*Link {
 	if x != nil {
 		return x.Links
@@ -403,6 +805,7 @@ type MovieInfo struct {
 	Title         *string                `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
 	Tagline       *string                `protobuf:"bytes,2,opt,name=tagline,proto3,oneof" json:"tagline,omitempty"`
 	Overview      *string                `protobuf:"bytes,3,opt,name=overview,proto3,oneof" json:"overview,omitempty"`
+	Collection    *string                `protobuf:"bytes,4,opt,name=collection,proto3,oneof" json:"collection,omitempty"` // TMDB collection (franchise) the film belongs to, e.g. "Alien Collection"
 	Links         []*Link                `protobuf:"bytes,10,rep,name=links,proto3" json:"links,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -410,7 +813,7 @@ type MovieInfo struct {
 
 func (x *MovieInfo) Reset() {
 	*x = MovieInfo{}
-	mi := &file_showtimes_proto_msgTypes[4]
+	mi := &file_showtimes_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -422,7 +825,7 @@ func (x *MovieInfo) String() string {
 func (*MovieInfo) ProtoMessage() {}
 
 func (x *MovieInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_showtimes_proto_msgTypes[4]
+	mi := &file_showtimes_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +838,7 @@ func (x *MovieInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MovieInfo.ProtoReflect.Descriptor instead.
 func (*MovieInfo) Descriptor() ([]byte, []int) {
-	return file_showtimes_proto_rawDescGZIP(), []int{4}
+	return file_showtimes_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *MovieInfo) GetTitle() string {
@@ -459,6 +862,13 @@ func (x *MovieInfo) GetOverview() string {
 	return ""
 }
 
+func (x *MovieInfo) GetCollection() string {
+	if x != nil && x.Collection != nil {
+		return *x.Collection
+	}
+	return ""
+}
+
 func (x *MovieInfo) GetLinks() []*Link {
 	if x != nil {
 		return x.Links
@@ -470,13 +880,14 @@ type Link struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Href          string                 `protobuf:"bytes,1,opt,name=href,proto3" json:"href,omitempty"`
 	Display       *string                `protobuf:"bytes,10,opt,name=display,proto3,oneof" json:"display,omitempty"`
+	Rel           *string                `protobuf:"bytes,11,opt,name=rel,proto3,oneof" json:"rel,omitempty"` // what the link points at: "tickets", "info", "series", or "tmdb"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Link) Reset() {
 	*x = Link{}
-	mi := &file_showtimes_proto_msgTypes[5]
+	mi := &file_showtimes_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -488,7 +899,7 @@ func (x *Link) String() string {
 func (*Link) ProtoMessage() {}
 
 func (x *Link) ProtoReflect() protoreflect.Message {
-	mi := &file_showtimes_proto_msgTypes[5]
+	mi := &file_showtimes_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,7 +912,7 @@ func (x *Link) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Link.ProtoReflect.Descriptor instead.
 func (*Link) Descriptor() ([]byte, []int) {
-	return file_showtimes_proto_rawDescGZIP(), []int{5}
+	return file_showtimes_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Link) GetHref() string {
@@ -518,16 +929,29 @@ func (x *Link) GetDisplay() string {
 	return ""
 }
 
+func (x *Link) GetRel() string {
+	if x != nil && x.Rel != nil {
+		return *x.Rel
+	}
+	return ""
+}
+
 type ShowtimeConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tmdb          *TMDBConfig            `protobuf:"bytes,1,opt,name=tmdb,proto3" json:"tmdb,omitempty"`
+	LogHttp       bool                   `protobuf:"varint,2,opt,name=log_http,json=logHttp,proto3" json:"log_http,omitempty"`
+	CheckTickets  bool                   `protobuf:"varint,3,opt,name=check_tickets,json=checkTickets,proto3" json:"check_tickets,omitempty"`
+	FetchSynopses bool                   `protobuf:"varint,4,opt,name=fetch_synopses,json=fetchSynopses,proto3" json:"fetch_synopses,omitempty"`
+	NoBrowser     bool                   `protobuf:"varint,5,opt,name=no_browser,json=noBrowser,proto3" json:"no_browser,omitempty"`
+	// Named site groups --from can select, e.g. repertory: {sites: [HollywoodTheatre, Cinema21]}.
+	Groups        map[string]*SiteGroup `protobuf:"bytes,6,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShowtimeConfig) Reset() {
 	*x = ShowtimeConfig{}
-	mi := &file_showtimes_proto_msgTypes[6]
+	mi := &file_showtimes_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -539,7 +963,7 @@ func (x *ShowtimeConfig) String() string {
 func (*ShowtimeConfig) ProtoMessage() {}
 
 func (x *ShowtimeConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_showtimes_proto_msgTypes[6]
+	mi := &file_showtimes_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +976,7 @@ func (x *ShowtimeConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShowtimeConfig.ProtoReflect.Descriptor instead.
 func (*ShowtimeConfig) Descriptor() ([]byte, []int) {
-	return file_showtimes_proto_rawDescGZIP(), []int{6}
+	return file_showtimes_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ShowtimeConfig) GetTmdb() *TMDBConfig {
@@ -562,16 +986,97 @@ func (x *ShowtimeConfig) GetTmdb() *TMDBConfig {
 	return nil
 }
 
-type TMDBConfig struct {
+func (x *ShowtimeConfig) GetLogHttp() bool {
+	if x != nil {
+		return x.LogHttp
+	}
+	return false
+}
+
+func (x *ShowtimeConfig) GetCheckTickets() bool {
+	if x != nil {
+		return x.CheckTickets
+	}
+	return false
+}
+
+func (x *ShowtimeConfig) GetFetchSynopses() bool {
+	if x != nil {
+		return x.FetchSynopses
+	}
+	return false
+}
+
+func (x *ShowtimeConfig) GetNoBrowser() bool {
+	if x != nil {
+		return x.NoBrowser
+	}
+	return false
+}
+
+func (x *ShowtimeConfig) GetGroups() map[string]*SiteGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type SiteGroup struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Sites         []PdxSite              `protobuf:"varint,1,rep,packed,name=sites,proto3,enum=showtimes.PdxSite" json:"sites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SiteGroup) Reset() {
+	*x = SiteGroup{}
+	mi := &file_showtimes_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SiteGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SiteGroup) ProtoMessage() {}
+
+func (x *SiteGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_showtimes_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SiteGroup.ProtoReflect.Descriptor instead.
+func (*SiteGroup) Descriptor() ([]byte, []int) {
+	return file_showtimes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SiteGroup) GetSites() []PdxSite {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
+type TMDBConfig struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// Directory movie details are cached in between runs (default: the user cache dir's pdx-watcher/tmdb).
+	CacheDir      string `protobuf:"bytes,2,opt,name=cache_dir,json=cacheDir,proto3" json:"cache_dir,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TMDBConfig) Reset() {
 	*x = TMDBConfig{}
-	mi := &file_showtimes_proto_msgTypes[7]
+	mi := &file_showtimes_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -583,7 +1088,7 @@ func (x *TMDBConfig) String() string {
 func (*TMDBConfig) ProtoMessage() {}
 
 func (x *TMDBConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_showtimes_proto_msgTypes[7]
+	mi := &file_showtimes_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,7 +1101,7 @@ func (x *TMDBConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TMDBConfig.ProtoReflect.Descriptor instead.
 func (*TMDBConfig) Descriptor() ([]byte, []int) {
-	return file_showtimes_proto_rawDescGZIP(), []int{7}
+	return file_showtimes_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *TMDBConfig) GetApiKey() string {
@@ -606,36 +1111,157 @@ func (x *TMDBConfig) GetApiKey() string {
 	return ""
 }
 
+func (x *TMDBConfig) GetCacheDir() string {
+	if x != nil {
+		return x.CacheDir
+	}
+	return ""
+}
+
 var File_showtimes_proto protoreflect.FileDescriptor
 
 const file_showtimes_proto_rawDesc = "" +
 	"\n" +
-	"\x0fshowtimes.proto\x12\tshowtimes\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16proto/cli/v1/cli.proto\"\xb9\x06\n" +
-	"\x14ListShowtimesRequest\x12\xa9\x01\n" +
-	"\x04from\x18\x01 \x03(\x0e2\x12.showtimes.PdxSiteB\x80\x01\x92\xb5\x18|\n" +
-	"\x04from\x1anTheater(s) to list showtimes from (hollywood-theatre, cinemagic, cinema21). Repeat for multiple; omit for all.*\x04SITER\x04from\x12r\n" +
-	"\x05after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB;\x92\xb5\x187\n" +
-	"\x05after\x1a(Only showtimes after this time (RFC3339)*\x04TIMEH\x00R\x05after\x88\x01\x01\x12v\n" +
-	"\x06before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB=\x92\xb5\x189\n" +
-	"\x06before\x1a)Only showtimes before this time (RFC3339)*\x04TIMEH\x01R\x06before\x88\x01\x01\x12K\n" +
+	"\x0fshowtimes.proto\x12\tshowtimes\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16proto/cli/v1/cli.proto\"\xe3+\n" +
+	"\x14ListShowtimesRequest\x12\xc2\x01\n" +
+	"\x04from\x18\x01 \x03(\x0e2\x12.showtimes.PdxSiteB\x99\x01\x92\xb5\x18\x94\x01\n" +
+	"\x04from\x1a\x85\x01Theater(s) or configured group(s) to list showtimes from (hollywood-theatre, cinemagic, cinema21). Repeat for multiple; omit for all.*\x04SITER\x04from\x12\x9d\x01\n" +
+	"\x05after\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampBf\x92\xb5\x18b\n" +
+	"\x05after\x1aSOnly showtimes after this time (RFC3339 or YYYY-MM-DD; default: $PDX_WATCHER_AFTER)*\x04TIMEH\x00R\x05after\x88\x01\x01\x12\xa2\x01\n" +
+	"\x06before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampBi\x92\xb5\x18e\n" +
+	"\x06before\x1aUOnly showtimes before this time (RFC3339 or YYYY-MM-DD; default: $PDX_WATCHER_BEFORE)*\x04TIMEH\x01R\x06before\x88\x01\x01\x12K\n" +
 	"\x05limit\x18\x06 \x01(\x05B0\x92\xb5\x18,\n" +
 	"\x05limit\x1a Max number of showtimes per page*\x01NH\x02R\x05limit\x88\x01\x01\x12b\n" +
 	"\x06anchor\x18\a \x01(\tBE\x92\xb5\x18A\n" +
 	"\x06anchor\x1a0Page token from previous response for pagination*\x05TOKENH\x03R\x06anchor\x88\x01\x01\x12\x99\x01\n" +
 	"\x0foutput_timezone\x18\b \x01(\tBk\x92\xb5\x18g\n" +
-	"\btimezone\x1aWDisplay times in this IANA timezone (e.g. America/Los_Angeles). Default: CLI local time*\x02TZH\x04R\x0eoutputTimezone\x88\x01\x01B\b\n" +
+	"\btimezone\x1aWDisplay times in this IANA timezone (e.g. America/Los_Angeles). Default: CLI local time*\x02TZH\x04R\x0eoutputTimezone\x88\x01\x01\x12\x95\x01\n" +
+	"\aexplain\x18\t \x01(\bBv\x92\xb5\x18r\n" +
+	"\aexplain\x1agShow the TMDB candidates considered for each showtime with their director-match and runtime-diff scoresH\x05R\aexplain\x88\x01\x01\x12i\n" +
+	"\arefresh\x18\n" +
+	" \x01(\bBJ\x92\xb5\x18F\n" +
+	"\arefresh\x1a;Scrape fresh results and replace the cached entry with themH\x06R\arefresh\x88\x01\x01\x12x\n" +
+	"\bprogress\x18\v \x01(\bBW\x92\xb5\x18S\n" +
+	"\bprogress\x1aGPrint scrape progress (e.g. \"Cinemagic: fetched 12/52 dates\") to stderrH\aR\bprogress\x88\x01\x01\x12\x9d\x01\n" +
+	"\x04date\x18\f \x01(\tB\x83\x01\x92\xb5\x18\x7f\n" +
+	"\x04date\x1aqList showtimes for a single day (YYYY-MM-DD) in the output timezone (not with --after, --before, or --since-last)*\x04DATEH\bR\x04date\x88\x01\x01\x12\x9b\x01\n" +
+	"\rlimit_per_day\x18\r \x01(\x05Br\x92\xb5\x18n\n" +
+	"\rlimit-per-day\x1aZKeep at most N showtimes per calendar day in the output timezone (balanced multi-day view)*\x01NH\tR\vlimitPerDay\x88\x01\x01\x12\x87\x01\n" +
+	"\rexclude_title\x18\x0e \x03(\tBb\x92\xb5\x18^\n" +
+	"\rexclude-title\x1aFHide showtimes with this title (case-insensitive); pass multiple times*\x05TITLER\fexcludeTitle\x12h\n" +
+	"\n" +
+	"exclude_id\x18\x0f \x03(\tBI\x92\xb5\x18E\n" +
+	"\n" +
+	"exclude-id\x1a3Hide the showtime with this id; pass multiple times*\x02IDR\texcludeId\x12\xa7\x01\n" +
+	"\n" +
+	"since_last\x18\x10 \x01(\bB\x82\x01\x92\xb5\x18~\n" +
+	"\n" +
+	"since-last\x1apOnly list showtimes after the previous --since-last run (tracked in --state-file; not with --date or --upcoming)H\n" +
+	"R\tsinceLast\x88\x01\x01\x12\x8b\x01\n" +
+	"\n" +
+	"state_file\x18\x11 \x01(\tBg\x92\xb5\x18c\n" +
+	"\n" +
+	"state-file\x1aOState file for --since-last (default: <user config dir>/pdx-watcher/since-last)*\x04PATHH\vR\tstateFile\x88\x01\x01\x12\x88\x01\n" +
+	"\x05trace\x18\x12 \x01(\bBm\x92\xb5\x18i\n" +
+	"\x05trace\x1a`Print enrichment cache stats (searches, cache hits, details calls, hit rate) to stderr when doneH\fR\x05trace\x88\x01\x01\x12\x85\x01\n" +
+	"\bupcoming\x18\x13 \x01(\bBd\x92\xb5\x18`\n" +
+	"\bupcoming\x1aTOnly list showtimes from now forward (not with --after, --date, or a range shortcut)H\rR\bupcoming\x88\x01\x01\x12o\n" +
+	"\x04near\x18\x14 \x01(\tBV\x92\xb5\x18R\n" +
+	"\x04near\x1aAOnly list showtimes at venues near this point (requires --radius)*\aLAT,LONH\x0eR\x04near\x88\x01\x01\x12S\n" +
+	"\tradius_km\x18\x15 \x01(\x01B1\x92\xb5\x18-\n" +
+	"\x06radius\x1a\x1fRadius in kilometers for --near*\x02KMH\x0fR\bradiusKm\x88\x01\x01\x12r\n" +
+	"\tno_subhed\x18\x16 \x01(\bBP\x92\xb5\x18L\n" +
+	"\tno-subhed\x1a?Don't append the screening subhed (e.g. \"in 35mm\") to summariesH\x10R\bnoSubhed\x88\x01\x01\x12\x93\x01\n" +
+	"\x13prefer_listed_title\x18\x17 \x01(\bB^\x92\xb5\x18Z\n" +
+	"\x13prefer-listed-title\x1aCUse the theater's advertised title as the summary instead of TMDB'sH\x11R\x11preferListedTitle\x88\x01\x01\x12\xbb\x01\n" +
+	"\rsummary_style\x18\x18 \x01(\tB\x90\x01\x92\xb5\x18\x8b\x01\n" +
+	"\rsummary-style\x1asHow to compose summaries: title, title-subhed (e.g. \"Heat - in 35mm\"; default), or series-title (e.g. \"70mm: Heat\")*\x05STYLEH\x12R\fsummaryStyle\x88\x01\x01\x12\xc2\x01\n" +
+	"\n" +
+	"strip_html\x18\x19 \x01(\bB\x9d\x01\x92\xb5\x18\x98\x01\n" +
+	"\n" +
+	"strip-html\x1a\x89\x01Reduce descriptions to plain text, removing tags and decoding entities like &amp; (default: true; --strip-html=false passes them through)H\x13R\tstripHtml\x88\x01\x01\x12\xb4\x01\n" +
+	"\rexplain_skips\x18\x1a \x01(\bB\x89\x01\x92\xb5\x18\x84\x01\n" +
+	"\rexplain-skips\x1asPrint how many listings each site skipped and why (before --after, after --before, unparseable) to stderr when doneH\x14R\fexplainSkips\x88\x01\x01\x12\xa1\x01\n" +
+	"\n" +
+	"from_group\x18\x1b \x03(\tB\x81\x01\x92\xb5\x18}\n" +
+	"\n" +
+	"from-group\x1ahSite group(s) defined under groups in the config to list showtimes from (--from accepts group names too)*\x05GROUPR\tfromGroup\x12\xb9\x01\n" +
+	"\bdeadline\x18\x1c \x01(\tB\x97\x01\x92\xb5\x18\x92\x01\n" +
+	"\bdeadline\x1a|Stop scraping after this long (e.g. 10s); sites that haven't finished contribute what they have and are logged as incomplete*\bDURATIONH\x15R\bdeadline\x88\x01\x01\x12d\n" +
+	"\bbookable\x18\x1d \x01(\bBC\x92\xb5\x18?\n" +
+	"\bbookable\x1a3Only list showtimes that have a link to buy ticketsH\x16R\bbookable\x88\x01\x01\x12\xb5\x01\n" +
+	"\bgroup_by\x18\x1e \x01(\tB\x94\x01\x92\xb5\x18\x8f\x01\n" +
+	"\bgroup-by\x1a|Cluster showtimes under headers, each sorted by time: series (e.g. all \"70mm\" screenings together; \"No series\" for the rest)*\x05FIELDH\x17R\agroupBy\x88\x01\x01\x12\xbd\x01\n" +
+	"\tmax_range\x18\x1f \x01(\tB\x9a\x01\x92\xb5\x18\x95\x01\n" +
+	"\tmax-range\x1a~Longest time range to list (e.g. 90d or 2160h; default 367d); a wider --after/--before range is shortened to it with a warning*\bDURATIONH\x18R\bmaxRange\x88\x01\x01\x12\xa5\x01\n" +
+	"\tfrom_file\x18  \x01(\tB\x82\x01\x92\xb5\x18~\n" +
+	"\tfrom-file\x1akRead sites or groups to list from, one per line (blank lines and # comments ignored), in addition to --from*\x04PATHH\x19R\bfromFile\x88\x01\x01\x12\xd6\x01\n" +
+	"\raccessibility\x18! \x03(\tB\xaf\x01\x92\xb5\x18\xaa\x01\n" +
+	"\raccessibility\x1a\x8f\x01Only list screenings with this access feature: open-captions, audio-description, or sensory-friendly; pass multiple times to accept any of them*\aFEATURER\raccessibility\x12M\n" +
+	"\x04free\x18\" \x01(\bB4\x92\xb5\x180\n" +
+	"\x04free\x1a(Only list screenings with free admissionH\x1aR\x04free\x88\x01\x01\x12\x8b\x01\n" +
+	"\x05today\x18# \x01(\bBp\x92\xb5\x18l\n" +
+	"\x05today\x1acList showtimes for the current day in the output timezone (can't be combined with --after/--before)H\x1bR\x05today\x88\x01\x01\x12\x91\x01\n" +
+	"\btomorrow\x18$ \x01(\bBp\x92\xb5\x18l\n" +
+	"\btomorrow\x1a`List showtimes for the next day in the output timezone (can't be combined with --after/--before)H\x1cR\btomorrow\x88\x01\x01\x12\xab\x01\n" +
+	"\tthis_week\x18% \x01(\bB\x88\x01\x92\xb5\x18\x83\x01\n" +
+	"\tthis-week\x1avList showtimes from the start of today through Sunday in the output timezone (can't be combined with --after/--before)H\x1dR\bthisWeek\x88\x01\x01B\b\n" +
 	"\x06_afterB\t\n" +
 	"\a_beforeB\b\n" +
 	"\x06_limitB\t\n" +
 	"\a_anchorB\x12\n" +
-	"\x10_output_timezone\"\xb4\x01\n" +
+	"\x10_output_timezoneB\n" +
+	"\n" +
+	"\b_explainB\n" +
+	"\n" +
+	"\b_refreshB\v\n" +
+	"\t_progressB\a\n" +
+	"\x05_dateB\x10\n" +
+	"\x0e_limit_per_dayB\r\n" +
+	"\v_since_lastB\r\n" +
+	"\v_state_fileB\b\n" +
+	"\x06_traceB\v\n" +
+	"\t_upcomingB\a\n" +
+	"\x05_nearB\f\n" +
+	"\n" +
+	"_radius_kmB\f\n" +
+	"\n" +
+	"_no_subhedB\x16\n" +
+	"\x14_prefer_listed_titleB\x10\n" +
+	"\x0e_summary_styleB\r\n" +
+	"\v_strip_htmlB\x10\n" +
+	"\x0e_explain_skipsB\v\n" +
+	"\t_deadlineB\v\n" +
+	"\t_bookableB\v\n" +
+	"\t_group_byB\f\n" +
+	"\n" +
+	"_max_rangeB\f\n" +
+	"\n" +
+	"_from_fileB\a\n" +
+	"\x05_freeB\b\n" +
+	"\x06_todayB\v\n" +
+	"\t_tomorrowB\f\n" +
+	"\n" +
+	"_this_week\"\xc0\x02\n" +
 	"\x15ListShowtimesResponse\x12/\n" +
 	"\bshowtime\x18\x01 \x01(\v2\x13.showtimes.ShowtimeR\bshowtime\x12$\n" +
 	"\vnext_anchor\x18\x02 \x01(\tH\x00R\n" +
 	"nextAnchor\x88\x01\x01\x12+\n" +
-	"\x04site\x18\x03 \x01(\x0e2\x12.showtimes.PdxSiteH\x01R\x04site\x88\x01\x01B\x0e\n" +
+	"\x04site\x18\x03 \x01(\x0e2\x12.showtimes.PdxSiteH\x01R\x04site\x88\x01\x01\x12D\n" +
+	"\x10match_candidates\x18\x04 \x03(\v2\x19.showtimes.MatchCandidateR\x0fmatchCandidates\x12\x19\n" +
+	"\x05group\x18\x05 \x01(\tH\x02R\x05group\x88\x01\x01\x12\x1f\n" +
+	"\vgroup_start\x18\x06 \x01(\bR\n" +
+	"groupStartB\x0e\n" +
 	"\f_next_anchorB\a\n" +
-	"\x05_site\"\x95\x03\n" +
+	"\x05_siteB\b\n" +
+	"\x06_group\"\xce\x01\n" +
+	"\x0eMatchCandidate\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x17\n" +
+	"\atmdb_id\x18\x02 \x01(\x03R\x06tmdbId\x12%\n" +
+	"\x0edirector_match\x18\x03 \x01(\bR\rdirectorMatch\x125\n" +
+	"\x14runtime_diff_minutes\x18\x04 \x01(\x05H\x00R\x12runtimeDiffMinutes\x88\x01\x01\x12\x16\n" +
+	"\x06chosen\x18\x05 \x01(\bR\x06chosenB\x17\n" +
+	"\x15_runtime_diff_minutes\"\xbe\x04\n" +
 	"\bShowtime\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\asummary\x18\x02 \x01(\tR\asummary\x12%\n" +
@@ -643,46 +1269,79 @@ const file_showtimes_proto_rawDesc = "" +
 	"\n" +
 	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tstartTime\x88\x01\x01\x12:\n" +
 	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\aendTime\x88\x01\x01\x12\x1f\n" +
-	"\blocation\x18\x06 \x01(\tH\x03R\blocation\x88\x01\x01\x126\n" +
+	"\blocation\x18\x06 \x01(\tH\x03R\blocation\x88\x01\x01\x12\x1e\n" +
+	"\bsold_out\x18\a \x01(\bH\x04R\asoldOut\x88\x01\x01\x12\x16\n" +
+	"\x06repeat\x18\b \x01(\bR\x06repeat\x12\"\n" +
+	"\n" +
+	"venue_name\x18\t \x01(\tH\x05R\tvenueName\x88\x01\x01\x126\n" +
 	"\tscreening\x18\n" +
 	" \x01(\v2\x18.showtimes.ScreeningInfoR\tscreening\x12*\n" +
-	"\x05movie\x18\v \x01(\v2\x14.showtimes.MovieInfoR\x05movieB\x0e\n" +
+	"\x05movie\x18\v \x01(\v2\x14.showtimes.MovieInfoR\x05movie\x12!\n" +
+	"\tadmission\x18\f \x01(\tH\x06R\tadmission\x88\x01\x01B\x0e\n" +
 	"\f_descriptionB\r\n" +
 	"\v_start_timeB\v\n" +
 	"\t_end_timeB\v\n" +
-	"\t_location\"\xcd\x01\n" +
+	"\t_locationB\v\n" +
+	"\t_sold_outB\r\n" +
+	"\v_venue_nameB\f\n" +
+	"\n" +
+	"_admission\"\xf3\x01\n" +
 	"\rScreeningInfo\x12\x19\n" +
 	"\x05title\x18\x01 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1b\n" +
 	"\x06series\x18\x02 \x01(\tH\x01R\x06series\x88\x01\x01\x12\x17\n" +
 	"\x04host\x18\x03 \x01(\tH\x02R\x04host\x88\x01\x01\x12\x1b\n" +
-	"\x06subhed\x18\x04 \x01(\tH\x03R\x06subhed\x88\x01\x01\x12%\n" +
+	"\x06subhed\x18\x04 \x01(\tH\x03R\x06subhed\x88\x01\x01\x12$\n" +
+	"\raccessibility\x18\x05 \x03(\tR\raccessibility\x12%\n" +
 	"\x05links\x18\n" +
 	" \x03(\v2\x0f.showtimes.LinkR\x05linksB\b\n" +
 	"\x06_titleB\t\n" +
 	"\a_seriesB\a\n" +
 	"\x05_hostB\t\n" +
-	"\a_subhed\"\xb0\x01\n" +
+	"\a_subhed\"\xe4\x01\n" +
 	"\tMovieInfo\x12\x19\n" +
 	"\x05title\x18\x01 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
 	"\atagline\x18\x02 \x01(\tH\x01R\atagline\x88\x01\x01\x12\x1f\n" +
-	"\boverview\x18\x03 \x01(\tH\x02R\boverview\x88\x01\x01\x12%\n" +
+	"\boverview\x18\x03 \x01(\tH\x02R\boverview\x88\x01\x01\x12#\n" +
+	"\n" +
+	"collection\x18\x04 \x01(\tH\x03R\n" +
+	"collection\x88\x01\x01\x12%\n" +
 	"\x05links\x18\n" +
 	" \x03(\v2\x0f.showtimes.LinkR\x05linksB\b\n" +
 	"\x06_titleB\n" +
 	"\n" +
 	"\b_taglineB\v\n" +
-	"\t_overview\"E\n" +
+	"\t_overviewB\r\n" +
+	"\v_collection\"d\n" +
 	"\x04Link\x12\x12\n" +
 	"\x04href\x18\x01 \x01(\tR\x04href\x12\x1d\n" +
 	"\adisplay\x18\n" +
-	" \x01(\tH\x00R\adisplay\x88\x01\x01B\n" +
+	" \x01(\tH\x00R\adisplay\x88\x01\x01\x12\x15\n" +
+	"\x03rel\x18\v \x01(\tH\x01R\x03rel\x88\x01\x01B\n" +
 	"\n" +
-	"\b_display\";\n" +
+	"\b_displayB\x06\n" +
+	"\x04_rel\"\x80\a\n" +
 	"\x0eShowtimeConfig\x12)\n" +
-	"\x04tmdb\x18\x01 \x01(\v2\x15.showtimes.TMDBConfigR\x04tmdb\"%\n" +
+	"\x04tmdb\x18\x01 \x01(\v2\x15.showtimes.TMDBConfigR\x04tmdb\x12{\n" +
+	"\blog_http\x18\x02 \x01(\bB`\x92\xb5\x18\\\n" +
+	"\blog-http\x1aPLog each scraper HTTP request's method, URL, status, and duration at debug levelR\alogHttp\x12\x9b\x01\n" +
+	"\rcheck_tickets\x18\x03 \x01(\bBv\x92\xb5\x18r\n" +
+	"\rcheck-tickets\x1aaFetch each showtime's booking page to detect sold-out screenings (one extra request per showtime)R\fcheckTickets\x12\xc9\x01\n" +
+	"\x0efetch_synopses\x18\x04 \x01(\bB\xa1\x01\x92\xb5\x18\x9c\x01\n" +
+	"\x0efetch-synopses\x1a\x89\x01Replace descriptions that only repeat the title with the film page's synopsis, else TMDB's overview (one extra request per such showtime)R\rfetchSynopses\x12\xcb\x01\n" +
+	"\n" +
+	"no_browser\x18\x05 \x01(\bB\xab\x01\x92\xb5\x18\xa6\x01\n" +
+	"\n" +
+	"no-browser\x1a\x97\x01Never launch chrome (also PDX_WATCHER_NO_BROWSER=true); browser theaters use HTTP where they can, else report an error and are skipped when listing allR\tnoBrowser\x12=\n" +
+	"\x06groups\x18\x06 \x03(\v2%.showtimes.ShowtimeConfig.GroupsEntryR\x06groups\x1aO\n" +
+	"\vGroupsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.showtimes.SiteGroupR\x05value:\x028\x01\"5\n" +
+	"\tSiteGroup\x12(\n" +
+	"\x05sites\x18\x01 \x03(\x0e2\x12.showtimes.PdxSiteR\x05sites\"B\n" +
 	"\n" +
 	"TMDBConfig\x12\x17\n" +
-	"\aapi_key\x18\x01 \x01(\tR\x06apiKey*\x80\x01\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x1b\n" +
+	"\tcache_dir\x18\x02 \x01(\tR\bcacheDir*\x80\x01\n" +
 	"\aPdxSite\x12\b\n" +
 	"\x04None\x10\x00\x12-\n" +
 	"\x10HollywoodTheatre\x10\x01\x1a\x17\xa2\xb5\x18\x13\n" +
@@ -711,39 +1370,46 @@ func file_showtimes_proto_rawDescGZIP() []byte {
 }
 
 var file_showtimes_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_showtimes_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_showtimes_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_showtimes_proto_goTypes = []any{
 	(PdxSite)(0),                  // 0: showtimes.PdxSite
 	(*ListShowtimesRequest)(nil),  // 1: showtimes.ListShowtimesRequest
 	(*ListShowtimesResponse)(nil), // 2: showtimes.ListShowtimesResponse
-	(*Showtime)(nil),              // 3: showtimes.Showtime
-	(*ScreeningInfo)(nil),         // 4: showtimes.ScreeningInfo
-	(*MovieInfo)(nil),             // 5: showtimes.MovieInfo
-	(*Link)(nil),                  // 6: showtimes.Link
-	(*ShowtimeConfig)(nil),        // 7: showtimes.ShowtimeConfig
-	(*TMDBConfig)(nil),            // 8: showtimes.TMDBConfig
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*MatchCandidate)(nil),        // 3: showtimes.MatchCandidate
+	(*Showtime)(nil),              // 4: showtimes.Showtime
+	(*ScreeningInfo)(nil),         // 5: showtimes.ScreeningInfo
+	(*MovieInfo)(nil),             // 6: showtimes.MovieInfo
+	(*Link)(nil),                  // 7: showtimes.Link
+	(*ShowtimeConfig)(nil),        // 8: showtimes.ShowtimeConfig
+	(*SiteGroup)(nil),             // 9: showtimes.SiteGroup
+	(*TMDBConfig)(nil),            // 10: showtimes.TMDBConfig
+	nil,                           // 11: showtimes.ShowtimeConfig.GroupsEntry
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
 }
 var file_showtimes_proto_depIdxs = []int32{
 	0,  // 0: showtimes.ListShowtimesRequest.from:type_name -> showtimes.PdxSite
-	9,  // 1: showtimes.ListShowtimesRequest.after:type_name -> google.protobuf.Timestamp
-	9,  // 2: showtimes.ListShowtimesRequest.before:type_name -> google.protobuf.Timestamp
-	3,  // 3: showtimes.ListShowtimesResponse.showtime:type_name -> showtimes.Showtime
+	12, // 1: showtimes.ListShowtimesRequest.after:type_name -> google.protobuf.Timestamp
+	12, // 2: showtimes.ListShowtimesRequest.before:type_name -> google.protobuf.Timestamp
+	4,  // 3: showtimes.ListShowtimesResponse.showtime:type_name -> showtimes.Showtime
 	0,  // 4: showtimes.ListShowtimesResponse.site:type_name -> showtimes.PdxSite
-	9,  // 5: showtimes.Showtime.start_time:type_name -> google.protobuf.Timestamp
-	9,  // 6: showtimes.Showtime.end_time:type_name -> google.protobuf.Timestamp
-	4,  // 7: showtimes.Showtime.screening:type_name -> showtimes.ScreeningInfo
-	5,  // 8: showtimes.Showtime.movie:type_name -> showtimes.MovieInfo
-	6,  // 9: showtimes.ScreeningInfo.links:type_name -> showtimes.Link
-	6,  // 10: showtimes.MovieInfo.links:type_name -> showtimes.Link
-	8,  // 11: showtimes.ShowtimeConfig.tmdb:type_name -> showtimes.TMDBConfig
-	1,  // 12: showtimes.ShowtimeService.ListShowtimes:input_type -> showtimes.ListShowtimesRequest
-	2,  // 13: showtimes.ShowtimeService.ListShowtimes:output_type -> showtimes.ListShowtimesResponse
-	13, // [13:14] is the sub-list for method output_type
-	12, // [12:13] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	3,  // 5: showtimes.ListShowtimesResponse.match_candidates:type_name -> showtimes.MatchCandidate
+	12, // 6: showtimes.Showtime.start_time:type_name -> google.protobuf.Timestamp
+	12, // 7: showtimes.Showtime.end_time:type_name -> google.protobuf.Timestamp
+	5,  // 8: showtimes.Showtime.screening:type_name -> showtimes.ScreeningInfo
+	6,  // 9: showtimes.Showtime.movie:type_name -> showtimes.MovieInfo
+	7,  // 10: showtimes.ScreeningInfo.links:type_name -> showtimes.Link
+	7,  // 11: showtimes.MovieInfo.links:type_name -> showtimes.Link
+	10, // 12: showtimes.ShowtimeConfig.tmdb:type_name -> showtimes.TMDBConfig
+	11, // 13: showtimes.ShowtimeConfig.groups:type_name -> showtimes.ShowtimeConfig.GroupsEntry
+	0,  // 14: showtimes.SiteGroup.sites:type_name -> showtimes.PdxSite
+	9,  // 15: showtimes.ShowtimeConfig.GroupsEntry.value:type_name -> showtimes.SiteGroup
+	1,  // 16: showtimes.ShowtimeService.ListShowtimes:input_type -> showtimes.ListShowtimesRequest
+	2,  // 17: showtimes.ShowtimeService.ListShowtimes:output_type -> showtimes.ListShowtimesResponse
+	17, // [17:18] is the sub-list for method output_type
+	16, // [16:17] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_showtimes_proto_init() }
@@ -757,13 +1423,14 @@ func file_showtimes_proto_init() {
 	file_showtimes_proto_msgTypes[3].OneofWrappers = []any{}
 	file_showtimes_proto_msgTypes[4].OneofWrappers = []any{}
 	file_showtimes_proto_msgTypes[5].OneofWrappers = []any{}
+	file_showtimes_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_showtimes_proto_rawDesc), len(file_showtimes_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   8,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
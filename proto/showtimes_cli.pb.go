@@ -141,17 +141,17 @@ func ShowtimeServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
 		DefaultText: "SITE",
 		Name:        "from",
-		Usage:       "Theater(s) to list showtimes from (hollywood-theatre, cinemagic, cinema21). Repeat for multiple; omit for all. [hollywood-theatre|cinemagic|cinema21]",
+		Usage:       "Theater(s) or configured group(s) to list showtimes from (hollywood-theatre, cinemagic, cinema21). Repeat for multiple; omit for all. [hollywood-theatre|cinemagic|cinema21]",
 	})
 	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
 		DefaultText: "TIME",
 		Name:        "after",
-		Usage:       "Only showtimes after this time (RFC3339)",
+		Usage:       "Only showtimes after this time (RFC3339 or YYYY-MM-DD; default: $PDX_WATCHER_AFTER)",
 	})
 	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
 		DefaultText: "TIME",
 		Name:        "before",
-		Usage:       "Only showtimes before this time (RFC3339)",
+		Usage:       "Only showtimes before this time (RFC3339 or YYYY-MM-DD; default: $PDX_WATCHER_BEFORE)",
 	})
 	flags_list_showtimes = append(flags_list_showtimes, &v3.Int32Flag{
 		DefaultText: "N",
@@ -168,8 +168,154 @@ func ShowtimeServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:        "timezone",
 		Usage:       "Display times in this IANA timezone (e.g. America/Los_Angeles). Default: CLI local time",
 	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "explain",
+		Usage: "Show the TMDB candidates considered for each showtime with their director-match and runtime-diff scores",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "refresh",
+		Usage: "Scrape fresh results and replace the cached entry with them",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "progress",
+		Usage: "Print scrape progress (e.g. \"Cinemagic: fetched 12/52 dates\") to stderr",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "DATE",
+		Name:        "date",
+		Usage:       "List showtimes for a single day (YYYY-MM-DD) in the output timezone (not with --after, --before, or --since-last)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.Int32Flag{
+		DefaultText: "N",
+		Name:        "limit-per-day",
+		Usage:       "Keep at most N showtimes per calendar day in the output timezone (balanced multi-day view)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "TITLE",
+		Name:        "exclude-title",
+		Usage:       "Hide showtimes with this title (case-insensitive); pass multiple times",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "ID",
+		Name:        "exclude-id",
+		Usage:       "Hide the showtime with this id; pass multiple times",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "since-last",
+		Usage: "Only list showtimes after the previous --since-last run (tracked in --state-file; not with --date or --upcoming)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "PATH",
+		Name:        "state-file",
+		Usage:       "State file for --since-last (default: <user config dir>/pdx-watcher/since-last)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "trace",
+		Usage: "Print enrichment cache stats (searches, cache hits, details calls, hit rate) to stderr when done",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "upcoming",
+		Usage: "Only list showtimes from now forward (not with --after, --date, or a range shortcut)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "LAT,LON",
+		Name:        "near",
+		Usage:       "Only list showtimes at venues near this point (requires --radius)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.Float64Flag{
+		DefaultText: "KM",
+		Name:        "radius",
+		Usage:       "Radius in kilometers for --near",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "no-subhed",
+		Usage: "Don't append the screening subhed (e.g. \"in 35mm\") to summaries",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "prefer-listed-title",
+		Usage: "Use the theater's advertised title as the summary instead of TMDB's",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "STYLE",
+		Name:        "summary-style",
+		Usage:       "How to compose summaries: title, title-subhed (e.g. \"Heat - in 35mm\"; default), or series-title (e.g. \"70mm: Heat\")",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "strip-html",
+		Usage: "Reduce descriptions to plain text, removing tags and decoding entities like &amp; (default: true; --strip-html=false passes them through)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "explain-skips",
+		Usage: "Print how many listings each site skipped and why (before --after, after --before, unparseable) to stderr when done",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "GROUP",
+		Name:        "from-group",
+		Usage:       "Site group(s) defined under groups in the config to list showtimes from (--from accepts group names too)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "DURATION",
+		Name:        "deadline",
+		Usage:       "Stop scraping after this long (e.g. 10s); sites that haven't finished contribute what they have and are logged as incomplete",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "bookable",
+		Usage: "Only list showtimes that have a link to buy tickets",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "FIELD",
+		Name:        "group-by",
+		Usage:       "Cluster showtimes under headers, each sorted by time: series (e.g. all \"70mm\" screenings together; \"No series\" for the rest)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "DURATION",
+		Name:        "max-range",
+		Usage:       "Longest time range to list (e.g. 90d or 2160h; default 367d); a wider --after/--before range is shortened to it with a warning",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "PATH",
+		Name:        "from-file",
+		Usage:       "Read sites or groups to list from, one per line (blank lines and # comments ignored), in addition to --from",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "FEATURE",
+		Name:        "accessibility",
+		Usage:       "Only list screenings with this access feature: open-captions, audio-description, or sensory-friendly; pass multiple times to accept any of them",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "free",
+		Usage: "Only list screenings with free admission",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "today",
+		Usage: "List showtimes for the current day in the output timezone (can't be combined with --after/--before)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "tomorrow",
+		Usage: "List showtimes for the next day in the output timezone (can't be combined with --after/--before)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "this-week",
+		Usage: "List showtimes from the start of today through Sunday in the output timezone (can't be combined with --after/--before)",
+	})
 
 	// Add config field flags for single-command mode
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "log-http",
+		Usage: "Log each scraper HTTP request's method, URL, status, and duration at debug level",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "check-tickets",
+		Usage: "Fetch each showtime's booking page to detect sold-out screenings (one extra request per showtime)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "fetch-synopses",
+		Usage: "Replace descriptions that only repeat the title with the film page's synopsis, else TMDB's overview (one extra request per such showtime)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "no-browser",
+		Usage: "Never launch chrome (also PDX_WATCHER_NO_BROWSER=true); browser theaters use HTTP where they can, else report an error and are skipped when listing all",
+	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
@@ -266,6 +412,118 @@ func ShowtimeServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 					val := cmd.String("output-timezone")
 					req.OutputTimezone = &val
 				}
+				if cmd.IsSet("explain") {
+					val := cmd.Bool("explain")
+					req.Explain = &val
+				}
+				if cmd.IsSet("refresh") {
+					val := cmd.Bool("refresh")
+					req.Refresh = &val
+				}
+				if cmd.IsSet("progress") {
+					val := cmd.Bool("progress")
+					req.Progress = &val
+				}
+				if cmd.IsSet("date") {
+					val := cmd.String("date")
+					req.Date = &val
+				}
+				if cmd.IsSet("limit-per-day") {
+					val := cmd.Int32("limit-per-day")
+					req.LimitPerDay = &val
+				}
+				if cmd.IsSet("exclude-title") {
+					req.ExcludeTitle = cmd.StringSlice("exclude-title")
+				}
+				if cmd.IsSet("exclude-id") {
+					req.ExcludeId = cmd.StringSlice("exclude-id")
+				}
+				if cmd.IsSet("since-last") {
+					val := cmd.Bool("since-last")
+					req.SinceLast = &val
+				}
+				if cmd.IsSet("state-file") {
+					val := cmd.String("state-file")
+					req.StateFile = &val
+				}
+				if cmd.IsSet("trace") {
+					val := cmd.Bool("trace")
+					req.Trace = &val
+				}
+				if cmd.IsSet("upcoming") {
+					val := cmd.Bool("upcoming")
+					req.Upcoming = &val
+				}
+				if cmd.IsSet("near") {
+					val := cmd.String("near")
+					req.Near = &val
+				}
+				if cmd.IsSet("radius-km") {
+					val := cmd.Float64("radius-km")
+					req.RadiusKm = &val
+				}
+				if cmd.IsSet("no-subhed") {
+					val := cmd.Bool("no-subhed")
+					req.NoSubhed = &val
+				}
+				if cmd.IsSet("prefer-listed-title") {
+					val := cmd.Bool("prefer-listed-title")
+					req.PreferListedTitle = &val
+				}
+				if cmd.IsSet("summary-style") {
+					val := cmd.String("summary-style")
+					req.SummaryStyle = &val
+				}
+				if cmd.IsSet("strip-html") {
+					val := cmd.Bool("strip-html")
+					req.StripHtml = &val
+				}
+				if cmd.IsSet("explain-skips") {
+					val := cmd.Bool("explain-skips")
+					req.ExplainSkips = &val
+				}
+				if cmd.IsSet("from-group") {
+					req.FromGroup = cmd.StringSlice("from-group")
+				}
+				if cmd.IsSet("deadline") {
+					val := cmd.String("deadline")
+					req.Deadline = &val
+				}
+				if cmd.IsSet("bookable") {
+					val := cmd.Bool("bookable")
+					req.Bookable = &val
+				}
+				if cmd.IsSet("group-by") {
+					val := cmd.String("group-by")
+					req.GroupBy = &val
+				}
+				if cmd.IsSet("max-range") {
+					val := cmd.String("max-range")
+					req.MaxRange = &val
+				}
+				if cmd.IsSet("from-file") {
+					val := cmd.String("from-file")
+					req.FromFile = &val
+				}
+				if cmd.IsSet("accessibility") {
+					req.Accessibility = cmd.StringSlice("accessibility")
+				}
+				if cmd.IsSet("free") {
+					val := cmd.Bool("free")
+					req.Free = &val
+				}
+				if cmd.IsSet("today") {
+					val := cmd.Bool("today")
+					req.Today = &val
+				}
+				if cmd.IsSet("tomorrow") {
+					val := cmd.Bool("tomorrow")
+					req.Tomorrow = &val
+				}
+				if cmd.IsSet("this-week") {
+					val := cmd.Bool("this-week")
+					req.ThisWeek = &val
+				}
 			} else {
 				// Check for custom flag deserializer for showtimes.ListShowtimesRequest
 				deserializer, hasDeserializer := options.FlagDeserializer("showtimes.ListShowtimesRequest")
@@ -354,6 +612,110 @@ func ShowtimeServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 						val := cmd.String("output-timezone")
 						req.OutputTimezone = &val
 					}
+					if cmd.IsSet("explain") {
+						val := cmd.Bool("explain")
+						req.Explain = &val
+					}
+					if cmd.IsSet("refresh") {
+						val := cmd.Bool("refresh")
+						req.Refresh = &val
+					}
+					if cmd.IsSet("progress") {
+						val := cmd.Bool("progress")
+						req.Progress = &val
+					}
+					if cmd.IsSet("date") {
+						val := cmd.String("date")
+						req.Date = &val
+					}
+					if cmd.IsSet("limit-per-day") {
+						val := cmd.Int32("limit-per-day")
+						req.LimitPerDay = &val
+					}
+					req.ExcludeTitle = cmd.StringSlice("exclude-title")
+					req.ExcludeId = cmd.StringSlice("exclude-id")
+					if cmd.IsSet("since-last") {
+						val := cmd.Bool("since-last")
+						req.SinceLast = &val
+					}
+					if cmd.IsSet("state-file") {
+						val := cmd.String("state-file")
+						req.StateFile = &val
+					}
+					if cmd.IsSet("trace") {
+						val := cmd.Bool("trace")
+						req.Trace = &val
+					}
+					if cmd.IsSet("upcoming") {
+						val := cmd.Bool("upcoming")
+						req.Upcoming = &val
+					}
+					if cmd.IsSet("near") {
+						val := cmd.String("near")
+						req.Near = &val
+					}
+					if cmd.IsSet("radius-km") {
+						val := cmd.Float64("radius-km")
+						req.RadiusKm = &val
+					}
+					if cmd.IsSet("no-subhed") {
+						val := cmd.Bool("no-subhed")
+						req.NoSubhed = &val
+					}
+					if cmd.IsSet("prefer-listed-title") {
+						val := cmd.Bool("prefer-listed-title")
+						req.PreferListedTitle = &val
+					}
+					if cmd.IsSet("summary-style") {
+						val := cmd.String("summary-style")
+						req.SummaryStyle = &val
+					}
+					if cmd.IsSet("strip-html") {
+						val := cmd.Bool("strip-html")
+						req.StripHtml = &val
+					}
+					if cmd.IsSet("explain-skips") {
+						val := cmd.Bool("explain-skips")
+						req.ExplainSkips = &val
+					}
+					req.FromGroup = cmd.StringSlice("from-group")
+					if cmd.IsSet("deadline") {
+						val := cmd.String("deadline")
+						req.Deadline = &val
+					}
+					if cmd.IsSet("bookable") {
+						val := cmd.Bool("bookable")
+						req.Bookable = &val
+					}
+					if cmd.IsSet("group-by") {
+						val := cmd.String("group-by")
+						req.GroupBy = &val
+					}
+					if cmd.IsSet("max-range") {
+						val := cmd.String("max-range")
+						req.MaxRange = &val
+					}
+					if cmd.IsSet("from-file") {
+						val := cmd.String("from-file")
+						req.FromFile = &val
+					}
+					req.Accessibility = cmd.StringSlice("accessibility")
+					if cmd.IsSet("free") {
+						val := cmd.Bool("free")
+						req.Free = &val
+					}
+					if cmd.IsSet("today") {
+						val := cmd.Bool("today")
+						req.Today = &val
+					}
+					if cmd.IsSet("tomorrow") {
+						val := cmd.Bool("tomorrow")
+						req.Tomorrow = &val
+					}
+					if cmd.IsSet("this-week") {
+						val := cmd.Bool("this-week")
+						req.ThisWeek = &val
+					}
 				}
 			}
 
@@ -568,17 +930,17 @@ func ShowtimeServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
 		DefaultText: "SITE",
 		Name:        "from",
-		Usage:       "Theater(s) to list showtimes from (hollywood-theatre, cinemagic, cinema21). Repeat for multiple; omit for all. [hollywood-theatre|cinemagic|cinema21]",
+		Usage:       "Theater(s) or configured group(s) to list showtimes from (hollywood-theatre, cinemagic, cinema21). Repeat for multiple; omit for all. [hollywood-theatre|cinemagic|cinema21]",
 	})
 	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
 		DefaultText: "TIME",
 		Name:        "after",
-		Usage:       "Only showtimes after this time (RFC3339)",
+		Usage:       "Only showtimes after this time (RFC3339 or YYYY-MM-DD; default: $PDX_WATCHER_AFTER)",
 	})
 	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
 		DefaultText: "TIME",
 		Name:        "before",
-		Usage:       "Only showtimes before this time (RFC3339)",
+		Usage:       "Only showtimes before this time (RFC3339 or YYYY-MM-DD; default: $PDX_WATCHER_BEFORE)",
 	})
 	flags_list_showtimes = append(flags_list_showtimes, &v3.Int32Flag{
 		DefaultText: "N",
@@ -595,8 +957,154 @@ func ShowtimeServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:        "timezone",
 		Usage:       "Display times in this IANA timezone (e.g. America/Los_Angeles). Default: CLI local time",
 	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "explain",
+		Usage: "Show the TMDB candidates considered for each showtime with their director-match and runtime-diff scores",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "refresh",
+		Usage: "Scrape fresh results and replace the cached entry with them",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "progress",
+		Usage: "Print scrape progress (e.g. \"Cinemagic: fetched 12/52 dates\") to stderr",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "DATE",
+		Name:        "date",
+		Usage:       "List showtimes for a single day (YYYY-MM-DD) in the output timezone (not with --after, --before, or --since-last)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.Int32Flag{
+		DefaultText: "N",
+		Name:        "limit-per-day",
+		Usage:       "Keep at most N showtimes per calendar day in the output timezone (balanced multi-day view)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "TITLE",
+		Name:        "exclude-title",
+		Usage:       "Hide showtimes with this title (case-insensitive); pass multiple times",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "ID",
+		Name:        "exclude-id",
+		Usage:       "Hide the showtime with this id; pass multiple times",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "since-last",
+		Usage: "Only list showtimes after the previous --since-last run (tracked in --state-file; not with --date or --upcoming)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "PATH",
+		Name:        "state-file",
+		Usage:       "State file for --since-last (default: <user config dir>/pdx-watcher/since-last)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "trace",
+		Usage: "Print enrichment cache stats (searches, cache hits, details calls, hit rate) to stderr when done",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "upcoming",
+		Usage: "Only list showtimes from now forward (not with --after, --date, or a range shortcut)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "LAT,LON",
+		Name:        "near",
+		Usage:       "Only list showtimes at venues near this point (requires --radius)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.Float64Flag{
+		DefaultText: "KM",
+		Name:        "radius",
+		Usage:       "Radius in kilometers for --near",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "no-subhed",
+		Usage: "Don't append the screening subhed (e.g. \"in 35mm\") to summaries",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "prefer-listed-title",
+		Usage: "Use the theater's advertised title as the summary instead of TMDB's",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "STYLE",
+		Name:        "summary-style",
+		Usage:       "How to compose summaries: title, title-subhed (e.g. \"Heat - in 35mm\"; default), or series-title (e.g. \"70mm: Heat\")",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "strip-html",
+		Usage: "Reduce descriptions to plain text, removing tags and decoding entities like &amp; (default: true; --strip-html=false passes them through)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "explain-skips",
+		Usage: "Print how many listings each site skipped and why (before --after, after --before, unparseable) to stderr when done",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "GROUP",
+		Name:        "from-group",
+		Usage:       "Site group(s) defined under groups in the config to list showtimes from (--from accepts group names too)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "DURATION",
+		Name:        "deadline",
+		Usage:       "Stop scraping after this long (e.g. 10s); sites that haven't finished contribute what they have and are logged as incomplete",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "bookable",
+		Usage: "Only list showtimes that have a link to buy tickets",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "FIELD",
+		Name:        "group-by",
+		Usage:       "Cluster showtimes under headers, each sorted by time: series (e.g. all \"70mm\" screenings together; \"No series\" for the rest)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "DURATION",
+		Name:        "max-range",
+		Usage:       "Longest time range to list (e.g. 90d or 2160h; default 367d); a wider --after/--before range is shortened to it with a warning",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringFlag{
+		DefaultText: "PATH",
+		Name:        "from-file",
+		Usage:       "Read sites or groups to list from, one per line (blank lines and # comments ignored), in addition to --from",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.StringSliceFlag{
+		DefaultText: "FEATURE",
+		Name:        "accessibility",
+		Usage:       "Only list screenings with this access feature: open-captions, audio-description, or sensory-friendly; pass multiple times to accept any of them",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "free",
+		Usage: "Only list screenings with free admission",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "today",
+		Usage: "List showtimes for the current day in the output timezone (can't be combined with --after/--before)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "tomorrow",
+		Usage: "List showtimes for the next day in the output timezone (can't be combined with --after/--before)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "this-week",
+		Usage: "List showtimes from the start of today through Sunday in the output timezone (can't be combined with --after/--before)",
+	})
 
 	// Add config field flags for single-command mode
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "log-http",
+		Usage: "Log each scraper HTTP request's method, URL, status, and duration at debug level",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "check-tickets",
+		Usage: "Fetch each showtime's booking page to detect sold-out screenings (one extra request per showtime)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "fetch-synopses",
+		Usage: "Replace descriptions that only repeat the title with the film page's synopsis, else TMDB's overview (one extra request per such showtime)",
+	})
+	flags_list_showtimes = append(flags_list_showtimes, &v3.BoolFlag{
+		Name:  "no-browser",
+		Usage: "Never launch chrome (also PDX_WATCHER_NO_BROWSER=true); browser theaters use HTTP where they can, else report an error and are skipped when listing all",
+	})
 
 	// Add format-specific flags from registered formats
 	for _, outputFmt := range options.OutputFormats() {
@@ -693,6 +1201,118 @@ func ShowtimeServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 					val := cmd.String("output-timezone")
 					req.OutputTimezone = &val
 				}
+				if cmd.IsSet("explain") {
+					val := cmd.Bool("explain")
+					req.Explain = &val
+				}
+				if cmd.IsSet("refresh") {
+					val := cmd.Bool("refresh")
+					req.Refresh = &val
+				}
+				if cmd.IsSet("progress") {
+					val := cmd.Bool("progress")
+					req.Progress = &val
+				}
+				if cmd.IsSet("date") {
+					val := cmd.String("date")
+					req.Date = &val
+				}
+				if cmd.IsSet("limit-per-day") {
+					val := cmd.Int32("limit-per-day")
+					req.LimitPerDay = &val
+				}
+				if cmd.IsSet("exclude-title") {
+					req.ExcludeTitle = cmd.StringSlice("exclude-title")
+				}
+				if cmd.IsSet("exclude-id") {
+					req.ExcludeId = cmd.StringSlice("exclude-id")
+				}
+				if cmd.IsSet("since-last") {
+					val := cmd.Bool("since-last")
+					req.SinceLast = &val
+				}
+				if cmd.IsSet("state-file") {
+					val := cmd.String("state-file")
+					req.StateFile = &val
+				}
+				if cmd.IsSet("trace") {
+					val := cmd.Bool("trace")
+					req.Trace = &val
+				}
+				if cmd.IsSet("upcoming") {
+					val := cmd.Bool("upcoming")
+					req.Upcoming = &val
+				}
+				if cmd.IsSet("near") {
+					val := cmd.String("near")
+					req.Near = &val
+				}
+				if cmd.IsSet("radius-km") {
+					val := cmd.Float64("radius-km")
+					req.RadiusKm = &val
+				}
+				if cmd.IsSet("no-subhed") {
+					val := cmd.Bool("no-subhed")
+					req.NoSubhed = &val
+				}
+				if cmd.IsSet("prefer-listed-title") {
+					val := cmd.Bool("prefer-listed-title")
+					req.PreferListedTitle = &val
+				}
+				if cmd.IsSet("summary-style") {
+					val := cmd.String("summary-style")
+					req.SummaryStyle = &val
+				}
+				if cmd.IsSet("strip-html") {
+					val := cmd.Bool("strip-html")
+					req.StripHtml = &val
+				}
+				if cmd.IsSet("explain-skips") {
+					val := cmd.Bool("explain-skips")
+					req.ExplainSkips = &val
+				}
+				if cmd.IsSet("from-group") {
+					req.FromGroup = cmd.StringSlice("from-group")
+				}
+				if cmd.IsSet("deadline") {
+					val := cmd.String("deadline")
+					req.Deadline = &val
+				}
+				if cmd.IsSet("bookable") {
+					val := cmd.Bool("bookable")
+					req.Bookable = &val
+				}
+				if cmd.IsSet("group-by") {
+					val := cmd.String("group-by")
+					req.GroupBy = &val
+				}
+				if cmd.IsSet("max-range") {
+					val := cmd.String("max-range")
+					req.MaxRange = &val
+				}
+				if cmd.IsSet("from-file") {
+					val := cmd.String("from-file")
+					req.FromFile = &val
+				}
+				if cmd.IsSet("accessibility") {
+					req.Accessibility = cmd.StringSlice("accessibility")
+				}
+				if cmd.IsSet("free") {
+					val := cmd.Bool("free")
+					req.Free = &val
+				}
+				if cmd.IsSet("today") {
+					val := cmd.Bool("today")
+					req.Today = &val
+				}
+				if cmd.IsSet("tomorrow") {
+					val := cmd.Bool("tomorrow")
+					req.Tomorrow = &val
+				}
+				if cmd.IsSet("this-week") {
+					val := cmd.Bool("this-week")
+					req.ThisWeek = &val
+				}
 			} else {
 				// Check for custom flag deserializer for showtimes.ListShowtimesRequest
 				deserializer, hasDeserializer := options.FlagDeserializer("showtimes.ListShowtimesRequest")
@@ -781,6 +1401,110 @@ func ShowtimeServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 						val := cmd.String("output-timezone")
 						req.OutputTimezone = &val
 					}
+					if cmd.IsSet("explain") {
+						val := cmd.Bool("explain")
+						req.Explain = &val
+					}
+					if cmd.IsSet("refresh") {
+						val := cmd.Bool("refresh")
+						req.Refresh = &val
+					}
+					if cmd.IsSet("progress") {
+						val := cmd.Bool("progress")
+						req.Progress = &val
+					}
+					if cmd.IsSet("date") {
+						val := cmd.String("date")
+						req.Date = &val
+					}
+					if cmd.IsSet("limit-per-day") {
+						val := cmd.Int32("limit-per-day")
+						req.LimitPerDay = &val
+					}
+					req.ExcludeTitle = cmd.StringSlice("exclude-title")
+					req.ExcludeId = cmd.StringSlice("exclude-id")
+					if cmd.IsSet("since-last") {
+						val := cmd.Bool("since-last")
+						req.SinceLast = &val
+					}
+					if cmd.IsSet("state-file") {
+						val := cmd.String("state-file")
+						req.StateFile = &val
+					}
+					if cmd.IsSet("trace") {
+						val := cmd.Bool("trace")
+						req.Trace = &val
+					}
+					if cmd.IsSet("upcoming") {
+						val := cmd.Bool("upcoming")
+						req.Upcoming = &val
+					}
+					if cmd.IsSet("near") {
+						val := cmd.String("near")
+						req.Near = &val
+					}
+					if cmd.IsSet("radius-km") {
+						val := cmd.Float64("radius-km")
+						req.RadiusKm = &val
+					}
+					if cmd.IsSet("no-subhed") {
+						val := cmd.Bool("no-subhed")
+						req.NoSubhed = &val
+					}
+					if cmd.IsSet("prefer-listed-title") {
+						val := cmd.Bool("prefer-listed-title")
+						req.PreferListedTitle = &val
+					}
+					if cmd.IsSet("summary-style") {
+						val := cmd.String("summary-style")
+						req.SummaryStyle = &val
+					}
+					if cmd.IsSet("strip-html") {
+						val := cmd.Bool("strip-html")
+						req.StripHtml = &val
+					}
+					if cmd.IsSet("explain-skips") {
+						val := cmd.Bool("explain-skips")
+						req.ExplainSkips = &val
+					}
+					req.FromGroup = cmd.StringSlice("from-group")
+					if cmd.IsSet("deadline") {
+						val := cmd.String("deadline")
+						req.Deadline = &val
+					}
+					if cmd.IsSet("bookable") {
+						val := cmd.Bool("bookable")
+						req.Bookable = &val
+					}
+					if cmd.IsSet("group-by") {
+						val := cmd.String("group-by")
+						req.GroupBy = &val
+					}
+					if cmd.IsSet("max-range") {
+						val := cmd.String("max-range")
+						req.MaxRange = &val
+					}
+					if cmd.IsSet("from-file") {
+						val := cmd.String("from-file")
+						req.FromFile = &val
+					}
+					req.Accessibility = cmd.StringSlice("accessibility")
+					if cmd.IsSet("free") {
+						val := cmd.Bool("free")
+						req.Free = &val
+					}
+					if cmd.IsSet("today") {
+						val := cmd.Bool("today")
+						req.Today = &val
+					}
+					if cmd.IsSet("tomorrow") {
+						val := cmd.Bool("tomorrow")
+						req.Tomorrow = &val
+					}
+					if cmd.IsSet("this-week") {
+						val := cmd.Bool("this-week")
+						req.ThisWeek = &val
+					}
 				}
 			}
 